@@ -0,0 +1,87 @@
+// Command aggregates rebuilds and verifies the monthly transaction aggregates
+// used by reports.
+//
+//	go run ./cmd/aggregates                  # rebuild all families, then verify
+//	go run ./cmd/aggregates -family <uuid>   # rebuild one family
+//	go run ./cmd/aggregates -check           # only compare aggregates with raw transactions
+//
+// Exits with status 1 when aggregates differ from raw transactions.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/google/uuid"
+
+	"github.com/DigitLock/expense-tracker/internal/config"
+	"github.com/DigitLock/expense-tracker/internal/database"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+// maxReportedMismatches limits the mismatches printed by a check
+const maxReportedMismatches = 50
+
+func main() {
+	familyFlag := flag.String("family", "", "Family ID (default: all families)")
+	checkOnly := flag.Bool("check", false, "Only check aggregates against raw transactions, do not rebuild")
+	flag.Parse()
+
+	familyID := uuid.Nil
+	if *familyFlag != "" {
+		parsed, err := uuid.Parse(*familyFlag)
+		if err != nil {
+			log.Fatalf("❌ Invalid family ID: %v", err)
+		}
+		familyID = parsed
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("❌ Failed to load config: %v", err)
+	}
+
+	db, err := database.New(ctx, cfg.Database)
+	if err != nil {
+		log.Fatalf("❌ Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	repos := repository.New(db.Pool)
+
+	if !*checkOnly {
+		rows, err := repos.Aggregates.Rebuild(ctx, familyID)
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		log.Printf("✅ Rebuilt %d monthly aggregate rows", rows)
+	}
+
+	mismatches, err := repos.Aggregates.ListMismatches(ctx, familyID)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+	if len(mismatches) == 0 {
+		log.Println("✅ Monthly aggregates match raw transactions")
+		return
+	}
+
+	for i, m := range mismatches {
+		if i == maxReportedMismatches {
+			log.Printf("... and %d more", len(mismatches)-maxReportedMismatches)
+			break
+		}
+		log.Printf("family %s, %s, category %s, %s %s: expected %d transactions / %s, aggregated %d / %s",
+			m.FamilyID, m.Month.Time.Format("2006-01"), m.CategoryID, m.Type, m.Currency,
+			m.ExpectedCount, m.ExpectedTotal.StringFixed(2), m.ActualCount, m.ActualTotal.StringFixed(2))
+	}
+	log.Printf("❌ %d monthly aggregates differ from raw transactions", len(mismatches))
+
+	// Deferred close is skipped by os.Exit
+	db.Close()
+	os.Exit(1)
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -11,6 +12,8 @@ import (
 	"github.com/DigitLock/expense-tracker/internal/api"
 	"github.com/DigitLock/expense-tracker/internal/config"
 	"github.com/DigitLock/expense-tracker/internal/database"
+	"github.com/DigitLock/expense-tracker/internal/exchangerates"
+	"github.com/DigitLock/expense-tracker/internal/jobs"
 	"github.com/DigitLock/expense-tracker/internal/repository"
 )
 
@@ -39,8 +42,27 @@ func main() {
 
 	// Initialize repositories
 	repos := repository.New(db.Pool)
+	repos.Categories.SetMaxDepth(cfg.Categories.MaxDepth)
 	log.Println("✅ Repositories initialized")
 
+	// Start background jobs
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+
+	backgroundJobs := []jobs.Job{jobs.NewInterestJob(repos.Interest)}
+	if chain := newRateProviderChain(cfg.ExchangeRates); chain.Len() > 0 {
+		backgroundJobs = append(backgroundJobs, jobs.NewExchangeRateJob(chain, repos.ExchangeRates, repos.Currencies))
+	}
+
+	scheduler := jobs.NewScheduler(
+		time.Duration(cfg.Jobs.IntervalMinutes)*time.Minute,
+		backgroundJobs...,
+	)
+	if cfg.Jobs.Enabled {
+		scheduler.Start(jobsCtx)
+		log.Printf("✅ Background jobs started (every %d min)", cfg.Jobs.IntervalMinutes)
+	}
+
 	// Setup router
 	router := api.NewRouter(cfg, db.Pool, repos)
 
@@ -59,6 +81,10 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
+	// Stop background jobs
+	stopJobs()
+	scheduler.Wait()
+
 	// Shutdown with timeout
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Server.ShutdownTimeout)*time.Second)
 	defer cancel()
@@ -69,3 +95,23 @@ func main() {
 
 	log.Println("✅ Server stopped gracefully")
 }
+
+// newRateProviderChain builds the exchange rate providers in configured fallback order
+func newRateProviderChain(cfg config.ExchangeRatesConfig) *exchangerates.Chain {
+	client := &http.Client{Timeout: time.Duration(cfg.TimeoutSeconds) * time.Second}
+
+	var providers []exchangerates.Provider
+	for _, name := range cfg.Providers {
+		switch name {
+		case exchangerates.SourceNBS:
+			if cfg.NBSURL == "" {
+				log.Println("⚠️  NBS_RATES_URL not set, skipping nbs exchange rate provider")
+				continue
+			}
+			providers = append(providers, exchangerates.NewNBSProvider(cfg.NBSURL, client))
+		case exchangerates.SourceECB:
+			providers = append(providers, exchangerates.NewECBProvider(cfg.ECBURL, client))
+		}
+	}
+	return exchangerates.NewChain(providers...)
+}
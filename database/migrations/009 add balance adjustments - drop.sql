@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_categories_family_system;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS is_system;
+
+COMMENT ON COLUMN accounts.initial_balance IS
+    'Starting balance when account was created. Does NOT change after creation. Used for balance calculations.';
+
+COMMIT;
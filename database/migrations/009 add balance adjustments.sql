@@ -0,0 +1,21 @@
+-- ============================================================================
+-- Migration: balance adjustments
+-- Purpose: System categories for balance corrections and editable initial balance
+-- ============================================================================
+
+BEGIN;
+
+ALTER TABLE categories
+    ADD COLUMN is_system BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX idx_categories_family_system
+    ON categories(family_id, type)
+    WHERE is_system = true;
+
+COMMENT ON COLUMN categories.is_system IS
+    'System category managed by the application (e.g. "Balance adjustment"). Cannot be edited or deleted by users. Transactions in system categories are excluded from spending reports.';
+
+COMMENT ON COLUMN accounts.initial_balance IS
+    'Starting balance when account was created. Can be corrected later; current_balance is recalculated and the change is recorded in audit_log.';
+
+COMMIT;
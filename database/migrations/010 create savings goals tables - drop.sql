@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_audit_savings_goals ON savings_goals;
+DROP TRIGGER IF EXISTS trigger_savings_goals_updated_at ON savings_goals;
+
+DROP TABLE IF EXISTS savings_goal_accounts CASCADE;
+DROP TABLE IF EXISTS savings_goals CASCADE;
+
+COMMIT;
@@ -0,0 +1,123 @@
+-- ============================================================================
+-- Table: savings_goals, savings_goal_accounts
+-- Purpose: Goal-oriented savings linked to accounts (BR-3, BR-12)
+-- ============================================================================
+
+BEGIN;
+
+CREATE TABLE savings_goals (
+                               id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                               family_id UUID NOT NULL,
+                               name VARCHAR(255) NOT NULL,
+                               target_amount DECIMAL(15, 2) NOT NULL,
+                               currency VARCHAR(3) NOT NULL,
+                               deadline DATE,
+                               description TEXT,
+                               created_by UUID,
+                               created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               is_active BOOLEAN NOT NULL DEFAULT true,
+
+                               CONSTRAINT fk_savings_goals_family
+                                   FOREIGN KEY (family_id)
+                                       REFERENCES families(id)
+                                       ON DELETE CASCADE,
+
+                               CONSTRAINT fk_savings_goals_created_by
+                                   FOREIGN KEY (created_by)
+                                       REFERENCES users(id)
+                                       ON DELETE SET NULL,
+
+                               CONSTRAINT savings_goals_target_positive
+                                   CHECK (target_amount > 0),
+
+                               CONSTRAINT savings_goals_currency_check
+                                   CHECK (currency IN ('RSD', 'EUR')),
+
+                               CONSTRAINT savings_goals_name_length
+                                   CHECK (LENGTH(name) >= 1)
+);
+
+CREATE INDEX idx_savings_goals_family
+    ON savings_goals(family_id);
+CREATE INDEX idx_savings_goals_family_active
+    ON savings_goals(family_id, is_active)
+    WHERE is_active = true;
+
+COMMENT ON TABLE savings_goals IS
+    'Savings goals of a family. Progress is calculated from balances of linked accounts.';
+COMMENT ON COLUMN savings_goals.id IS
+    'UUID primary key. Generated automatically.';
+COMMENT ON COLUMN savings_goals.family_id IS
+    'Foreign key to families. ON DELETE CASCADE.';
+COMMENT ON COLUMN savings_goals.name IS
+    'User-defined goal name. Examples: "Vacation", "New Car", "Emergency Fund"';
+COMMENT ON COLUMN savings_goals.target_amount IS
+    'Amount to be saved, in goal currency. Must be positive.';
+COMMENT ON COLUMN savings_goals.currency IS
+    'Goal currency. Balances of linked accounts are converted to it.';
+COMMENT ON COLUMN savings_goals.deadline IS
+    'Optional date by which the target should be reached. Used to calculate required monthly contribution.';
+COMMENT ON COLUMN savings_goals.description IS
+    'Optional description/notes about the goal';
+COMMENT ON COLUMN savings_goals.created_by IS
+    'User who created the goal. NULL if user was deleted.';
+COMMENT ON COLUMN savings_goals.created_at IS
+    'Timestamp when goal was created. Set automatically.';
+COMMENT ON COLUMN savings_goals.updated_at IS
+    'Timestamp of last update. Updated automatically by trigger.';
+COMMENT ON COLUMN savings_goals.is_active IS
+    'Soft delete flag. true = active goal, false = deleted goal (data preserved for history)';
+
+CREATE TRIGGER trigger_savings_goals_updated_at
+    BEFORE UPDATE ON savings_goals
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER trigger_audit_savings_goals
+    AFTER INSERT OR UPDATE OR DELETE ON savings_goals
+    FOR EACH ROW
+EXECUTE FUNCTION audit_trigger();
+
+COMMENT ON TRIGGER trigger_savings_goals_updated_at ON savings_goals IS
+    'Updates updated_at timestamp automatically on every UPDATE';
+COMMENT ON TRIGGER trigger_audit_savings_goals ON savings_goals IS
+    'Logs all changes to savings_goals table';
+
+CREATE TABLE savings_goal_accounts (
+                                       goal_id UUID NOT NULL,
+                                       account_id UUID NOT NULL,
+                                       earmarked_amount DECIMAL(15, 2),
+                                       created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                       PRIMARY KEY (goal_id, account_id),
+
+                                       CONSTRAINT fk_savings_goal_accounts_goal
+                                           FOREIGN KEY (goal_id)
+                                               REFERENCES savings_goals(id)
+                                               ON DELETE CASCADE,
+
+                                       CONSTRAINT fk_savings_goal_accounts_account
+                                           FOREIGN KEY (account_id)
+                                               REFERENCES accounts(id)
+                                               ON DELETE CASCADE,
+
+                                       CONSTRAINT savings_goal_accounts_earmarked_positive
+                                           CHECK (earmarked_amount IS NULL OR earmarked_amount > 0)
+);
+
+CREATE INDEX idx_savings_goal_accounts_account
+    ON savings_goal_accounts(account_id);
+
+COMMENT ON TABLE savings_goal_accounts IS
+    'Accounts linked to a savings goal. Either the whole account balance or an earmarked part of it counts towards the goal.';
+COMMENT ON COLUMN savings_goal_accounts.goal_id IS
+    'Foreign key to savings_goals. ON DELETE CASCADE.';
+COMMENT ON COLUMN savings_goal_accounts.account_id IS
+    'Foreign key to accounts. ON DELETE CASCADE.';
+COMMENT ON COLUMN savings_goal_accounts.earmarked_amount IS
+    'Part of the account balance reserved for the goal, in account currency. NULL = whole balance counts towards the goal.';
+COMMENT ON COLUMN savings_goal_accounts.created_at IS
+    'Timestamp when account was linked. Set automatically.';
+
+COMMIT;
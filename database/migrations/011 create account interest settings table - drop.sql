@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_audit_account_interest_settings ON account_interest_settings;
+DROP TRIGGER IF EXISTS trigger_account_interest_settings_updated_at ON account_interest_settings;
+
+DROP TABLE IF EXISTS account_interest_settings CASCADE;
+
+COMMIT;
@@ -0,0 +1,103 @@
+-- ============================================================================
+-- Table: account_interest_settings
+-- Purpose: Interest accrual configuration for savings accounts
+-- ============================================================================
+
+BEGIN;
+
+CREATE TABLE account_interest_settings (
+                                           id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                           account_id UUID NOT NULL,
+                                           family_id UUID NOT NULL,
+                                           annual_rate DECIMAL(7, 4) NOT NULL,
+                                           compounding VARCHAR(20) NOT NULL DEFAULT 'monthly',
+                                           payout_day SMALLINT NOT NULL DEFAULT 1,
+                                           withholding_tax_rate DECIMAL(5, 2) NOT NULL DEFAULT 0.00,
+                                           income_category_id UUID NOT NULL,
+                                           created_by UUID NOT NULL,
+                                           last_posted_date DATE,
+                                           created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                           updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                           CONSTRAINT fk_account_interest_settings_account
+                                               FOREIGN KEY (account_id)
+                                                   REFERENCES accounts(id)
+                                                   ON DELETE CASCADE,
+
+                                           CONSTRAINT fk_account_interest_settings_family
+                                               FOREIGN KEY (family_id)
+                                                   REFERENCES families(id)
+                                                   ON DELETE CASCADE,
+
+                                           CONSTRAINT fk_account_interest_settings_category
+                                               FOREIGN KEY (income_category_id)
+                                                   REFERENCES categories(id)
+                                                   ON DELETE RESTRICT,
+
+                                           CONSTRAINT fk_account_interest_settings_created_by
+                                               FOREIGN KEY (created_by)
+                                                   REFERENCES users(id)
+                                                   ON DELETE RESTRICT,
+
+                                           CONSTRAINT account_interest_settings_account_unique
+                                               UNIQUE (account_id),
+
+                                           CONSTRAINT account_interest_settings_rate_check
+                                               CHECK (annual_rate >= 0 AND annual_rate <= 100),
+
+                                           CONSTRAINT account_interest_settings_compounding_check
+                                               CHECK (compounding IN ('monthly', 'quarterly', 'annually')),
+
+                                           CONSTRAINT account_interest_settings_payout_day_check
+                                               CHECK (payout_day BETWEEN 1 AND 28),
+
+                                           CONSTRAINT account_interest_settings_tax_check
+                                               CHECK (withholding_tax_rate >= 0 AND withholding_tax_rate <= 100)
+);
+
+CREATE INDEX idx_account_interest_settings_family
+    ON account_interest_settings(family_id);
+
+COMMENT ON TABLE account_interest_settings IS
+    'Interest configuration for savings accounts. Interest income transactions are posted by a scheduled job.';
+COMMENT ON COLUMN account_interest_settings.id IS
+    'UUID primary key. Generated automatically.';
+COMMENT ON COLUMN account_interest_settings.account_id IS
+    'Foreign key to accounts. One configuration per account. ON DELETE CASCADE.';
+COMMENT ON COLUMN account_interest_settings.family_id IS
+    'Foreign key to families. ON DELETE CASCADE.';
+COMMENT ON COLUMN account_interest_settings.annual_rate IS
+    'Nominal annual interest rate in percent. Example: 3.5000 = 3.5% p.a.';
+COMMENT ON COLUMN account_interest_settings.compounding IS
+    'How often interest is paid out and added to the balance: monthly, quarterly, annually';
+COMMENT ON COLUMN account_interest_settings.payout_day IS
+    'Day of month when interest is paid out (1-28)';
+COMMENT ON COLUMN account_interest_settings.withholding_tax_rate IS
+    'Tax withheld from gross interest in percent. Only net interest is posted.';
+COMMENT ON COLUMN account_interest_settings.income_category_id IS
+    'Income category used for posted interest transactions. ON DELETE RESTRICT.';
+COMMENT ON COLUMN account_interest_settings.created_by IS
+    'User who configured interest. Posted transactions are created on behalf of this user.';
+COMMENT ON COLUMN account_interest_settings.last_posted_date IS
+    'Payout date of the last posted interest. NULL = nothing posted yet.';
+COMMENT ON COLUMN account_interest_settings.created_at IS
+    'Timestamp when configuration was created. First payout is one compounding period later.';
+COMMENT ON COLUMN account_interest_settings.updated_at IS
+    'Timestamp of last update. Updated automatically by trigger.';
+
+CREATE TRIGGER trigger_account_interest_settings_updated_at
+    BEFORE UPDATE ON account_interest_settings
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER trigger_audit_account_interest_settings
+    AFTER INSERT OR UPDATE OR DELETE ON account_interest_settings
+    FOR EACH ROW
+EXECUTE FUNCTION audit_trigger();
+
+COMMENT ON TRIGGER trigger_account_interest_settings_updated_at ON account_interest_settings IS
+    'Updates updated_at timestamp automatically on every UPDATE';
+COMMENT ON TRIGGER trigger_audit_account_interest_settings ON account_interest_settings IS
+    'Logs all changes to account_interest_settings table';
+
+COMMIT;
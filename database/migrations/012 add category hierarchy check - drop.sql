@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_categories_hierarchy ON categories;
+
+DROP FUNCTION IF EXISTS check_category_hierarchy() CASCADE;
+
+COMMIT;
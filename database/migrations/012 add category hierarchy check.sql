@@ -0,0 +1,93 @@
+-- ============================================================================
+-- Migration: category hierarchy check
+-- Purpose: Enforce consistent category tree (same family, same type, no cycles, max depth)
+-- ============================================================================
+
+BEGIN;
+
+CREATE OR REPLACE FUNCTION check_category_hierarchy()
+    RETURNS TRIGGER AS $$
+DECLARE
+    max_depth INTEGER;
+    parent_family_id UUID;
+    parent_type VARCHAR(50);
+    ancestor_id UUID;
+    parent_depth INTEGER := 0;
+    subtree_depth INTEGER := 1;
+BEGIN
+    IF NEW.parent_id IS NULL THEN
+        RETURN NEW;
+    END IF;
+
+    -- Serialize hierarchy changes within a family, so concurrent moves cannot create a cycle
+    PERFORM pg_advisory_xact_lock(hashtext('categories:' || NEW.family_id::text));
+
+    max_depth := COALESCE(NULLIF(current_setting('app.category_max_depth', true), '')::INTEGER, 5);
+
+    SELECT family_id, type
+    INTO parent_family_id, parent_type
+    FROM categories
+    WHERE id = NEW.parent_id;
+
+    IF parent_family_id IS DISTINCT FROM NEW.family_id THEN
+        RAISE EXCEPTION 'Parent category % does not belong to family %', NEW.parent_id, NEW.family_id
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    IF parent_type <> NEW.type THEN
+        RAISE EXCEPTION 'Parent category type % does not match category type %', parent_type, NEW.type
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    -- Walk up from the parent: detects cycles and calculates depth of the parent
+    ancestor_id := NEW.parent_id;
+    WHILE ancestor_id IS NOT NULL LOOP
+            IF ancestor_id = NEW.id THEN
+                RAISE EXCEPTION 'Category % cannot be moved under itself or its descendant', NEW.id
+                    USING ERRCODE = 'check_violation';
+            END IF;
+
+            parent_depth := parent_depth + 1;
+            IF parent_depth >= max_depth THEN
+                RAISE EXCEPTION 'Category hierarchy cannot be deeper than % levels', max_depth
+                    USING ERRCODE = 'check_violation';
+            END IF;
+
+            SELECT parent_id INTO ancestor_id FROM categories WHERE id = ancestor_id;
+        END LOOP;
+
+    -- Height of the subtree being attached (1 = category without children)
+    IF TG_OP = 'UPDATE' THEN
+        WITH RECURSIVE subtree AS (
+            SELECT c.id, 2 AS depth
+            FROM categories c
+            WHERE c.parent_id = NEW.id
+            UNION ALL
+            SELECT c.id, s.depth + 1
+            FROM categories c
+                     JOIN subtree s ON c.parent_id = s.id
+            WHERE s.depth <= max_depth
+        )
+        SELECT COALESCE(MAX(depth), 1) INTO subtree_depth FROM subtree;
+    END IF;
+
+    IF parent_depth + subtree_depth > max_depth THEN
+        RAISE EXCEPTION 'Category hierarchy cannot be deeper than % levels', max_depth
+            USING ERRCODE = 'check_violation';
+    END IF;
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+COMMENT ON FUNCTION check_category_hierarchy() IS
+    'Validates category parent: same family, same type, no cycles and depth not exceeding app.category_max_depth (default 5). Called by trigger on categories INSERT/UPDATE.';
+
+CREATE TRIGGER trigger_categories_hierarchy
+    BEFORE INSERT OR UPDATE OF parent_id, family_id, type ON categories
+    FOR EACH ROW
+EXECUTE FUNCTION check_category_hierarchy();
+
+COMMENT ON TRIGGER trigger_categories_hierarchy ON categories IS
+    'Rejects parent changes that would break the category tree';
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_categories_family_sort;
+
+ALTER TABLE categories
+    DROP CONSTRAINT IF EXISTS categories_sort_order_non_negative,
+    DROP CONSTRAINT IF EXISTS categories_color_hex;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS sort_order,
+    DROP COLUMN IF EXISTS color,
+    DROP COLUMN IF EXISTS icon;
+
+COMMIT;
@@ -0,0 +1,40 @@
+-- ============================================================================
+-- Migration: category presentation fields
+-- Purpose: Icon, color and display order of categories
+-- ============================================================================
+
+BEGIN;
+
+ALTER TABLE categories
+    ADD COLUMN icon VARCHAR(50),
+    ADD COLUMN color VARCHAR(7),
+    ADD COLUMN sort_order INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE categories
+    ADD CONSTRAINT categories_color_hex
+        CHECK (color IS NULL OR color ~ '^#[0-9A-Fa-f]{6}$'),
+    ADD CONSTRAINT categories_sort_order_non_negative
+        CHECK (sort_order >= 0);
+
+-- Initial order: alphabetical within siblings
+UPDATE categories c
+SET sort_order = o.position
+FROM (
+         SELECT id,
+                ROW_NUMBER() OVER (PARTITION BY family_id, type, parent_id ORDER BY name) - 1 AS position
+         FROM categories
+     ) o
+WHERE c.id = o.id;
+
+CREATE INDEX idx_categories_family_sort
+    ON categories(family_id, type, parent_id, sort_order)
+    WHERE is_active = true;
+
+COMMENT ON COLUMN categories.icon IS
+    'Optional icon identifier used by the frontend. Examples: "cart", "car", "home"';
+COMMENT ON COLUMN categories.color IS
+    'Optional display color in hex format #RRGGBB';
+COMMENT ON COLUMN categories.sort_order IS
+    'Display position among sibling categories (same parent and type). 0 = first.';
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_audit_budgets ON budgets;
+DROP TRIGGER IF EXISTS trigger_budgets_updated_at ON budgets;
+
+DROP TABLE IF EXISTS budgets CASCADE;
+
+COMMIT;
@@ -0,0 +1,89 @@
+-- ============================================================================
+-- Table: budgets
+-- Purpose: Monthly spending limits per category
+-- ============================================================================
+
+BEGIN;
+
+CREATE TABLE budgets (
+                         id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                         family_id UUID NOT NULL,
+                         category_id UUID NOT NULL,
+                         month DATE,
+                         amount DECIMAL(15, 2) NOT NULL,
+                         created_by UUID,
+                         created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                         updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                         is_active BOOLEAN NOT NULL DEFAULT true,
+
+                         CONSTRAINT fk_budgets_family
+                             FOREIGN KEY (family_id)
+                                 REFERENCES families(id)
+                                 ON DELETE CASCADE,
+
+                         CONSTRAINT fk_budgets_category
+                             FOREIGN KEY (category_id)
+                                 REFERENCES categories(id)
+                                 ON DELETE CASCADE,
+
+                         CONSTRAINT fk_budgets_created_by
+                             FOREIGN KEY (created_by)
+                                 REFERENCES users(id)
+                                 ON DELETE SET NULL,
+
+                         CONSTRAINT budgets_amount_positive
+                             CHECK (amount > 0),
+
+                         CONSTRAINT budgets_month_first_day
+                             CHECK (month IS NULL OR EXTRACT(DAY FROM month) = 1)
+);
+
+CREATE INDEX idx_budgets_family
+    ON budgets(family_id);
+CREATE INDEX idx_budgets_family_month
+    ON budgets(family_id, month)
+    WHERE is_active = true;
+CREATE UNIQUE INDEX uq_budgets_category_month
+    ON budgets(category_id, month)
+    WHERE is_active = true AND month IS NOT NULL;
+CREATE UNIQUE INDEX uq_budgets_category_default
+    ON budgets(category_id)
+    WHERE is_active = true AND month IS NULL;
+
+COMMENT ON TABLE budgets IS
+    'Monthly spending limits per category. A budget without month is the default for every month without its own budget.';
+COMMENT ON COLUMN budgets.id IS
+    'UUID primary key. Generated automatically.';
+COMMENT ON COLUMN budgets.family_id IS
+    'Foreign key to families. ON DELETE CASCADE.';
+COMMENT ON COLUMN budgets.category_id IS
+    'Foreign key to categories. Spending in child categories counts towards the budget of the parent.';
+COMMENT ON COLUMN budgets.month IS
+    'First day of the budgeted month. NULL = default budget, same every month.';
+COMMENT ON COLUMN budgets.amount IS
+    'Planned spending for the month in the family base currency. Must be positive.';
+COMMENT ON COLUMN budgets.created_by IS
+    'User who created the budget. NULL if user was deleted.';
+COMMENT ON COLUMN budgets.created_at IS
+    'Timestamp when budget was created. Set automatically.';
+COMMENT ON COLUMN budgets.updated_at IS
+    'Timestamp of last update. Updated automatically by trigger.';
+COMMENT ON COLUMN budgets.is_active IS
+    'Soft delete flag. true = active budget, false = deleted budget (data preserved for history)';
+
+CREATE TRIGGER trigger_budgets_updated_at
+    BEFORE UPDATE ON budgets
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER trigger_audit_budgets
+    AFTER INSERT OR UPDATE OR DELETE ON budgets
+    FOR EACH ROW
+EXECUTE FUNCTION audit_trigger();
+
+COMMENT ON TRIGGER trigger_budgets_updated_at ON budgets IS
+    'Updates updated_at timestamp automatically on every UPDATE';
+COMMENT ON TRIGGER trigger_audit_budgets ON budgets IS
+    'Logs all changes to budgets table';
+
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_audit_budget_assignments ON budget_assignments;
+DROP TRIGGER IF EXISTS trigger_budget_assignments_updated_at ON budget_assignments;
+
+DROP TABLE IF EXISTS budget_assignments CASCADE;
+
+ALTER TABLE budgets
+    DROP CONSTRAINT IF EXISTS budgets_rollover_mode_check;
+
+ALTER TABLE budgets
+    DROP COLUMN IF EXISTS rollover_mode;
+
+COMMIT;
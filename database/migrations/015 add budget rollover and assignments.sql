@@ -0,0 +1,90 @@
+-- ============================================================================
+-- Migration: budget rollover and envelope assignments
+-- Purpose: Carry unspent/overspent budget into next month, assign income to categories
+-- ============================================================================
+
+BEGIN;
+
+ALTER TABLE budgets
+    ADD COLUMN rollover_mode VARCHAR(20) NOT NULL DEFAULT 'none';
+
+ALTER TABLE budgets
+    ADD CONSTRAINT budgets_rollover_mode_check
+        CHECK (rollover_mode IN ('none', 'carry_surplus', 'carry_all'));
+
+COMMENT ON COLUMN budgets.rollover_mode IS
+    'What happens with the remainder at month end: none = dropped, carry_surplus = unspent amount added to next month, carry_all = surplus and overspending carried to next month';
+
+CREATE TABLE budget_assignments (
+                                    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                    family_id UUID NOT NULL,
+                                    category_id UUID NOT NULL,
+                                    month DATE NOT NULL,
+                                    amount DECIMAL(15, 2) NOT NULL,
+                                    created_by UUID,
+                                    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                    CONSTRAINT fk_budget_assignments_family
+                                        FOREIGN KEY (family_id)
+                                            REFERENCES families(id)
+                                            ON DELETE CASCADE,
+
+                                    CONSTRAINT fk_budget_assignments_category
+                                        FOREIGN KEY (category_id)
+                                            REFERENCES categories(id)
+                                            ON DELETE CASCADE,
+
+                                    CONSTRAINT fk_budget_assignments_created_by
+                                        FOREIGN KEY (created_by)
+                                            REFERENCES users(id)
+                                            ON DELETE SET NULL,
+
+                                    CONSTRAINT budget_assignments_amount_non_negative
+                                        CHECK (amount >= 0),
+
+                                    CONSTRAINT budget_assignments_month_first_day
+                                        CHECK (EXTRACT(DAY FROM month) = 1),
+
+                                    CONSTRAINT uq_budget_assignments_category_month
+                                        UNIQUE (category_id, month)
+);
+
+CREATE INDEX idx_budget_assignments_family_month
+    ON budget_assignments(family_id, month);
+
+COMMENT ON TABLE budget_assignments IS
+    'Envelope budgeting: income explicitly assigned to expense categories per month.';
+COMMENT ON COLUMN budget_assignments.id IS
+    'UUID primary key. Generated automatically.';
+COMMENT ON COLUMN budget_assignments.family_id IS
+    'Foreign key to families. ON DELETE CASCADE.';
+COMMENT ON COLUMN budget_assignments.category_id IS
+    'Foreign key to categories. One assignment per category and month.';
+COMMENT ON COLUMN budget_assignments.month IS
+    'First day of the month the money is assigned for.';
+COMMENT ON COLUMN budget_assignments.amount IS
+    'Assigned amount in the family base currency. 0 = assignment cleared.';
+COMMENT ON COLUMN budget_assignments.created_by IS
+    'User who made the assignment. NULL if user was deleted.';
+COMMENT ON COLUMN budget_assignments.created_at IS
+    'Timestamp when assignment was created. Set automatically.';
+COMMENT ON COLUMN budget_assignments.updated_at IS
+    'Timestamp of last update. Updated automatically by trigger.';
+
+CREATE TRIGGER trigger_budget_assignments_updated_at
+    BEFORE UPDATE ON budget_assignments
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER trigger_audit_budget_assignments
+    AFTER INSERT OR UPDATE OR DELETE ON budget_assignments
+    FOR EACH ROW
+EXECUTE FUNCTION audit_trigger();
+
+COMMENT ON TRIGGER trigger_budget_assignments_updated_at ON budget_assignments IS
+    'Updates updated_at timestamp automatically on every UPDATE';
+COMMENT ON TRIGGER trigger_audit_budget_assignments ON budget_assignments IS
+    'Logs all changes to budget_assignments table';
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP TABLE IF EXISTS alert_reads CASCADE;
+DROP TABLE IF EXISTS alerts CASCADE;
+
+DROP TRIGGER IF EXISTS trigger_audit_alert_thresholds ON alert_thresholds;
+DROP TRIGGER IF EXISTS trigger_alert_thresholds_updated_at ON alert_thresholds;
+
+DROP TABLE IF EXISTS alert_thresholds CASCADE;
+
+COMMIT;
@@ -0,0 +1,147 @@
+-- ============================================================================
+-- Table: alert_thresholds, alerts, alert_reads
+-- Purpose: Spending threshold alerts with per-user read state
+-- ============================================================================
+
+BEGIN;
+
+CREATE TABLE alert_thresholds (
+                                  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                                  family_id UUID NOT NULL,
+                                  category_id UUID,
+                                  limit_amount DECIMAL(15, 2),
+                                  percentages INTEGER[] NOT NULL DEFAULT '{80,100}',
+                                  created_by UUID,
+                                  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                  is_active BOOLEAN NOT NULL DEFAULT true,
+
+                                  CONSTRAINT fk_alert_thresholds_family
+                                      FOREIGN KEY (family_id)
+                                          REFERENCES families(id)
+                                          ON DELETE CASCADE,
+
+                                  CONSTRAINT fk_alert_thresholds_category
+                                      FOREIGN KEY (category_id)
+                                          REFERENCES categories(id)
+                                          ON DELETE CASCADE,
+
+                                  CONSTRAINT fk_alert_thresholds_created_by
+                                      FOREIGN KEY (created_by)
+                                          REFERENCES users(id)
+                                          ON DELETE SET NULL,
+
+                                  CONSTRAINT alert_thresholds_limit_positive
+                                      CHECK (limit_amount IS NULL OR limit_amount > 0),
+
+                                  CONSTRAINT alert_thresholds_limit_required
+                                      CHECK (category_id IS NOT NULL OR limit_amount IS NOT NULL),
+
+                                  CONSTRAINT alert_thresholds_percentages_not_empty
+                                      CHECK (cardinality(percentages) >= 1)
+);
+
+CREATE INDEX idx_alert_thresholds_family
+    ON alert_thresholds(family_id)
+    WHERE is_active = true;
+CREATE UNIQUE INDEX uq_alert_thresholds_category
+    ON alert_thresholds(category_id)
+    WHERE is_active = true AND category_id IS NOT NULL;
+CREATE UNIQUE INDEX uq_alert_thresholds_overall
+    ON alert_thresholds(family_id)
+    WHERE is_active = true AND category_id IS NULL;
+
+COMMENT ON TABLE alert_thresholds IS
+    'Spending thresholds per category or for overall monthly spending. Checked when transactions are created or updated.';
+COMMENT ON COLUMN alert_thresholds.category_id IS
+    'Watched category including subcategories. NULL = overall monthly spending of the family.';
+COMMENT ON COLUMN alert_thresholds.limit_amount IS
+    'Monthly limit in the family base currency. NULL = monthly budget of the category.';
+COMMENT ON COLUMN alert_thresholds.percentages IS
+    'Percentages of the limit that raise an alert. Default: 80 and 100.';
+COMMENT ON COLUMN alert_thresholds.is_active IS
+    'Soft delete flag. true = active threshold, false = deleted threshold';
+
+CREATE TRIGGER trigger_alert_thresholds_updated_at
+    BEFORE UPDATE ON alert_thresholds
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER trigger_audit_alert_thresholds
+    AFTER INSERT OR UPDATE OR DELETE ON alert_thresholds
+    FOR EACH ROW
+EXECUTE FUNCTION audit_trigger();
+
+
+CREATE TABLE alerts (
+                        id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                        family_id UUID NOT NULL,
+                        threshold_id UUID NOT NULL,
+                        category_id UUID,
+                        period DATE NOT NULL,
+                        percentage INTEGER NOT NULL,
+                        limit_amount DECIMAL(15, 2) NOT NULL,
+                        spent_amount DECIMAL(15, 2) NOT NULL,
+                        created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                        CONSTRAINT fk_alerts_family
+                            FOREIGN KEY (family_id)
+                                REFERENCES families(id)
+                                ON DELETE CASCADE,
+
+                        CONSTRAINT fk_alerts_threshold
+                            FOREIGN KEY (threshold_id)
+                                REFERENCES alert_thresholds(id)
+                                ON DELETE CASCADE,
+
+                        CONSTRAINT fk_alerts_category
+                            FOREIGN KEY (category_id)
+                                REFERENCES categories(id)
+                                ON DELETE CASCADE,
+
+                        -- One alert per threshold, month and percentage
+                        CONSTRAINT uq_alerts_threshold_period_percentage
+                            UNIQUE (threshold_id, period, percentage)
+);
+
+CREATE INDEX idx_alerts_family_created
+    ON alerts(family_id, created_at DESC);
+
+COMMENT ON TABLE alerts IS
+    'Raised when spending of a month reaches a percentage of a threshold limit. Never duplicated within a month.';
+COMMENT ON COLUMN alerts.period IS
+    'First day of the month the alert belongs to.';
+COMMENT ON COLUMN alerts.limit_amount IS
+    'Limit at the moment the alert was raised, in the family base currency.';
+COMMENT ON COLUMN alerts.spent_amount IS
+    'Spending of the month at the moment the alert was raised, in the family base currency.';
+
+
+CREATE TABLE alert_reads (
+                             alert_id UUID NOT NULL,
+                             user_id UUID NOT NULL,
+                             read_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                             acknowledged_at TIMESTAMP,
+
+                             PRIMARY KEY (alert_id, user_id),
+
+                             CONSTRAINT fk_alert_reads_alert
+                                 FOREIGN KEY (alert_id)
+                                     REFERENCES alerts(id)
+                                     ON DELETE CASCADE,
+
+                             CONSTRAINT fk_alert_reads_user
+                                 FOREIGN KEY (user_id)
+                                     REFERENCES users(id)
+                                     ON DELETE CASCADE
+);
+
+CREATE INDEX idx_alert_reads_user
+    ON alert_reads(user_id);
+
+COMMENT ON TABLE alert_reads IS
+    'Read and acknowledge state of alerts per user. No row = unread.';
+COMMENT ON COLUMN alert_reads.acknowledged_at IS
+    'When the user acknowledged the alert. NULL = read but not acknowledged.';
+
+COMMIT;
@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_audit_saved_reports ON saved_reports;
+DROP TRIGGER IF EXISTS trigger_saved_reports_updated_at ON saved_reports;
+
+DROP TABLE IF EXISTS saved_reports CASCADE;
+
+COMMIT;
@@ -0,0 +1,57 @@
+-- ============================================================================
+-- Table: saved_reports
+-- Purpose: Custom report definitions saved per family
+-- ============================================================================
+
+BEGIN;
+
+CREATE TABLE saved_reports (
+                               id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+                               family_id UUID NOT NULL,
+                               name VARCHAR(100) NOT NULL,
+                               description TEXT,
+                               definition JSONB NOT NULL,
+                               created_by UUID,
+                               created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                               is_active BOOLEAN NOT NULL DEFAULT true,
+
+                               CONSTRAINT fk_saved_reports_family
+                                   FOREIGN KEY (family_id)
+                                       REFERENCES families(id)
+                                       ON DELETE CASCADE,
+
+                               CONSTRAINT fk_saved_reports_created_by
+                                   FOREIGN KEY (created_by)
+                                       REFERENCES users(id)
+                                       ON DELETE SET NULL,
+
+                               CONSTRAINT saved_reports_name_not_empty
+                                   CHECK (length(trim(name)) > 0),
+
+                               CONSTRAINT saved_reports_definition_object
+                                   CHECK (jsonb_typeof(definition) = 'object')
+);
+
+CREATE UNIQUE INDEX uq_saved_reports_family_name
+    ON saved_reports(family_id, lower(name))
+    WHERE is_active = true;
+
+COMMENT ON TABLE saved_reports IS
+    'Custom report definitions (measures, dimensions, filters, sort) saved by family members. Run on demand.';
+COMMENT ON COLUMN saved_reports.definition IS
+    'Report definition as JSON: measures, dimensions, filters, sort and limit. Validated by the application.';
+COMMENT ON COLUMN saved_reports.is_active IS
+    'Soft delete flag. true = active report, false = deleted report';
+
+CREATE TRIGGER trigger_saved_reports_updated_at
+    BEFORE UPDATE ON saved_reports
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+CREATE TRIGGER trigger_audit_saved_reports
+    AFTER INSERT OR UPDATE OR DELETE ON saved_reports
+    FOR EACH ROW
+EXECUTE FUNCTION audit_trigger();
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trigger_transactions_monthly_aggregates ON transactions;
+
+DROP FUNCTION IF EXISTS rebuild_transaction_monthly_aggregates(UUID) CASCADE;
+DROP FUNCTION IF EXISTS update_transaction_monthly_aggregates() CASCADE;
+DROP FUNCTION IF EXISTS apply_transaction_monthly_aggregate(transactions, INTEGER) CASCADE;
+
+DROP TABLE IF EXISTS transaction_monthly_aggregates CASCADE;
+
+COMMIT;
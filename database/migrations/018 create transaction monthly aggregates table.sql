@@ -0,0 +1,149 @@
+-- ============================================================================
+-- Table: transaction_monthly_aggregates
+-- Purpose: Monthly totals of active transactions maintained by trigger for fast reporting
+-- ============================================================================
+
+BEGIN;
+
+CREATE TABLE transaction_monthly_aggregates (
+                                                family_id UUID NOT NULL,
+                                                month DATE NOT NULL,
+                                                category_id UUID NOT NULL,
+                                                type VARCHAR(50) NOT NULL,
+                                                currency VARCHAR(3) NOT NULL,
+                                                total_amount DECIMAL(15, 2) NOT NULL DEFAULT 0,
+                                                total_base DECIMAL(15, 2) NOT NULL DEFAULT 0,
+                                                transaction_count INTEGER NOT NULL DEFAULT 0,
+                                                updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                                                PRIMARY KEY (family_id, month, category_id, type, currency),
+
+                                                CONSTRAINT fk_transaction_monthly_aggregates_family
+                                                    FOREIGN KEY (family_id)
+                                                        REFERENCES families(id)
+                                                        ON DELETE CASCADE,
+
+                                                CONSTRAINT fk_transaction_monthly_aggregates_category
+                                                    FOREIGN KEY (category_id)
+                                                        REFERENCES categories(id)
+                                                        ON DELETE CASCADE,
+
+                                                CONSTRAINT transaction_monthly_aggregates_month_start
+                                                    CHECK (month = date_trunc('month', month)::date)
+);
+
+CREATE INDEX idx_transaction_monthly_aggregates_family_type
+    ON transaction_monthly_aggregates(family_id, type, month);
+
+COMMENT ON TABLE transaction_monthly_aggregates IS
+    'Totals of active transactions per family, month, category, type and currency. Maintained by trigger on transactions; rebuild with rebuild_transaction_monthly_aggregates().';
+COMMENT ON COLUMN transaction_monthly_aggregates.month IS
+    'First day of the month of transaction_date';
+COMMENT ON COLUMN transaction_monthly_aggregates.total_amount IS
+    'Sum of amount in the original currency';
+COMMENT ON COLUMN transaction_monthly_aggregates.total_base IS
+    'Sum of amount_base in the family base currency. Used for reports.';
+COMMENT ON COLUMN transaction_monthly_aggregates.transaction_count IS
+    'Number of active transactions. Rows are removed when the count drops to zero.';
+
+-- Adds (p_sign = 1) or removes (p_sign = -1) a transaction from its monthly aggregate
+CREATE OR REPLACE FUNCTION apply_transaction_monthly_aggregate(t transactions, p_sign INTEGER)
+    RETURNS VOID AS $$
+DECLARE
+    aggregate_month DATE := date_trunc('month', t.transaction_date)::date;
+BEGIN
+    INSERT INTO transaction_monthly_aggregates (
+        family_id, month, category_id, type, currency,
+        total_amount, total_base, transaction_count
+    )
+    VALUES (
+               t.family_id, aggregate_month, t.category_id, t.type, t.currency,
+               p_sign * t.amount, p_sign * t.amount_base, p_sign
+           )
+    ON CONFLICT (family_id, month, category_id, type, currency) DO UPDATE
+        SET total_amount = transaction_monthly_aggregates.total_amount + EXCLUDED.total_amount,
+            total_base = transaction_monthly_aggregates.total_base + EXCLUDED.total_base,
+            transaction_count = transaction_monthly_aggregates.transaction_count + EXCLUDED.transaction_count,
+            updated_at = CURRENT_TIMESTAMP;
+
+    -- Remove the aggregate together with its last transaction
+    IF p_sign < 0 THEN
+        DELETE FROM transaction_monthly_aggregates
+        WHERE family_id = t.family_id
+          AND month = aggregate_month
+          AND category_id = t.category_id
+          AND type = t.type
+          AND currency = t.currency
+          AND transaction_count <= 0;
+    END IF;
+END;
+$$ LANGUAGE plpgsql;
+COMMENT ON FUNCTION apply_transaction_monthly_aggregate(transactions, INTEGER) IS
+    'Adds (p_sign = 1) or subtracts (p_sign = -1) a transaction from transaction_monthly_aggregates.';
+
+CREATE OR REPLACE FUNCTION update_transaction_monthly_aggregates()
+    RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') AND OLD.is_active THEN
+        PERFORM apply_transaction_monthly_aggregate(OLD, -1);
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE') AND NEW.is_active THEN
+        PERFORM apply_transaction_monthly_aggregate(NEW, 1);
+    END IF;
+
+    RETURN COALESCE(NEW, OLD);
+END;
+$$ LANGUAGE plpgsql;
+COMMENT ON FUNCTION update_transaction_monthly_aggregates() IS
+    'Keeps transaction_monthly_aggregates in sync within the writing transaction. Called by trigger on transactions INSERT/UPDATE/DELETE.';
+
+CREATE TRIGGER trigger_transactions_monthly_aggregates
+    AFTER INSERT OR UPDATE OR DELETE ON transactions
+    FOR EACH ROW
+EXECUTE FUNCTION update_transaction_monthly_aggregates();
+
+COMMENT ON TRIGGER trigger_transactions_monthly_aggregates ON transactions IS
+    'Automatically updates monthly aggregates when transaction is inserted/updated/deleted';
+
+-- Recalculates aggregates from raw transactions (all families when p_family_id is NULL)
+CREATE OR REPLACE FUNCTION rebuild_transaction_monthly_aggregates(p_family_id UUID DEFAULT NULL)
+    RETURNS INTEGER AS $$
+DECLARE
+    inserted INTEGER;
+BEGIN
+    -- Block concurrent transaction writes, so their trigger updates are not lost or counted twice
+    LOCK TABLE transactions IN SHARE MODE;
+
+    DELETE FROM transaction_monthly_aggregates
+    WHERE p_family_id IS NULL OR family_id = p_family_id;
+
+    INSERT INTO transaction_monthly_aggregates (
+        family_id, month, category_id, type, currency,
+        total_amount, total_base, transaction_count
+    )
+    SELECT
+        family_id,
+        date_trunc('month', transaction_date)::date,
+        category_id,
+        type,
+        currency,
+        SUM(amount),
+        SUM(amount_base),
+        COUNT(*)
+    FROM transactions
+    WHERE is_active = true
+      AND (p_family_id IS NULL OR family_id = p_family_id)
+    GROUP BY family_id, date_trunc('month', transaction_date)::date, category_id, type, currency;
+
+    GET DIAGNOSTICS inserted = ROW_COUNT;
+    RETURN inserted;
+END;
+$$ LANGUAGE plpgsql;
+COMMENT ON FUNCTION rebuild_transaction_monthly_aggregates(UUID) IS
+    'Recalculates transaction_monthly_aggregates from raw transactions for one family or all families (NULL). Returns the number of aggregate rows.';
+
+-- Fill aggregates for existing transactions
+SELECT rebuild_transaction_monthly_aggregates();
+
+COMMIT;
@@ -0,0 +1,62 @@
+BEGIN;
+
+-- Only possible while all families use RSD
+ALTER TABLE families
+    DROP CONSTRAINT families_base_currency_check;
+
+ALTER TABLE families
+    ADD CONSTRAINT families_base_currency_check
+        CHECK (base_currency = 'RSD');
+
+COMMENT ON COLUMN families.base_currency IS
+    'Base currency for financial reporting. MVP: Only RSD supported. Post-MVP: User-configurable (EUR, USD, etc.)';
+COMMENT ON COLUMN transactions.amount_base IS
+    'Amount converted to base currency (RSD) using exchange rate at transaction date. Used for reports and balance calculations.';
+
+CREATE OR REPLACE FUNCTION update_account_balance()
+    RETURNS TRIGGER AS $$
+DECLARE
+    affected_account_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        affected_account_id := OLD.account_id;
+    ELSE
+        affected_account_id := NEW.account_id;
+    END IF;
+
+    UPDATE accounts
+    SET current_balance = initial_balance + COALESCE((
+                                                         SELECT SUM(
+                                                                        CASE
+                                                                            WHEN t.type = 'income' THEN t.amount_base
+                                                                            WHEN t.type = 'expense' THEN -t.amount_base
+                                                                            ELSE 0
+                                                                            END
+                                                                )
+                                                         FROM transactions t
+                                                         WHERE t.account_id = affected_account_id
+                                                           AND t.is_active = true
+                                                     ), 0)
+    WHERE id = affected_account_id;
+
+    RETURN COALESCE(NEW, OLD);
+END;
+$$ LANGUAGE plpgsql;
+COMMENT ON FUNCTION update_account_balance() IS
+    'Recalculates account.current_balance based on all active transactions. Called by trigger on transactions INSERT/UPDATE/DELETE.';
+
+UPDATE accounts a
+SET current_balance = a.initial_balance + COALESCE((
+                                                       SELECT SUM(
+                                                                      CASE
+                                                                          WHEN t.type = 'income' THEN t.amount_base
+                                                                          WHEN t.type = 'expense' THEN -t.amount_base
+                                                                          ELSE 0
+                                                                          END
+                                                              )
+                                                       FROM transactions t
+                                                       WHERE t.account_id = a.id
+                                                         AND t.is_active = true
+                                                   ), 0);
+
+COMMIT;
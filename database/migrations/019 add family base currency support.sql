@@ -0,0 +1,63 @@
+-- ============================================================================
+-- Migration: family base currency support
+-- Purpose: Allow EUR as family base currency; account balances in account currency
+-- ============================================================================
+
+BEGIN;
+
+ALTER TABLE families
+    DROP CONSTRAINT families_base_currency_check;
+
+ALTER TABLE families
+    ADD CONSTRAINT families_base_currency_check
+        CHECK (base_currency IN ('RSD', 'EUR'));
+
+COMMENT ON COLUMN families.base_currency IS
+    'Base currency for financial reporting. transactions.amount_base is stored in this currency; changing it recomputes amount_base of all family transactions.';
+COMMENT ON COLUMN transactions.amount_base IS
+    'Amount converted to the family base currency using exchange rate at transaction date. Used for reports.';
+
+-- Balances are kept in the account currency: they are summed from the original
+-- amounts, never from base amounts
+CREATE OR REPLACE FUNCTION update_account_balance()
+    RETURNS TRIGGER AS $$
+DECLARE
+    affected_account_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        affected_account_id := OLD.account_id;
+    ELSE
+        affected_account_id := NEW.account_id;
+    END IF;
+
+    UPDATE accounts a
+    SET current_balance = a.initial_balance + COALESCE((
+                                                           SELECT SUM(
+                                                                          t.amount
+                                                                          * CASE WHEN t.type = 'income' THEN 1 ELSE -1 END
+                                                                  )
+                                                           FROM transactions t
+                                                           WHERE t.account_id = affected_account_id
+                                                             AND t.is_active = true
+                                                       ), 0)
+    WHERE a.id = affected_account_id;
+
+    RETURN COALESCE(NEW, OLD);
+END;
+$$ LANGUAGE plpgsql;
+COMMENT ON FUNCTION update_account_balance() IS
+    'Recalculates account.current_balance in account currency from amounts of all active transactions. Called by trigger on transactions INSERT/UPDATE/DELETE.';
+
+-- Recalculate existing balances with the new formula
+UPDATE accounts a
+SET current_balance = a.initial_balance + COALESCE((
+                                                       SELECT SUM(
+                                                                      t.amount
+                                                                      * CASE WHEN t.type = 'income' THEN 1 ELSE -1 END
+                                                              )
+                                                       FROM transactions t
+                                                       WHERE t.account_id = a.id
+                                                         AND t.is_active = true
+                                                   ), 0);
+
+COMMIT;
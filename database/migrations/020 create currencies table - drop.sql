@@ -0,0 +1,78 @@
+BEGIN;
+
+-- Only possible while all data uses RSD or EUR
+ALTER TABLE savings_goals
+    DROP CONSTRAINT IF EXISTS fk_savings_goals_currency;
+ALTER TABLE savings_goals
+    ADD CONSTRAINT savings_goals_currency_check
+        CHECK (currency IN ('RSD', 'EUR'));
+
+ALTER TABLE exchange_rates
+    DROP CONSTRAINT IF EXISTS fk_exchange_rates_to_currency;
+ALTER TABLE exchange_rates
+    DROP CONSTRAINT IF EXISTS fk_exchange_rates_from_currency;
+ALTER TABLE exchange_rates
+    ADD CONSTRAINT exchange_rates_from_currency_check
+        CHECK (from_currency IN ('RSD', 'EUR'));
+ALTER TABLE exchange_rates
+    ADD CONSTRAINT exchange_rates_to_currency_check
+        CHECK (to_currency IN ('RSD', 'EUR'));
+
+ALTER TABLE transactions
+    DROP CONSTRAINT IF EXISTS fk_transactions_currency;
+ALTER TABLE transactions
+    ADD CONSTRAINT transactions_currency_check
+        CHECK (currency IN ('RSD', 'EUR'));
+
+ALTER TABLE accounts
+    DROP CONSTRAINT IF EXISTS fk_accounts_currency;
+ALTER TABLE accounts
+    ADD CONSTRAINT accounts_currency_check
+        CHECK (currency IN ('RSD', 'EUR'));
+
+ALTER TABLE families
+    DROP CONSTRAINT IF EXISTS fk_families_base_currency;
+ALTER TABLE families
+    ADD CONSTRAINT families_base_currency_check
+        CHECK (base_currency IN ('RSD', 'EUR'));
+
+COMMENT ON COLUMN families.base_currency IS
+    'Base currency for financial reporting (RSD or EUR). transactions.amount_base is stored in this currency; changing it recomputes amount_base of all family transactions.';
+COMMENT ON COLUMN accounts.currency IS
+    'Account currency. MVP: RSD or EUR only. Post-MVP: any currency.';
+COMMENT ON COLUMN transactions.currency IS
+    'Original transaction currency (RSD or EUR in MVP)';
+COMMENT ON COLUMN exchange_rates.from_currency IS
+    'Source currency code. MVP: RSD or EUR only.';
+COMMENT ON COLUMN exchange_rates.to_currency IS
+    'Target currency code. MVP: RSD or EUR only.';
+
+-- Amounts of currencies with 3 or more minor units are rounded to 2 decimal places
+ALTER TABLE transaction_monthly_aggregates
+    ALTER COLUMN total_amount TYPE DECIMAL(15, 2),
+    ALTER COLUMN total_base TYPE DECIMAL(15, 2);
+ALTER TABLE alerts
+    ALTER COLUMN limit_amount TYPE DECIMAL(15, 2),
+    ALTER COLUMN spent_amount TYPE DECIMAL(15, 2);
+ALTER TABLE alert_thresholds
+    ALTER COLUMN limit_amount TYPE DECIMAL(15, 2);
+ALTER TABLE budget_assignments
+    ALTER COLUMN amount TYPE DECIMAL(15, 2);
+ALTER TABLE budgets
+    ALTER COLUMN amount TYPE DECIMAL(15, 2);
+ALTER TABLE savings_goal_accounts
+    ALTER COLUMN earmarked_amount TYPE DECIMAL(15, 2);
+ALTER TABLE savings_goals
+    ALTER COLUMN target_amount TYPE DECIMAL(15, 2);
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE DECIMAL(15, 2),
+    ALTER COLUMN amount_base TYPE DECIMAL(15, 2);
+ALTER TABLE accounts
+    ALTER COLUMN initial_balance TYPE DECIMAL(15, 2),
+    ALTER COLUMN current_balance TYPE DECIMAL(15, 2);
+
+DROP TRIGGER IF EXISTS trigger_currencies_updated_at ON currencies;
+
+DROP TABLE IF EXISTS currencies CASCADE;
+
+COMMIT;
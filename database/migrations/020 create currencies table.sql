@@ -0,0 +1,145 @@
+-- ============================================================================
+-- Table: currencies
+-- Purpose: ISO 4217 currency reference; replaces hard-coded RSD/EUR checks
+-- ============================================================================
+
+BEGIN;
+
+CREATE TABLE currencies (
+                            code VARCHAR(3) PRIMARY KEY,
+                            name VARCHAR(100) NOT NULL,
+                            minor_units SMALLINT NOT NULL DEFAULT 2,
+                            symbol VARCHAR(10) NOT NULL,
+                            is_enabled BOOLEAN NOT NULL DEFAULT true,
+                            created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                            updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+                            CONSTRAINT currencies_code_format
+                                CHECK (code ~ '^[A-Z]{3}$'),
+
+                            -- Amounts are stored as DECIMAL(18, 4)
+                            CONSTRAINT currencies_minor_units_range
+                                CHECK (minor_units BETWEEN 0 AND 4)
+);
+
+COMMENT ON TABLE currencies IS
+    'ISO 4217 currencies available to accounts, transactions, goals, exchange rates and family base currency.';
+COMMENT ON COLUMN currencies.code IS
+    'ISO 4217 alphabetic code, e.g. RSD, EUR, USD';
+COMMENT ON COLUMN currencies.minor_units IS
+    'Number of decimal places of the currency (JPY = 0, EUR = 2, KWD = 3). Amounts are rounded to it. At most 4 - amount columns are DECIMAL(18, 4).';
+COMMENT ON COLUMN currencies.is_enabled IS
+    'Enabled currencies can be used for new accounts, transactions and goals. Disabling keeps existing data valid.';
+
+CREATE TRIGGER trigger_currencies_updated_at
+    BEFORE UPDATE ON currencies
+    FOR EACH ROW
+EXECUTE FUNCTION update_updated_at_column();
+
+INSERT INTO currencies (code, name, minor_units, symbol) VALUES
+    ('RSD', 'Serbian Dinar', 2, 'дин.'),
+    ('EUR', 'Euro', 2, '€'),
+    ('USD', 'US Dollar', 2, '$'),
+    ('CHF', 'Swiss Franc', 2, 'CHF'),
+    ('GBP', 'Pound Sterling', 2, '£'),
+    ('JPY', 'Yen', 0, '¥'),
+    ('CNY', 'Yuan Renminbi', 2, '¥'),
+    ('CAD', 'Canadian Dollar', 2, 'CA$'),
+    ('AUD', 'Australian Dollar', 2, 'A$'),
+    ('SEK', 'Swedish Krona', 2, 'kr'),
+    ('NOK', 'Norwegian Krone', 2, 'kr'),
+    ('DKK', 'Danish Krone', 2, 'kr'),
+    ('PLN', 'Zloty', 2, 'zł'),
+    ('CZK', 'Czech Koruna', 2, 'Kč'),
+    ('HUF', 'Forint', 2, 'Ft'),
+    ('RON', 'Romanian Leu', 2, 'lei'),
+    ('BGN', 'Bulgarian Lev', 2, 'лв'),
+    ('BAM', 'Convertible Mark', 2, 'KM'),
+    ('MKD', 'Denar', 2, 'ден'),
+    ('TRY', 'Turkish Lira', 2, '₺'),
+    ('RUB', 'Russian Ruble', 2, '₽'),
+    ('BHD', 'Bahraini Dinar', 3, 'BD'),
+    ('KWD', 'Kuwaiti Dinar', 3, 'KD'),
+    ('JOD', 'Jordanian Dinar', 3, 'JD'),
+    ('OMR', 'Rial Omani', 3, 'OMR'),
+    ('TND', 'Tunisian Dinar', 3, 'DT');
+
+-- Amount columns hold up to 4 decimal places, so currencies with 3 minor units
+-- (BHD, KWD, ...) are stored exactly; values are rounded to the currency minor units
+ALTER TABLE accounts
+    ALTER COLUMN initial_balance TYPE DECIMAL(18, 4),
+    ALTER COLUMN current_balance TYPE DECIMAL(18, 4);
+ALTER TABLE transactions
+    ALTER COLUMN amount TYPE DECIMAL(18, 4),
+    ALTER COLUMN amount_base TYPE DECIMAL(18, 4);
+ALTER TABLE savings_goals
+    ALTER COLUMN target_amount TYPE DECIMAL(18, 4);
+ALTER TABLE savings_goal_accounts
+    ALTER COLUMN earmarked_amount TYPE DECIMAL(18, 4);
+ALTER TABLE budgets
+    ALTER COLUMN amount TYPE DECIMAL(18, 4);
+ALTER TABLE budget_assignments
+    ALTER COLUMN amount TYPE DECIMAL(18, 4);
+ALTER TABLE alert_thresholds
+    ALTER COLUMN limit_amount TYPE DECIMAL(18, 4);
+ALTER TABLE alerts
+    ALTER COLUMN limit_amount TYPE DECIMAL(18, 4),
+    ALTER COLUMN spent_amount TYPE DECIMAL(18, 4);
+ALTER TABLE transaction_monthly_aggregates
+    ALTER COLUMN total_amount TYPE DECIMAL(18, 4),
+    ALTER COLUMN total_base TYPE DECIMAL(18, 4);
+
+-- Replace hard-coded currency lists with references to currencies
+ALTER TABLE families
+    DROP CONSTRAINT families_base_currency_check;
+ALTER TABLE families
+    ADD CONSTRAINT fk_families_base_currency
+        FOREIGN KEY (base_currency)
+            REFERENCES currencies(code);
+
+ALTER TABLE accounts
+    DROP CONSTRAINT accounts_currency_check;
+ALTER TABLE accounts
+    ADD CONSTRAINT fk_accounts_currency
+        FOREIGN KEY (currency)
+            REFERENCES currencies(code);
+
+ALTER TABLE transactions
+    DROP CONSTRAINT transactions_currency_check;
+ALTER TABLE transactions
+    ADD CONSTRAINT fk_transactions_currency
+        FOREIGN KEY (currency)
+            REFERENCES currencies(code);
+
+ALTER TABLE exchange_rates
+    DROP CONSTRAINT exchange_rates_from_currency_check;
+ALTER TABLE exchange_rates
+    DROP CONSTRAINT exchange_rates_to_currency_check;
+ALTER TABLE exchange_rates
+    ADD CONSTRAINT fk_exchange_rates_from_currency
+        FOREIGN KEY (from_currency)
+            REFERENCES currencies(code);
+ALTER TABLE exchange_rates
+    ADD CONSTRAINT fk_exchange_rates_to_currency
+        FOREIGN KEY (to_currency)
+            REFERENCES currencies(code);
+
+ALTER TABLE savings_goals
+    DROP CONSTRAINT savings_goals_currency_check;
+ALTER TABLE savings_goals
+    ADD CONSTRAINT fk_savings_goals_currency
+        FOREIGN KEY (currency)
+            REFERENCES currencies(code);
+
+COMMENT ON COLUMN families.base_currency IS
+    'Base currency for financial reporting, any enabled currency. transactions.amount_base is stored in this currency; changing it recomputes amount_base of all family transactions.';
+COMMENT ON COLUMN accounts.currency IS
+    'Account currency (ISO 4217, see currencies).';
+COMMENT ON COLUMN transactions.currency IS
+    'Original transaction currency (ISO 4217, see currencies)';
+COMMENT ON COLUMN exchange_rates.from_currency IS
+    'Source currency code (ISO 4217, see currencies).';
+COMMENT ON COLUMN exchange_rates.to_currency IS
+    'Target currency code (ISO 4217, see currencies).';
+
+COMMIT;
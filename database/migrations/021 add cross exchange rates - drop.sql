@@ -0,0 +1,8 @@
+BEGIN;
+
+-- Queries converting to the base currency need find_exchange_rate
+
+DROP FUNCTION IF EXISTS find_exchange_rate(VARCHAR, VARCHAR, DATE);
+DROP FUNCTION IF EXISTS find_pair_exchange_rate(VARCHAR, VARCHAR, DATE);
+
+COMMIT;
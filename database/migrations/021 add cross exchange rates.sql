@@ -0,0 +1,53 @@
+-- ============================================================================
+-- Migration: cross exchange rates
+-- Purpose: Find the rate of a currency pair that providers only publish against
+--          their own currency (ECB: EUR, NBS: RSD) for bulk conversions
+-- ============================================================================
+
+BEGIN;
+
+-- Latest stored rate up to a date: the direct pair, otherwise the inverse one
+CREATE OR REPLACE FUNCTION find_pair_exchange_rate(p_from VARCHAR, p_to VARCHAR, p_date DATE)
+    RETURNS NUMERIC AS $$
+SELECT COALESCE(
+               (SELECT er.rate FROM exchange_rates er
+                WHERE er.from_currency = p_from
+                  AND er.to_currency = p_to
+                  AND er.date <= p_date
+                ORDER BY er.date DESC
+                LIMIT 1),
+               (SELECT 1 / er.rate FROM exchange_rates er
+                WHERE er.from_currency = p_to
+                  AND er.to_currency = p_from
+                  AND er.date <= p_date
+                ORDER BY er.date DESC
+                LIMIT 1)
+       );
+$$ LANGUAGE sql STABLE;
+COMMENT ON FUNCTION find_pair_exchange_rate(VARCHAR, VARCHAR, DATE) IS
+    'Rate converting 1 p_from to p_to from the latest direct or inverse rate up to p_date, NULL if none is stored.';
+
+-- Falls back to a cross rate through EUR, then RSD
+CREATE OR REPLACE FUNCTION find_exchange_rate(p_from VARCHAR, p_to VARCHAR, p_date DATE)
+    RETURNS NUMERIC AS $$
+SELECT CASE
+           WHEN p_from = p_to THEN 1
+           ELSE COALESCE(
+                   find_pair_exchange_rate(p_from, p_to, p_date),
+                   CASE
+                       WHEN p_from <> 'EUR' AND p_to <> 'EUR'
+                           THEN find_pair_exchange_rate(p_from, 'EUR', p_date)
+                                    * find_pair_exchange_rate('EUR', p_to, p_date)
+                       END,
+                   CASE
+                       WHEN p_from <> 'RSD' AND p_to <> 'RSD'
+                           THEN find_pair_exchange_rate(p_from, 'RSD', p_date)
+                                    * find_pair_exchange_rate('RSD', p_to, p_date)
+                       END
+                )
+           END;
+$$ LANGUAGE sql STABLE;
+COMMENT ON FUNCTION find_exchange_rate(VARCHAR, VARCHAR, DATE) IS
+    'Rate converting 1 p_from to p_to up to p_date: direct, inverse or cross rate through EUR or RSD. NULL if it cannot be derived.';
+
+COMMIT;
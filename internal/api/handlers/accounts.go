@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -15,14 +16,22 @@ import (
 )
 
 type AccountHandler struct {
-	accountRepo *repository.AccountRepository
-	validate    *validator.Validate
+	accountRepo     *repository.AccountRepository
+	transactionRepo *repository.TransactionRepository
+	currencyRepo    *repository.CurrencyRepository
+	validate        *validator.Validate
 }
 
-func NewAccountHandler(accountRepo *repository.AccountRepository) *AccountHandler {
+func NewAccountHandler(
+	accountRepo *repository.AccountRepository,
+	transactionRepo *repository.TransactionRepository,
+	currencyRepo *repository.CurrencyRepository,
+) *AccountHandler {
 	return &AccountHandler{
-		accountRepo: accountRepo,
-		validate:    validator.New(),
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		currencyRepo:    currencyRepo,
+		validate:        newCurrencyValidator(currencyRepo),
 	}
 }
 
@@ -88,7 +97,7 @@ func (h *AccountHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.validate.Struct(req); err != nil {
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
 		writeValidationError(w, formatValidationErrors(err))
 		return
 	}
@@ -100,6 +109,10 @@ func (h *AccountHandler) Create(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if errors := validateMinorUnits(r.Context(), h.currencyRepo, "initial_balance", req.InitialBalance, req.Currency); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
 
 	account, err := h.accountRepo.Create(r.Context(), repository.CreateAccountInput{
 		FamilyID:       familyID,
@@ -176,6 +189,12 @@ func (h *AccountHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
 	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid account ID format")
@@ -199,19 +218,36 @@ func (h *AccountHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.validate.Struct(req); err != nil {
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
 		writeValidationError(w, formatValidationErrors(err))
 		return
 	}
 
+	// Business validation
+	if req.InitialBalance != nil && req.InitialBalance.IsNegative() {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "initial_balance", Message: "Initial balance cannot be negative"},
+		})
+		return
+	}
+	if req.InitialBalance != nil {
+		if errors := validateMinorUnits(r.Context(), h.currencyRepo, "initial_balance", *req.InitialBalance, existing.Currency); len(errors) > 0 {
+			writeValidationError(w, errors)
+			return
+		}
+	}
+
 	// Build update input
-	input := repository.UpdateAccountInput{ID: accountID}
+	input := repository.UpdateAccountInput{ID: accountID, UpdatedBy: userID}
 	if req.Name != nil {
 		input.Name = req.Name
 	}
 	if req.IsActive != nil {
 		input.IsActive = req.IsActive
 	}
+	if req.InitialBalance != nil {
+		input.InitialBalance = req.InitialBalance
+	}
 
 	account, err := h.accountRepo.Update(r.Context(), input)
 	if err != nil {
@@ -311,6 +347,107 @@ func (h *AccountHandler) GetBalance(w http.ResponseWriter, r *http.Request) {
 	writeSuccess(w, http.StatusOK, response)
 }
 
+// AdjustBalance godoc
+// @Summary Adjust account balance
+// @Description Sets the account balance as of a date by creating a transaction in the system "Balance adjustment" category
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body dto.AdjustBalanceRequest true "Target balance"
+// @Success 201 {object} dto.SuccessResponse{data=dto.BalanceAdjustmentResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/accounts/{id}/adjust-balance [post]
+func (h *AccountHandler) AdjustBalance(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid account ID format")
+		return
+	}
+
+	// Check account exists and belongs to family
+	existing, err := h.accountRepo.GetByID(r.Context(), accountID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Account not found")
+		return
+	}
+	if existing.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Account not found")
+		return
+	}
+
+	var req dto.AdjustBalanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	date, dateErrors := req.ParseDate()
+	if len(dateErrors) > 0 {
+		writeValidationError(w, dateErrors)
+		return
+	}
+	if errors := validateMinorUnits(r.Context(), h.currencyRepo, "target_balance", *req.TargetBalance, existing.Currency); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	result, err := h.transactionRepo.CreateBalanceAdjustment(r.Context(), repository.BalanceAdjustmentInput{
+		AccountID:     accountID,
+		TargetBalance: *req.TargetBalance,
+		Date:          date,
+		CreatedBy:     userID,
+	})
+	if errors.Is(err, repository.ErrBalanceUnchanged) {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "target_balance", Message: "Account balance already matches target balance"},
+		})
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to adjust balance")
+		return
+	}
+
+	// Reload account to return recalculated balance
+	account, err := h.accountRepo.GetByID(r.Context(), accountID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch account")
+		return
+	}
+
+	response := dto.BalanceAdjustmentResponse{
+		Account:         mapAccount(account),
+		TransactionID:   result.Transaction.ID,
+		Date:            date.Format("2006-01-02"),
+		PreviousBalance: result.PreviousBalance,
+		TargetBalance:   *req.TargetBalance,
+		Difference:      result.Difference,
+	}
+
+	writeSuccess(w, http.StatusCreated, response)
+}
+
 // --- Helper functions ---
 
 func writeMessage(w http.ResponseWriter, status int, message string) {
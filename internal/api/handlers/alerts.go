@@ -0,0 +1,532 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/DigitLock/expense-tracker/internal/api/middleware"
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+	"github.com/DigitLock/expense-tracker/internal/dto"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+type AlertHandler struct {
+	alertRepo    *repository.AlertRepository
+	categoryRepo *repository.CategoryRepository
+	familyRepo   *repository.FamilyRepository
+	validate     *validator.Validate
+}
+
+func NewAlertHandler(
+	alertRepo *repository.AlertRepository,
+	categoryRepo *repository.CategoryRepository,
+	familyRepo *repository.FamilyRepository,
+) *AlertHandler {
+	return &AlertHandler{
+		alertRepo:    alertRepo,
+		categoryRepo: categoryRepo,
+		familyRepo:   familyRepo,
+		validate:     validator.New(),
+	}
+}
+
+// List godoc
+// @Summary List alerts
+// @Description Returns latest spending alerts of the family with read state of the current user
+// @Tags alerts
+// @Produce json
+// @Security BearerAuth
+// @Param unread query bool false "Only unread alerts"
+// @Param limit query int false "Max number of alerts (default: 50, max: 200)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AlertListResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/alerts [get]
+func (h *AlertHandler) List(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	limit := int32(50)
+	if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 && l <= 200 {
+		limit = int32(l)
+	}
+	unreadOnly := r.URL.Query().Get("unread") == "true"
+
+	alerts, err := h.alertRepo.ListForUser(r.Context(), familyID, userID, unreadOnly, limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch alerts")
+		return
+	}
+
+	unread, err := h.alertRepo.CountUnread(r.Context(), familyID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to count unread alerts")
+		return
+	}
+
+	categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch categories")
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(categories))
+	for _, c := range categories {
+		names[c.ID] = c.Name
+	}
+
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	response := make([]dto.AlertResponse, len(alerts))
+	for i, a := range alerts {
+		response[i] = mapAlert(a, names, baseCurrency)
+	}
+
+	writeSuccess(w, http.StatusOK, dto.AlertListResponse{
+		Alerts:      response,
+		UnreadCount: unread,
+	})
+}
+
+// MarkRead godoc
+// @Summary Mark alert as read
+// @Description Marks an alert as read by the current user
+// @Tags alerts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alert ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/alerts/{id}/read [post]
+func (h *AlertHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	h.updateReadState(w, r, false)
+}
+
+// Acknowledge godoc
+// @Summary Acknowledge alert
+// @Description Marks an alert as read and acknowledged by the current user
+// @Tags alerts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Alert ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/alerts/{id}/acknowledge [post]
+func (h *AlertHandler) Acknowledge(w http.ResponseWriter, r *http.Request) {
+	h.updateReadState(w, r, true)
+}
+
+// MarkAllRead godoc
+// @Summary Mark all alerts as read
+// @Description Marks all alerts of the family as read by the current user
+// @Tags alerts
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/alerts/read-all [post]
+func (h *AlertHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	marked, err := h.alertRepo.MarkAllRead(r.Context(), familyID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to mark alerts as read")
+		return
+	}
+
+	writeMessage(w, http.StatusOK, fmt.Sprintf("%d alerts marked as read", marked))
+}
+
+// ListThresholds godoc
+// @Summary List alert thresholds
+// @Description Returns spending thresholds of the family
+// @Tags alerts
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.AlertThresholdListResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/alerts/thresholds [get]
+func (h *AlertHandler) ListThresholds(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	thresholds, err := h.alertRepo.ListThresholds(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch alert thresholds")
+		return
+	}
+
+	categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch categories")
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(categories))
+	for _, c := range categories {
+		names[c.ID] = c.Name
+	}
+
+	response := make([]dto.AlertThresholdResponse, len(thresholds))
+	for i, t := range thresholds {
+		response[i] = mapAlertThreshold(t, names)
+	}
+
+	writeSuccess(w, http.StatusOK, dto.AlertThresholdListResponse{Thresholds: response})
+}
+
+// CreateThreshold godoc
+// @Summary Create alert threshold
+// @Description Creates a spending threshold for an expense category (limit defaults to its monthly budget) or for overall monthly spending
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateAlertThresholdRequest true "Threshold data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.AlertThresholdResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/alerts/thresholds [post]
+func (h *AlertHandler) CreateThreshold(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	var req dto.CreateAlertThresholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	names := make(map[uuid.UUID]string)
+
+	// Validate category
+	if req.CategoryID != nil {
+		category, err := h.categoryRepo.GetByID(r.Context(), *req.CategoryID)
+		if err != nil || category.FamilyID != familyID {
+			writeValidationError(w, []dto.ValidationError{
+				{Field: "category_id", Message: "Category not found"},
+			})
+			return
+		}
+		if category.Type != "expense" {
+			writeValidationError(w, []dto.ValidationError{
+				{Field: "category_id", Message: "Thresholds can only be set for expense categories"},
+			})
+			return
+		}
+		names[category.ID] = category.Name
+	}
+
+	// One threshold per category and one for overall spending
+	existing, err := h.alertRepo.ListThresholds(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch alert thresholds")
+		return
+	}
+	for _, t := range existing {
+		if t.CategoryID.Valid == (req.CategoryID != nil) && (req.CategoryID == nil || t.CategoryID.Bytes == *req.CategoryID) {
+			writeError(w, http.StatusConflict, "THRESHOLD_EXISTS", "Alert threshold for this category already exists")
+			return
+		}
+	}
+
+	threshold, err := h.alertRepo.CreateThreshold(r.Context(), repository.CreateThresholdInput{
+		FamilyID:    familyID,
+		CategoryID:  req.CategoryID,
+		LimitAmount: req.LimitAmount,
+		Percentages: req.Percentages,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create alert threshold")
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, mapAlertThreshold(threshold, names))
+}
+
+// UpdateThreshold godoc
+// @Summary Update alert threshold
+// @Description Updates limit and percentages of an alert threshold
+// @Tags alerts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Threshold ID"
+// @Param request body dto.UpdateAlertThresholdRequest true "Threshold data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AlertThresholdResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/alerts/thresholds/{id} [patch]
+func (h *AlertHandler) UpdateThreshold(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	thresholdID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid threshold ID format")
+		return
+	}
+
+	// Check threshold exists and belongs to family
+	existing, err := h.alertRepo.GetThreshold(r.Context(), thresholdID)
+	if err != nil || existing.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Alert threshold not found")
+		return
+	}
+
+	var req dto.UpdateAlertThresholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	if req.UseBudget && !existing.CategoryID.Valid {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "use_budget", Message: "Overall spending threshold requires a limit amount"},
+		})
+		return
+	}
+
+	threshold, err := h.alertRepo.UpdateThreshold(r.Context(), repository.UpdateThresholdInput{
+		ID:          thresholdID,
+		LimitAmount: req.LimitAmount,
+		ClearLimit:  req.UseBudget,
+		Percentages: req.Percentages,
+		UpdatedBy:   userID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update alert threshold")
+		return
+	}
+
+	names := make(map[uuid.UUID]string)
+	if threshold.CategoryID.Valid {
+		if category, err := h.categoryRepo.GetByIDIncludingInactive(r.Context(), threshold.CategoryID.Bytes); err == nil {
+			names[category.ID] = category.Name
+		}
+	}
+
+	writeSuccess(w, http.StatusOK, mapAlertThreshold(threshold, names))
+}
+
+// DeleteThreshold godoc
+// @Summary Delete alert threshold
+// @Description Soft deletes an alert threshold. Alerts already raised are kept.
+// @Tags alerts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Threshold ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/alerts/thresholds/{id} [delete]
+func (h *AlertHandler) DeleteThreshold(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	thresholdID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid threshold ID format")
+		return
+	}
+
+	// Check threshold exists and belongs to family
+	existing, err := h.alertRepo.GetThreshold(r.Context(), thresholdID)
+	if err != nil || existing.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Alert threshold not found")
+		return
+	}
+
+	if err := h.alertRepo.DeleteThreshold(r.Context(), thresholdID, userID); err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete alert threshold")
+		return
+	}
+
+	writeMessage(w, http.StatusOK, "Alert threshold deleted successfully")
+}
+
+// --- Helper functions ---
+
+func (h *AlertHandler) updateReadState(w http.ResponseWriter, r *http.Request, acknowledge bool) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	alertID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid alert ID format")
+		return
+	}
+
+	// Check alert exists and belongs to family
+	alert, err := h.alertRepo.GetByID(r.Context(), alertID)
+	if err != nil || alert.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Alert not found")
+		return
+	}
+
+	if acknowledge {
+		if err := h.alertRepo.Acknowledge(r.Context(), alertID, userID); err != nil {
+			writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to acknowledge alert")
+			return
+		}
+		writeMessage(w, http.StatusOK, "Alert acknowledged")
+		return
+	}
+
+	if err := h.alertRepo.MarkRead(r.Context(), alertID, userID); err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to mark alert as read")
+		return
+	}
+	writeMessage(w, http.StatusOK, "Alert marked as read")
+}
+
+func mapAlertThreshold(t sqlc.AlertThreshold, names map[uuid.UUID]string) dto.AlertThresholdResponse {
+	response := dto.AlertThresholdResponse{
+		ID:          t.ID,
+		LimitAmount: numericToDecimalPtr(t.LimitAmount),
+		UsesBudget:  !t.LimitAmount.Valid,
+		Percentages: t.Percentages,
+		CreatedAt:   t.CreatedAt,
+		UpdatedAt:   t.UpdatedAt,
+	}
+	if t.CategoryID.Valid {
+		categoryID := uuid.UUID(t.CategoryID.Bytes)
+		response.CategoryID = &categoryID
+		if name, ok := names[categoryID]; ok {
+			response.CategoryName = &name
+		}
+	}
+	return response
+}
+
+func mapAlert(a sqlc.ListAlertsForUserRow, names map[uuid.UUID]string, currency string) dto.AlertResponse {
+	response := dto.AlertResponse{
+		ID:             a.ID,
+		ThresholdID:    a.ThresholdID,
+		Month:          a.Period.Time.Format("2006-01"),
+		Percentage:     a.Percentage,
+		LimitAmount:    a.LimitAmount,
+		SpentAmount:    a.SpentAmount,
+		IsRead:         a.ReadAt.Valid,
+		ReadAt:         timestampToTimePtr(a.ReadAt),
+		IsAcknowledged: a.AcknowledgedAt.Valid,
+		AcknowledgedAt: timestampToTimePtr(a.AcknowledgedAt),
+		CreatedAt:      a.CreatedAt,
+	}
+
+	subject := "Monthly spending"
+	if a.CategoryID.Valid {
+		categoryID := uuid.UUID(a.CategoryID.Bytes)
+		response.CategoryID = &categoryID
+		if name, ok := names[categoryID]; ok {
+			response.CategoryName = &name
+			subject = name
+		}
+	}
+
+	response.Message = fmt.Sprintf("%s reached %d%% of the monthly limit (%s of %s %s)",
+		subject, a.Percentage, a.SpentAmount.StringFixed(2), a.LimitAmount.StringFixed(2), currency)
+
+	return response
+}
+
+func timestampToTimePtr(ts pgtype.Timestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
@@ -127,6 +127,8 @@ func formatValidationMessage(e validator.FieldError) string {
 		return "Value is too short"
 	case "max":
 		return "Value is too long"
+	case "currency":
+		return "Unsupported currency"
 	default:
 		return "Invalid value"
 	}
@@ -0,0 +1,492 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/DigitLock/expense-tracker/internal/api/middleware"
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+	"github.com/DigitLock/expense-tracker/internal/dto"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+type BudgetHandler struct {
+	budgetRepo   *repository.BudgetRepository
+	categoryRepo *repository.CategoryRepository
+	validate     *validator.Validate
+}
+
+func NewBudgetHandler(
+	budgetRepo *repository.BudgetRepository,
+	categoryRepo *repository.CategoryRepository,
+) *BudgetHandler {
+	return &BudgetHandler{
+		budgetRepo:   budgetRepo,
+		categoryRepo: categoryRepo,
+		validate:     validator.New(),
+	}
+}
+
+// List godoc
+// @Summary List budgets
+// @Description Returns all budgets of the family. With month, returns budgets in effect for that month (monthly budget, otherwise the default one).
+// @Tags budgets
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month (YYYY-MM)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BudgetListResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/budgets [get]
+func (h *BudgetHandler) List(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	var budgets []sqlc.Budget
+	var err error
+	if month, parseErr := time.Parse("2006-01", r.URL.Query().Get("month")); parseErr == nil {
+		budgets, err = h.budgetRepo.ListForMonth(r.Context(), familyID, month)
+	} else {
+		budgets, err = h.budgetRepo.ListByFamily(r.Context(), familyID)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch budgets")
+		return
+	}
+
+	categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch categories")
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(categories))
+	for _, c := range categories {
+		names[c.ID] = c.Name
+	}
+
+	response := make([]dto.BudgetResponse, len(budgets))
+	for i, b := range budgets {
+		response[i] = mapBudget(b, names[b.CategoryID])
+	}
+
+	writeSuccess(w, http.StatusOK, dto.BudgetListResponse{Budgets: response})
+}
+
+// Create godoc
+// @Summary Create budget
+// @Description Creates a monthly budget for an expense category. Without month, the budget is the default for every month.
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateBudgetRequest true "Budget data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.BudgetResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/budgets [post]
+func (h *BudgetHandler) Create(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	var req dto.CreateBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	// Validate category
+	category, errors := h.validateBudgetCategory(r.Context(), familyID, req.CategoryID)
+	if len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	var month *time.Time
+	if req.Month != "" {
+		parsed, _ := time.Parse("2006-01", req.Month)
+		month = &parsed
+	}
+
+	// One budget per category and month
+	if _, err := h.budgetRepo.GetByCategoryMonth(r.Context(), req.CategoryID, month); err == nil {
+		writeError(w, http.StatusConflict, "BUDGET_EXISTS", "Budget for this category and month already exists")
+		return
+	}
+
+	budget, err := h.budgetRepo.Create(r.Context(), repository.CreateBudgetInput{
+		FamilyID:     familyID,
+		CategoryID:   req.CategoryID,
+		Month:        month,
+		Amount:       req.Amount,
+		RolloverMode: req.RolloverMode,
+		CreatedBy:    userID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create budget")
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, mapBudget(budget, category.Name))
+}
+
+// Get godoc
+// @Summary Get budget
+// @Description Returns a specific budget by ID
+// @Tags budgets
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Budget ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BudgetResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/budgets/{id} [get]
+func (h *BudgetHandler) Get(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	budgetID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid budget ID format")
+		return
+	}
+
+	budget, err := h.budgetRepo.GetByID(r.Context(), budgetID)
+	if err != nil || budget.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Budget not found")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, mapBudget(budget, h.categoryName(r, budget.CategoryID)))
+}
+
+// Update godoc
+// @Summary Update budget
+// @Description Updates budget amount and rollover mode
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Budget ID"
+// @Param request body dto.UpdateBudgetRequest true "Budget data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BudgetResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/budgets/{id} [patch]
+func (h *BudgetHandler) Update(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	budgetID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid budget ID format")
+		return
+	}
+
+	// Check budget exists and belongs to family
+	existing, err := h.budgetRepo.GetByID(r.Context(), budgetID)
+	if err != nil || existing.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Budget not found")
+		return
+	}
+
+	var req dto.UpdateBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	budget, err := h.budgetRepo.Update(r.Context(), repository.UpdateBudgetInput{
+		ID:           budgetID,
+		Amount:       req.Amount,
+		RolloverMode: req.RolloverMode,
+		UpdatedBy:    userID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update budget")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, mapBudget(budget, h.categoryName(r, budget.CategoryID)))
+}
+
+// Delete godoc
+// @Summary Delete budget
+// @Description Soft deletes a budget
+// @Tags budgets
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Budget ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/budgets/{id} [delete]
+func (h *BudgetHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	budgetID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid budget ID format")
+		return
+	}
+
+	// Check budget exists and belongs to family
+	existing, err := h.budgetRepo.GetByID(r.Context(), budgetID)
+	if err != nil || existing.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Budget not found")
+		return
+	}
+
+	if err := h.budgetRepo.Delete(r.Context(), budgetID, userID); err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete budget")
+		return
+	}
+
+	writeMessage(w, http.StatusOK, "Budget deleted successfully")
+}
+
+// ListAssignments godoc
+// @Summary List budget assignments
+// @Description Returns income assigned to categories for a month (envelope budgeting) and the amount still available to assign
+// @Tags budgets
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month (YYYY-MM), default: current month"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BudgetAssignmentListResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/budgets/assignments [get]
+func (h *BudgetHandler) ListAssignments(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	month := repository.BudgetMonth(time.Now())
+	if m := r.URL.Query().Get("month"); m != "" {
+		if parsed, err := time.Parse("2006-01", m); err == nil {
+			month = parsed
+		}
+	}
+
+	assignments, err := h.budgetRepo.ListAssignments(r.Context(), familyID, month)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch assignments")
+		return
+	}
+
+	available, err := h.budgetRepo.GetAvailableToAssign(r.Context(), familyID, month)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to calculate available to assign")
+		return
+	}
+
+	categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch categories")
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(categories))
+	for _, c := range categories {
+		names[c.ID] = c.Name
+	}
+
+	response := make([]dto.BudgetAssignmentResponse, len(assignments))
+	for i, a := range assignments {
+		response[i] = mapBudgetAssignment(a, names[a.CategoryID])
+	}
+
+	writeSuccess(w, http.StatusOK, dto.BudgetAssignmentListResponse{
+		Month:             month.Format("2006-01"),
+		Assignments:       response,
+		Income:            available.Income,
+		Assigned:          available.Assigned,
+		CarriedOver:       available.CarriedOver,
+		AvailableToAssign: available.Available,
+	})
+}
+
+// Assign godoc
+// @Summary Assign money to category
+// @Description Sets the amount of income assigned to an expense category for a month (envelope budgeting). Replaces the previous assignment; 0 clears it.
+// @Tags budgets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.AssignBudgetRequest true "Assignment data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BudgetAssignmentResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/budgets/assignments [put]
+func (h *BudgetHandler) Assign(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	var req dto.AssignBudgetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	// Validate category
+	category, errors := h.validateBudgetCategory(r.Context(), familyID, req.CategoryID)
+	if len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	month, _ := time.Parse("2006-01", req.Month)
+
+	assignment, err := h.budgetRepo.Assign(r.Context(), repository.AssignBudgetInput{
+		FamilyID:   familyID,
+		CategoryID: req.CategoryID,
+		Month:      month,
+		Amount:     req.Amount,
+		AssignedBy: userID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to assign budget")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, mapBudgetAssignment(assignment, category.Name))
+}
+
+// --- Helper functions ---
+
+// validateBudgetCategory checks that a category can have a budget: own family, expense, not system
+func (h *BudgetHandler) validateBudgetCategory(ctx context.Context, familyID, categoryID uuid.UUID) (sqlc.Category, []dto.ValidationError) {
+	category, err := h.categoryRepo.GetByID(ctx, categoryID)
+	if err != nil || category.FamilyID != familyID {
+		return category, []dto.ValidationError{
+			{Field: "category_id", Message: "Category not found"},
+		}
+	}
+	if category.Type != "expense" {
+		return category, []dto.ValidationError{
+			{Field: "category_id", Message: "Budgets can only be set for expense categories"},
+		}
+	}
+	if category.IsSystem {
+		return category, []dto.ValidationError{
+			{Field: "category_id", Message: "System categories cannot be assigned manually"},
+		}
+	}
+	return category, nil
+}
+
+func (h *BudgetHandler) categoryName(r *http.Request, categoryID uuid.UUID) string {
+	if category, err := h.categoryRepo.GetByIDIncludingInactive(r.Context(), categoryID); err == nil {
+		return category.Name
+	}
+	return "Unknown"
+}
+
+func mapBudget(b sqlc.Budget, categoryName string) dto.BudgetResponse {
+	response := dto.BudgetResponse{
+		ID:           b.ID,
+		CategoryID:   b.CategoryID,
+		CategoryName: categoryName,
+		IsDefault:    !b.Month.Valid,
+		Amount:       b.Amount,
+		RolloverMode: b.RolloverMode,
+		CreatedAt:    b.CreatedAt,
+		UpdatedAt:    b.UpdatedAt,
+	}
+	if b.Month.Valid {
+		month := b.Month.Time.Format("2006-01")
+		response.Month = &month
+	}
+	return response
+}
+
+func mapBudgetAssignment(a sqlc.BudgetAssignment, categoryName string) dto.BudgetAssignmentResponse {
+	return dto.BudgetAssignmentResponse{
+		CategoryID:   a.CategoryID,
+		CategoryName: categoryName,
+		Month:        a.Month.Time.Format("2006-01"),
+		Amount:       a.Amount,
+		UpdatedAt:    a.UpdatedAt,
+	}
+}
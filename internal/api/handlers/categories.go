@@ -1,12 +1,18 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 
 	"github.com/DigitLock/expense-tracker/internal/api/middleware"
 	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
@@ -15,14 +21,22 @@ import (
 )
 
 type CategoryHandler struct {
-	categoryRepo *repository.CategoryRepository
-	validate     *validator.Validate
+	categoryRepo    *repository.CategoryRepository
+	transactionRepo *repository.TransactionRepository
+	familyRepo      *repository.FamilyRepository
+	validate        *validator.Validate
 }
 
-func NewCategoryHandler(categoryRepo *repository.CategoryRepository) *CategoryHandler {
+func NewCategoryHandler(
+	categoryRepo *repository.CategoryRepository,
+	transactionRepo *repository.TransactionRepository,
+	familyRepo *repository.FamilyRepository,
+) *CategoryHandler {
 	return &CategoryHandler{
-		categoryRepo: categoryRepo,
-		validate:     validator.New(),
+		categoryRepo:    categoryRepo,
+		transactionRepo: transactionRepo,
+		familyRepo:      familyRepo,
+		validate:        validator.New(),
 	}
 }
 
@@ -68,6 +82,85 @@ func (h *CategoryHandler) List(w http.ResponseWriter, r *http.Request) {
 	writeSuccess(w, http.StatusOK, dto.CategoryListResponse{Categories: categories})
 }
 
+// Tree godoc
+// @Summary Category tree
+// @Description Returns active categories as a nested hierarchy. With a date range, every node contains its own totals and totals rolled up from all descendants (in family base currency).
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Param type query string false "Filter by type: income or expense"
+// @Param include_totals query bool false "Include transaction totals (default period: current month)"
+// @Param start_date query string false "Start date (YYYY-MM-DD), implies include_totals, default: first day of current month"
+// @Param end_date query string false "End date (YYYY-MM-DD), implies include_totals, default: today"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CategoryTreeResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/categories/tree [get]
+func (h *CategoryHandler) Tree(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	typeFilter := r.URL.Query().Get("type")
+
+	var dbCategories []sqlc.Category
+	var err error
+	if typeFilter == "income" || typeFilter == "expense" {
+		dbCategories, err = h.categoryRepo.ListByType(r.Context(), familyID, typeFilter)
+	} else {
+		dbCategories, err = h.categoryRepo.ListByFamily(r.Context(), familyID)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch categories")
+		return
+	}
+
+	response := dto.CategoryTreeResponse{}
+
+	// Optional totals for a date range
+	var totals map[uuid.UUID]categoryTotal
+	sd := r.URL.Query().Get("start_date")
+	ed := r.URL.Query().Get("end_date")
+	if sd != "" || ed != "" || r.URL.Query().Get("include_totals") == "true" {
+		now := time.Now()
+		startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+		endDate := now
+
+		if parsed, err := time.Parse("2006-01-02", sd); err == nil {
+			startDate = parsed
+		}
+		if parsed, err := time.Parse("2006-01-02", ed); err == nil {
+			endDate = parsed
+		}
+
+		totals = make(map[uuid.UUID]categoryTotal)
+		for _, transactionType := range []string{"income", "expense"} {
+			summaries, err := h.transactionRepo.GetSummaryByCategory(r.Context(), familyID, transactionType, startDate, endDate)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to calculate category totals")
+				return
+			}
+			for _, s := range summaries {
+				totals[s.CategoryID] = categoryTotal{amount: s.Total, count: int(s.Count)}
+			}
+		}
+
+		response.Period = &dto.ReportPeriod{
+			StartDate: startDate.Format("2006-01-02"),
+			EndDate:   endDate.Format("2006-01-02"),
+		}
+		baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+		if !ok {
+			return
+		}
+		response.Currency = baseCurrency
+	}
+
+	response.Categories = buildCategoryTree(dbCategories, totals)
+	writeSuccess(w, http.StatusOK, response)
+}
+
 // Create godoc
 // @Summary Create category
 // @Description Creates a new category for the authenticated user's family
@@ -98,37 +191,20 @@ func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate parent_id if provided
-	if req.ParentID != nil {
-		parent, err := h.categoryRepo.GetByID(r.Context(), *req.ParentID)
-		if err != nil {
-			writeValidationError(w, []dto.ValidationError{
-				{Field: "parent_id", Message: "Parent category not found"},
-			})
-			return
-		}
-		// Parent must belong to same family
-		if parent.FamilyID != familyID {
-			writeValidationError(w, []dto.ValidationError{
-				{Field: "parent_id", Message: "Parent category not found"},
-			})
-			return
-		}
-		// Parent must be same type
-		if parent.Type != req.Type {
-			writeValidationError(w, []dto.ValidationError{
-				{Field: "parent_id", Message: "Parent category must be the same type"},
-			})
-			return
-		}
-	}
-
 	category, err := h.categoryRepo.Create(r.Context(), repository.CreateCategoryInput{
-		FamilyID: familyID,
-		Name:     req.Name,
-		Type:     req.Type,
-		ParentID: req.ParentID,
+		FamilyID:    familyID,
+		Name:        req.Name,
+		Type:        req.Type,
+		ParentID:    req.ParentID,
+		Description: req.Description,
+		Icon:        req.Icon,
+		Color:       req.Color,
+		SortOrder:   req.SortOrder,
 	})
+	if errs := h.hierarchyValidationErrors(err, "parent_id"); errs != nil {
+		writeValidationError(w, errs)
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create category")
 		return
@@ -213,6 +289,10 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusNotFound, "NOT_FOUND", "Category not found")
 		return
 	}
+	if existing.IsSystem {
+		writeError(w, http.StatusForbidden, "SYSTEM_CATEGORY", "System categories cannot be modified")
+		return
+	}
 
 	var req dto.UpdateCategoryRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -225,35 +305,9 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate parent_id if provided
-	if req.ParentID != nil {
-		// Can't be own parent
-		if *req.ParentID == categoryID {
-			writeValidationError(w, []dto.ValidationError{
-				{Field: "parent_id", Message: "Category cannot be its own parent"},
-			})
-			return
-		}
-
-		parent, err := h.categoryRepo.GetByID(r.Context(), *req.ParentID)
-		if err != nil {
-			writeValidationError(w, []dto.ValidationError{
-				{Field: "parent_id", Message: "Parent category not found"},
-			})
-			return
-		}
-		if parent.FamilyID != familyID {
-			writeValidationError(w, []dto.ValidationError{
-				{Field: "parent_id", Message: "Parent category not found"},
-			})
-			return
-		}
-		if parent.Type != existing.Type {
-			writeValidationError(w, []dto.ValidationError{
-				{Field: "parent_id", Message: "Parent category must be the same type"},
-			})
-			return
-		}
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
 	}
 
 	// Build update input
@@ -267,8 +321,24 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 	if req.IsActive != nil {
 		input.IsActive = req.IsActive
 	}
+	if req.Description != nil {
+		input.Description = req.Description
+	}
+	if req.Icon != nil {
+		input.Icon = req.Icon
+	}
+	if req.Color != nil {
+		input.Color = req.Color
+	}
+	if req.SortOrder != nil {
+		input.SortOrder = req.SortOrder
+	}
 
 	category, err := h.categoryRepo.Update(r.Context(), input)
+	if errs := h.hierarchyValidationErrors(err, "parent_id"); errs != nil {
+		writeValidationError(w, errs)
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update category")
 		return
@@ -279,14 +349,18 @@ func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 // Delete godoc
 // @Summary Delete category
-// @Description Soft deletes a category
+// @Description Soft deletes a category. If the category has transactions, replacement_id is required: transactions, interest settings, budgets, alert thresholds and child categories are moved to the replacement. Otherwise child categories are moved to the parent of the deleted category.
 // @Tags categories
 // @Produce json
 // @Security BearerAuth
 // @Param id path string true "Category ID"
+// @Param replacement_id query string false "Category to move transactions and child categories to"
 // @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
 // @Router /api/v1/categories/{id} [delete]
 func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	familyID, ok := middleware.GetFamilyID(r.Context())
@@ -295,6 +369,12 @@ func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
 	categoryID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid category ID format")
@@ -311,8 +391,39 @@ func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusNotFound, "NOT_FOUND", "Category not found")
 		return
 	}
+	if existing.IsSystem {
+		writeError(w, http.StatusForbidden, "SYSTEM_CATEGORY", "System categories cannot be modified")
+		return
+	}
+
+	input := repository.DeleteCategoryInput{
+		ID:        categoryID,
+		DeletedBy: userID,
+	}
 
-	if err := h.categoryRepo.Delete(r.Context(), categoryID); err != nil {
+	if rid := r.URL.Query().Get("replacement_id"); rid != "" {
+		replacementID, err := uuid.Parse(rid)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid replacement category ID format")
+			return
+		}
+		if errs := h.validateMergeTarget(r.Context(), existing, replacementID, "replacement_id"); len(errs) > 0 {
+			writeValidationError(w, errs)
+			return
+		}
+		input.ReplacementID = &replacementID
+	}
+
+	err = h.categoryRepo.Delete(r.Context(), input)
+	if errors.Is(err, repository.ErrCategoryInUse) {
+		writeError(w, http.StatusConflict, "CATEGORY_IN_USE", "Category has transactions, specify replacement_id to move them")
+		return
+	}
+	if errs := h.hierarchyValidationErrors(err, "replacement_id"); errs != nil {
+		writeValidationError(w, errs)
+		return
+	}
+	if err != nil {
 		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete category")
 		return
 	}
@@ -320,6 +431,268 @@ func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	writeMessage(w, http.StatusOK, "Category deleted successfully")
 }
 
+// Merge godoc
+// @Summary Merge categories
+// @Description Moves all transactions, interest settings, budgets, alert thresholds and child categories of a category to the target category and deactivates the source category. Budgets of the same month are added to the target budget.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Source category ID"
+// @Param request body dto.MergeCategoryRequest true "Merge target"
+// @Success 200 {object} dto.SuccessResponse{data=dto.MergeCategoryResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/categories/{id}/merge [post]
+func (h *CategoryHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	categoryID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid category ID format")
+		return
+	}
+
+	// Check source category exists and belongs to family
+	source, err := h.categoryRepo.GetByID(r.Context(), categoryID)
+	if err != nil || source.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Category not found")
+		return
+	}
+	if source.IsSystem {
+		writeError(w, http.StatusForbidden, "SYSTEM_CATEGORY", "System categories cannot be modified")
+		return
+	}
+
+	var req dto.MergeCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errs := h.validateMergeTarget(r.Context(), source, req.TargetID, "target_id"); len(errs) > 0 {
+		writeValidationError(w, errs)
+		return
+	}
+
+	result, err := h.categoryRepo.Merge(r.Context(), repository.MergeCategoriesInput{
+		SourceID: categoryID,
+		TargetID: req.TargetID,
+		MergedBy: userID,
+	})
+	if errs := h.hierarchyValidationErrors(err, "target_id"); errs != nil {
+		writeValidationError(w, errs)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to merge categories")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, dto.MergeCategoryResponse{
+		Category:          mapCategory(result.Target),
+		TransactionsMoved: result.TransactionsMoved,
+		ChildrenMoved:     result.ChildrenMoved,
+	})
+}
+
+// Reorder godoc
+// @Summary Reorder categories
+// @Description Sets display order of sibling categories. ids must contain all active categories with the same parent and type, in the new order.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ReorderCategoriesRequest true "Ordered category IDs"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CategoryListResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/categories/reorder [post]
+func (h *CategoryHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	var req dto.ReorderCategoriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	// All categories must be siblings: same family, parent and type
+	first, err := h.categoryRepo.GetByID(r.Context(), req.IDs[0])
+	if err != nil || first.FamilyID != familyID {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "ids", Message: "Category not found"},
+		})
+		return
+	}
+
+	var siblings []sqlc.Category
+	if first.ParentID.Valid {
+		siblings, err = h.categoryRepo.ListChildCategories(r.Context(), uuid.UUID(first.ParentID.Bytes))
+	} else {
+		siblings, err = h.categoryRepo.ListRootCategories(r.Context(), familyID)
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch categories")
+		return
+	}
+
+	expected := make(map[uuid.UUID]sqlc.Category)
+	for _, c := range siblings {
+		if c.Type == first.Type {
+			expected[c.ID] = c
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		if _, ok := expected[id]; !ok || seen[id] {
+			writeValidationError(w, []dto.ValidationError{
+				{Field: "ids", Message: "All categories must have the same parent and type and be listed once"},
+			})
+			return
+		}
+		seen[id] = true
+	}
+	if len(seen) != len(expected) {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "ids", Message: "All categories with the same parent and type must be listed"},
+		})
+		return
+	}
+
+	if err := h.categoryRepo.Reorder(r.Context(), req.IDs); err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to reorder categories")
+		return
+	}
+
+	// Return categories in the new order
+	reordered := make([]sqlc.Category, len(req.IDs))
+	for i, id := range req.IDs {
+		c := expected[id]
+		c.SortOrder = int32(i)
+		reordered[i] = c
+	}
+
+	writeSuccess(w, http.StatusOK, dto.CategoryListResponse{
+		Categories: mapCategories(reordered),
+	})
+}
+
+// Move godoc
+// @Summary Move category
+// @Description Moves a category together with all its subcategories under a new parent (or to the top level if parent_id is null)
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Category ID"
+// @Param request body dto.MoveCategoryRequest true "New parent"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CategoryResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/categories/{id}/move [post]
+func (h *CategoryHandler) Move(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	categoryID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid category ID format")
+		return
+	}
+
+	// Check category exists and belongs to family
+	existing, err := h.categoryRepo.GetByID(r.Context(), categoryID)
+	if err != nil || existing.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Category not found")
+		return
+	}
+	if existing.IsSystem {
+		writeError(w, http.StatusForbidden, "SYSTEM_CATEGORY", "System categories cannot be modified")
+		return
+	}
+
+	var req dto.MoveCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	category, err := h.categoryRepo.Move(r.Context(), repository.MoveCategoryInput{
+		ID:       categoryID,
+		ParentID: req.ParentID,
+		MovedBy:  userID,
+	})
+	if errs := h.hierarchyValidationErrors(err, "parent_id"); errs != nil {
+		writeValidationError(w, errs)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to move category")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, mapCategory(category))
+}
+
+// validateMergeTarget checks that a category can receive transactions and children of source
+func (h *CategoryHandler) validateMergeTarget(ctx context.Context, source sqlc.Category, targetID uuid.UUID, field string) []dto.ValidationError {
+	if targetID == source.ID {
+		return []dto.ValidationError{{Field: field, Message: "Target must be a different category"}}
+	}
+
+	target, err := h.categoryRepo.GetByID(ctx, targetID)
+	if err != nil || target.FamilyID != source.FamilyID {
+		return []dto.ValidationError{{Field: field, Message: "Category not found"}}
+	}
+	if target.Type != source.Type {
+		return []dto.ValidationError{{Field: field, Message: "Category type must match"}}
+	}
+	if target.IsSystem {
+		return []dto.ValidationError{{Field: field, Message: "System categories cannot be assigned manually"}}
+	}
+
+	return nil
+}
+
 // --- Helper functions ---
 
 func mapCategory(c sqlc.Category) dto.CategoryResponse {
@@ -331,16 +704,28 @@ func mapCategory(c sqlc.Category) dto.CategoryResponse {
 	}
 
 	return dto.CategoryResponse{
-		ID:        c.ID,
-		Name:      c.Name,
-		Type:      c.Type,
-		ParentID:  parentID,
-		IsActive:  c.IsActive,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: c.UpdatedAt,
+		ID:          c.ID,
+		Name:        c.Name,
+		Type:        c.Type,
+		ParentID:    parentID,
+		Description: textToStringPtr(c.Description),
+		Icon:        textToStringPtr(c.Icon),
+		Color:       textToStringPtr(c.Color),
+		SortOrder:   int(c.SortOrder),
+		IsActive:    c.IsActive,
+		IsSystem:    c.IsSystem,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
 	}
 }
 
+func textToStringPtr(t pgtype.Text) *string {
+	if !t.Valid {
+		return nil
+	}
+	return &t.String
+}
+
 func mapCategories(categories []sqlc.Category) []dto.CategoryResponse {
 	result := make([]dto.CategoryResponse, len(categories))
 	for i, c := range categories {
@@ -348,3 +733,128 @@ func mapCategories(categories []sqlc.Category) []dto.CategoryResponse {
 	}
 	return result
 }
+
+// hierarchyValidationErrors maps category hierarchy errors to validation errors.
+// Returns nil if err is not a hierarchy error.
+func (h *CategoryHandler) hierarchyValidationErrors(err error, field string) []dto.ValidationError {
+	var message string
+	switch {
+	case errors.Is(err, repository.ErrCategoryParentNotFound):
+		message = "Parent category not found"
+	case errors.Is(err, repository.ErrCategoryTypeMismatch):
+		message = "Parent category must be the same type"
+	case errors.Is(err, repository.ErrCategoryCycle):
+		message = "Category cannot be moved under itself or its subcategory"
+	case errors.Is(err, repository.ErrCategoryMergeIntoDescendant):
+		message = "Category cannot be merged into its own subcategory"
+	case errors.Is(err, repository.ErrCategoryMaxDepth):
+		message = fmt.Sprintf("Category hierarchy cannot be deeper than %d levels", h.categoryRepo.MaxDepth())
+	default:
+		return nil
+	}
+	return []dto.ValidationError{{Field: field, Message: message}}
+}
+
+// categoryTotal holds aggregated transaction amount (base currency) and count of a category
+type categoryTotal struct {
+	amount decimal.Decimal
+	count  int
+}
+
+// buildCategoryTree nests categories by parent_id. Categories whose parent is not in
+// the list (e.g. inactive parent) become roots. If totals is not nil, every node gets
+// its own totals and totals rolled up from all descendants.
+func buildCategoryTree(categories []sqlc.Category, totals map[uuid.UUID]categoryTotal) []dto.CategoryTreeNode {
+	present := make(map[uuid.UUID]bool, len(categories))
+	for _, c := range categories {
+		present[c.ID] = true
+	}
+
+	children := make(map[uuid.UUID][]sqlc.Category)
+	var roots []sqlc.Category
+	for _, c := range categories {
+		parentID := uuid.UUID(c.ParentID.Bytes)
+		if c.ParentID.Valid && present[parentID] && parentID != c.ID {
+			children[parentID] = append(children[parentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	// visited guards against cycles in inconsistent data
+	visited := make(map[uuid.UUID]bool, len(categories))
+
+	var build func(c sqlc.Category) dto.CategoryTreeNode
+	build = func(c sqlc.Category) dto.CategoryTreeNode {
+		visited[c.ID] = true
+
+		node := dto.CategoryTreeNode{
+			ID:        c.ID,
+			Name:      c.Name,
+			Type:      c.Type,
+			Icon:      textToStringPtr(c.Icon),
+			Color:     textToStringPtr(c.Color),
+			SortOrder: int(c.SortOrder),
+			IsSystem:  c.IsSystem,
+			Children:  []dto.CategoryTreeNode{},
+		}
+		if c.ParentID.Valid {
+			parentID := uuid.UUID(c.ParentID.Bytes)
+			node.ParentID = &parentID
+		}
+
+		own := totals[c.ID]
+		total := own
+		for _, child := range children[c.ID] {
+			if visited[child.ID] {
+				continue
+			}
+			childNode := build(child)
+			node.Children = append(node.Children, childNode)
+			if totals != nil {
+				total.amount = total.amount.Add(*childNode.Total)
+				total.count += *childNode.TransactionCount
+			}
+		}
+
+		if totals != nil {
+			node.OwnTotal = &own.amount
+			node.OwnTransactionCount = &own.count
+			node.Total = &total.amount
+			node.TransactionCount = &total.count
+		}
+
+		return node
+	}
+
+	result := make([]dto.CategoryTreeNode, 0, len(roots))
+	for _, c := range roots {
+		result = append(result, build(c))
+	}
+	return result
+}
+
+// rootCategoryIDs maps every category to the top-level ancestor of its hierarchy
+func rootCategoryIDs(categories []sqlc.Category) map[uuid.UUID]uuid.UUID {
+	parents := make(map[uuid.UUID]uuid.UUID, len(categories))
+	for _, c := range categories {
+		if c.ParentID.Valid {
+			parents[c.ID] = uuid.UUID(c.ParentID.Bytes)
+		}
+	}
+
+	roots := make(map[uuid.UUID]uuid.UUID, len(categories))
+	for _, c := range categories {
+		id := c.ID
+		// Depth is bounded by number of categories (guards against cycles)
+		for i := 0; i < len(categories); i++ {
+			parentID, ok := parents[id]
+			if !ok {
+				break
+			}
+			id = parentID
+		}
+		roots[c.ID] = id
+	}
+	return roots
+}
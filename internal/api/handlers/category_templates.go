@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/DigitLock/expense-tracker/internal/api/middleware"
+	"github.com/DigitLock/expense-tracker/internal/categorytemplates"
+	"github.com/DigitLock/expense-tracker/internal/dto"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+type CategoryTemplateHandler struct {
+	categoryRepo *repository.CategoryRepository
+}
+
+func NewCategoryTemplateHandler(categoryRepo *repository.CategoryRepository) *CategoryTemplateHandler {
+	return &CategoryTemplateHandler{categoryRepo: categoryRepo}
+}
+
+// List godoc
+// @Summary List category templates
+// @Description Returns built-in category templates that can be applied to the family
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.CategoryTemplateListResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/category-templates [get]
+func (h *CategoryTemplateHandler) List(w http.ResponseWriter, r *http.Request) {
+	if _, ok := middleware.GetFamilyID(r.Context()); !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	templates := categorytemplates.All()
+
+	response := dto.CategoryTemplateListResponse{
+		Templates: make([]dto.CategoryTemplateResponse, len(templates)),
+	}
+	for i, t := range templates {
+		response.Templates[i] = mapCategoryTemplate(t)
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// Apply godoc
+// @Summary Apply category template
+// @Description Creates template categories missing in the family. Existing categories (same name and type) are kept, so applying a template again has no effect.
+// @Tags categories
+// @Produce json
+// @Security BearerAuth
+// @Param key path string true "Template key"
+// @Success 200 {object} dto.SuccessResponse{data=dto.ApplyCategoryTemplateResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/category-templates/{key}/apply [post]
+func (h *CategoryTemplateHandler) Apply(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	template, ok := categorytemplates.Get(chi.URLParam(r, "key"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Category template not found")
+		return
+	}
+
+	result, err := h.categoryRepo.ApplyTemplate(r.Context(), repository.ApplyTemplateInput{
+		FamilyID:  familyID,
+		Template:  template,
+		AppliedBy: userID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to apply category template")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, dto.ApplyCategoryTemplateResponse{
+		Template:   template.Key,
+		Created:    len(result.Created),
+		Skipped:    result.Skipped,
+		Categories: mapCategories(result.Created),
+	})
+}
+
+// --- Helper functions ---
+
+func mapCategoryTemplate(t categorytemplates.Template) dto.CategoryTemplateResponse {
+	return dto.CategoryTemplateResponse{
+		Key:           t.Key,
+		Name:          t.Name,
+		Description:   t.Description,
+		Language:      t.Language,
+		IsDefault:     t.Key == categorytemplates.Default,
+		CategoryCount: t.Count(),
+		Categories:    mapCategoryTemplateItems(t.Categories),
+	}
+}
+
+func mapCategoryTemplateItems(categories []categorytemplates.Category) []dto.CategoryTemplateItem {
+	if len(categories) == 0 {
+		return nil
+	}
+	result := make([]dto.CategoryTemplateItem, len(categories))
+	for i, c := range categories {
+		result[i] = dto.CategoryTemplateItem{
+			Name:        c.Name,
+			Type:        c.Type,
+			Description: c.Description,
+			Children:    mapCategoryTemplateItems(c.Children),
+		}
+	}
+	return result
+}
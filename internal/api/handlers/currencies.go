@@ -1,28 +1,76 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/shopspring/decimal"
 
+	"github.com/DigitLock/expense-tracker/internal/api/middleware"
 	"github.com/DigitLock/expense-tracker/internal/dto"
 	"github.com/DigitLock/expense-tracker/internal/repository"
 )
 
 type CurrencyHandler struct {
 	exchangeRateRepo *repository.ExchangeRateRepository
+	familyRepo       *repository.FamilyRepository
+	currencyRepo     *repository.CurrencyRepository
 }
 
-func NewCurrencyHandler(exchangeRateRepo *repository.ExchangeRateRepository) *CurrencyHandler {
+func NewCurrencyHandler(
+	exchangeRateRepo *repository.ExchangeRateRepository,
+	familyRepo *repository.FamilyRepository,
+	currencyRepo *repository.CurrencyRepository,
+) *CurrencyHandler {
 	return &CurrencyHandler{
 		exchangeRateRepo: exchangeRateRepo,
+		familyRepo:       familyRepo,
+		currencyRepo:     currencyRepo,
 	}
 }
 
+// List godoc
+// @Summary List currencies
+// @Description Returns enabled ISO 4217 currencies with their minor units (decimal places) and symbols
+// @Tags currencies
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.CurrencyListResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/currencies [get]
+func (h *CurrencyHandler) List(w http.ResponseWriter, r *http.Request) {
+	if _, ok := middleware.GetFamilyID(r.Context()); !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	currencies, err := h.currencyRepo.ListEnabled(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch currencies")
+		return
+	}
+
+	response := dto.CurrencyListResponse{
+		Currencies: make([]dto.CurrencyResponse, len(currencies)),
+	}
+	for i, c := range currencies {
+		response.Currencies[i] = dto.CurrencyResponse{
+			Code:       c.Code,
+			Name:       c.Name,
+			MinorUnits: int(c.MinorUnits),
+			Symbol:     c.Symbol,
+		}
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
 // GetRates godoc
 // @Summary Get exchange rates
-// @Description Returns current exchange rates
+// @Description Returns current exchange rates of enabled currencies relative to the family base currency (1 base = rate units). Currencies without a stored rate are left out.
 // @Tags currencies
 // @Produce json
 // @Security BearerAuth
@@ -30,36 +78,53 @@ func NewCurrencyHandler(exchangeRateRepo *repository.ExchangeRateRepository) *Cu
 // @Failure 401 {object} dto.ErrorResponse
 // @Router /api/v1/currencies/rates [get]
 func (h *CurrencyHandler) GetRates(w http.ResponseWriter, r *http.Request) {
-	now := time.Now()
-
-	// Get latest EUR to RSD rate
-	eurToRsdRate, err := h.exchangeRateRepo.GetLatestRate(r.Context(), "EUR", "RSD", now)
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
 
-	var eurToRsd decimal.Decimal
-	var lastUpdated time.Time
-	var source string
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
 
+	currencies, err := h.currencyRepo.ListEnabled(r.Context())
 	if err != nil {
-		// If no rate found, use a default
-		eurToRsd = decimal.NewFromFloat(117.5)
-		lastUpdated = now
-		source = "fallback"
-	} else {
-		eurToRsd = eurToRsdRate.Rate
-		lastUpdated = eurToRsdRate.CreatedAt
-		source = eurToRsdRate.Source
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch currencies")
+		return
 	}
 
-	// Calculate RSD to EUR (inverse)
-	rsdToEur := decimal.NewFromInt(1).Div(eurToRsd).Round(6)
-
+	now := time.Now()
 	rates := map[string]decimal.Decimal{
-		"RSD": decimal.NewFromInt(1),
-		"EUR": rsdToEur,
+		baseCurrency: decimal.NewFromInt(1),
+	}
+	var lastUpdated time.Time
+	source := "none"
+
+	for _, c := range currencies {
+		if c.Code == baseCurrency {
+			continue
+		}
+
+		rate, stored, err := h.exchangeRateRepo.ResolveRate(r.Context(), baseCurrency, c.Code, now)
+		if err != nil {
+			continue
+		}
+		rates[c.Code] = rate.Round(6)
+
+		// Report the most recently updated rate
+		if stored.CreatedAt.After(lastUpdated) {
+			lastUpdated = stored.CreatedAt
+			source = stored.Source
+		}
+	}
+	if lastUpdated.IsZero() {
+		lastUpdated = now
 	}
 
 	response := dto.ExchangeRatesResponse{
-		BaseCurrency: "RSD",
+		BaseCurrency: baseCurrency,
 		Rates:        rates,
 		LastUpdated:  lastUpdated,
 		Source:       source,
@@ -70,16 +135,17 @@ func (h *CurrencyHandler) GetRates(w http.ResponseWriter, r *http.Request) {
 
 // Convert godoc
 // @Summary Convert currency
-// @Description Converts an amount from one currency to another
+// @Description Converts an amount from one currency to another with the latest exchange rate. The result is rounded to the minor units of the target currency.
 // @Tags currencies
 // @Produce json
 // @Security BearerAuth
 // @Param amount query number true "Amount to convert"
-// @Param from query string true "Source currency (RSD or EUR)"
-// @Param to query string true "Target currency (RSD or EUR)"
+// @Param from query string true "Source currency (ISO 4217 code of an enabled currency)"
+// @Param to query string true "Target currency (ISO 4217 code of an enabled currency)"
 // @Success 200 {object} dto.SuccessResponse{data=dto.ConvertCurrencyResponse}
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
 // @Router /api/v1/currencies/convert [get]
 func (h *CurrencyHandler) Convert(w http.ResponseWriter, r *http.Request) {
 	// Parse parameters
@@ -104,16 +170,16 @@ func (h *CurrencyHandler) Convert(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate currencies
-	validCurrencies := map[string]bool{"RSD": true, "EUR": true}
-	if !validCurrencies[fromCurrency] {
+	if enabled, err := h.currencyRepo.IsEnabled(r.Context(), fromCurrency); err != nil || !enabled {
 		writeValidationError(w, []dto.ValidationError{
-			{Field: "from", Message: "Currency must be RSD or EUR"},
+			{Field: "from", Message: "Unsupported currency"},
 		})
 		return
 	}
-	if !validCurrencies[toCurrency] {
+	target, err := h.currencyRepo.Get(r.Context(), toCurrency)
+	if err != nil || !target.IsEnabled {
 		writeValidationError(w, []dto.ValidationError{
-			{Field: "to", Message: "Currency must be RSD or EUR"},
+			{Field: "to", Message: "Unsupported currency"},
 		})
 		return
 	}
@@ -132,40 +198,50 @@ func (h *CurrencyHandler) Convert(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := time.Now()
-	var rate decimal.Decimal
-	var convertedAmount decimal.Decimal
-
-	if fromCurrency == "EUR" && toCurrency == "RSD" {
-		// EUR to RSD
-		eurToRsdRate, err := h.exchangeRateRepo.GetLatestRate(r.Context(), "EUR", "RSD", now)
-		if err != nil {
-			rate = decimal.NewFromFloat(117.5) // fallback
-		} else {
-			rate = eurToRsdRate.Rate
-		}
-		convertedAmount = amount.Mul(rate).Round(2)
-	} else {
-		// RSD to EUR
-		eurToRsdRate, err := h.exchangeRateRepo.GetLatestRate(r.Context(), "EUR", "RSD", now)
-		var eurToRsd decimal.Decimal
-		if err != nil {
-			eurToRsd = decimal.NewFromFloat(117.5) // fallback
-		} else {
-			eurToRsd = eurToRsdRate.Rate
-		}
-		rate = decimal.NewFromInt(1).Div(eurToRsd).Round(6)
-		convertedAmount = amount.Mul(rate).Round(2)
+	rate, _, err := h.exchangeRateRepo.ResolveRate(r.Context(), fromCurrency, toCurrency, time.Now())
+	if err != nil {
+		writeError(w, http.StatusNotFound, "RATE_NOT_FOUND",
+			fmt.Sprintf("No exchange rate for %s/%s", fromCurrency, toCurrency))
+		return
 	}
 
 	response := dto.ConvertCurrencyResponse{
 		OriginalAmount:   amount,
 		OriginalCurrency: fromCurrency,
-		ConvertedAmount:  convertedAmount,
+		ConvertedAmount:  repository.RoundToMinorUnits(amount.Mul(rate), target),
 		TargetCurrency:   toCurrency,
-		ExchangeRate:     rate,
+		ExchangeRate:     rate.Round(6),
 		ConversionDate:   time.Now().UTC(),
 	}
 
 	writeSuccess(w, http.StatusOK, response)
 }
+
+// --- Helper functions ---
+
+// newCurrencyValidator returns a validator supporting the "currency" tag, which accepts
+// codes of enabled currencies. Use StructCtx so the lookup runs with the request context.
+func newCurrencyValidator(currencyRepo *repository.CurrencyRepository) *validator.Validate {
+	validate := validator.New()
+	err := validate.RegisterValidationCtx("currency", func(ctx context.Context, fl validator.FieldLevel) bool {
+		enabled, err := currencyRepo.IsEnabled(ctx, fl.Field().String())
+		return err == nil && enabled
+	})
+	if err != nil {
+		panic(err)
+	}
+	return validate
+}
+
+// validateMinorUnits checks that amount has no more decimal places than the currency allows.
+// Unknown currencies are reported by the "currency" tag and are not checked here.
+func validateMinorUnits(ctx context.Context, currencyRepo *repository.CurrencyRepository, field string, amount decimal.Decimal, code string) []dto.ValidationError {
+	currency, err := currencyRepo.Get(ctx, code)
+	if err != nil || repository.HasMinorUnits(amount, currency) {
+		return nil
+	}
+	return []dto.ValidationError{{
+		Field:   field,
+		Message: fmt.Sprintf("%s amounts can have at most %d decimal places", currency.Code, currency.MinorUnits),
+	}}
+}
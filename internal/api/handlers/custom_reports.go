@@ -0,0 +1,609 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/api/middleware"
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+	"github.com/DigitLock/expense-tracker/internal/dto"
+	"github.com/DigitLock/expense-tracker/internal/export"
+	"github.com/DigitLock/expense-tracker/internal/reportbuilder"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+type CustomReportHandler struct {
+	customReportRepo *repository.CustomReportRepository
+	familyRepo       *repository.FamilyRepository
+	validate         *validator.Validate
+}
+
+func NewCustomReportHandler(
+	customReportRepo *repository.CustomReportRepository,
+	familyRepo *repository.FamilyRepository,
+) *CustomReportHandler {
+	return &CustomReportHandler{
+		customReportRepo: customReportRepo,
+		familyRepo:       familyRepo,
+		validate:         validator.New(),
+	}
+}
+
+// Options godoc
+// @Summary Custom report options
+// @Description Returns measures, dimensions and relative periods supported by custom reports
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.CustomReportOptionsResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/reports/custom/options [get]
+func (h *CustomReportHandler) Options(w http.ResponseWriter, r *http.Request) {
+	if _, ok := middleware.GetFamilyID(r.Context()); !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, dto.CustomReportOptionsResponse{
+		Measures:   reportbuilder.Measures(),
+		Dimensions: reportbuilder.Dimensions(),
+		Periods:    reportbuilder.Periods(),
+		MaxLimit:   reportbuilder.MaxLimit,
+	})
+}
+
+// Run godoc
+// @Summary Run custom report
+// @Description Aggregates transactions by the given definition: measures (sum, count, avg of amount in base currency), dimensions (category, root_category, account, type, member, currency, day, week, month, quarter, year), filters, sort and limit. System categories are excluded.
+// @Tags reports
+// @Accept json
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/pdf
+// @Security BearerAuth
+// @Param request body dto.CustomReportRequest true "Report definition"
+// @Param format query string false "Response format: json (default), csv, xlsx or pdf"
+// @Param locale query string false "Number format of exported files (e.g. en, sr, ru), default: Accept-Language"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CustomReportResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/reports/custom [post]
+func (h *CustomReportHandler) Run(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	format, exporting, err := exportFormat(r)
+	if err != nil {
+		writeValidationError(w, []dto.ValidationError{{Field: "format", Message: err.Error()}})
+		return
+	}
+
+	var req dto.CustomReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	report, err := h.run(r, familyID, req.Definition)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	if exporting {
+		exportCustomReport(w, r, format, report)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, report)
+}
+
+// ListSaved godoc
+// @Summary List saved reports
+// @Description Returns saved custom report definitions of the family ordered by name
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.SavedReportListResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/reports/saved [get]
+func (h *CustomReportHandler) ListSaved(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	reports, err := h.customReportRepo.ListSaved(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch saved reports")
+		return
+	}
+
+	response := dto.SavedReportListResponse{
+		Reports: make([]dto.SavedReportResponse, 0, len(reports)),
+	}
+	for _, report := range reports {
+		response.Reports = append(response.Reports, mapSavedReport(report))
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// GetSaved godoc
+// @Summary Get saved report
+// @Description Returns a saved custom report definition
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved report ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SavedReportResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/reports/saved/{id} [get]
+func (h *CustomReportHandler) GetSaved(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	reportID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid report ID format")
+		return
+	}
+
+	report, err := h.customReportRepo.GetSaved(r.Context(), reportID)
+	if err != nil || report.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Saved report not found")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, mapSavedReport(report))
+}
+
+// CreateSaved godoc
+// @Summary Save report
+// @Description Saves a custom report definition for the family. Names are unique per family (case-insensitive).
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateSavedReportRequest true "Saved report data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.SavedReportResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/reports/saved [post]
+func (h *CustomReportHandler) CreateSaved(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	var req dto.CreateSavedReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+
+	if err := h.validate.Struct(req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	exists, err := h.nameExists(r, familyID, req.Name, uuid.Nil)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch saved reports")
+		return
+	}
+	if exists {
+		writeError(w, http.StatusConflict, "REPORT_EXISTS", "Saved report with this name already exists")
+		return
+	}
+
+	report, err := h.customReportRepo.CreateSaved(r.Context(), repository.CreateSavedReportInput{
+		FamilyID:    familyID,
+		Name:        req.Name,
+		Description: req.Description,
+		Definition:  req.Definition,
+		CreatedBy:   userID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to save report")
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, mapSavedReport(report))
+}
+
+// UpdateSaved godoc
+// @Summary Update saved report
+// @Description Updates name, description or definition of a saved report (partial update). An empty description removes it.
+// @Tags reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved report ID"
+// @Param request body dto.UpdateSavedReportRequest true "Saved report data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.SavedReportResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Router /api/v1/reports/saved/{id} [patch]
+func (h *CustomReportHandler) UpdateSaved(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	reportID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid report ID format")
+		return
+	}
+
+	// Check report exists and belongs to family
+	existing, err := h.customReportRepo.GetSaved(r.Context(), reportID)
+	if err != nil || existing.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Saved report not found")
+		return
+	}
+
+	var req dto.UpdateSavedReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		req.Name = &name
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	if req.Name != nil {
+		exists, err := h.nameExists(r, familyID, *req.Name, reportID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch saved reports")
+			return
+		}
+		if exists {
+			writeError(w, http.StatusConflict, "REPORT_EXISTS", "Saved report with this name already exists")
+			return
+		}
+	}
+
+	report, err := h.customReportRepo.UpdateSaved(r.Context(), repository.UpdateSavedReportInput{
+		ID:          reportID,
+		Name:        req.Name,
+		Description: req.Description,
+		Definition:  req.Definition,
+		UpdatedBy:   userID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update saved report")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, mapSavedReport(report))
+}
+
+// DeleteSaved godoc
+// @Summary Delete saved report
+// @Description Soft deletes a saved report definition
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Saved report ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/reports/saved/{id} [delete]
+func (h *CustomReportHandler) DeleteSaved(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	reportID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid report ID format")
+		return
+	}
+
+	// Check report exists and belongs to family
+	existing, err := h.customReportRepo.GetSaved(r.Context(), reportID)
+	if err != nil || existing.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Saved report not found")
+		return
+	}
+
+	if err := h.customReportRepo.DeleteSaved(r.Context(), reportID, userID); err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete saved report")
+		return
+	}
+
+	writeMessage(w, http.StatusOK, "Saved report deleted successfully")
+}
+
+// RunSaved godoc
+// @Summary Run saved report
+// @Description Runs a saved report definition. Relative periods are resolved against today; period or dates in the query replace the saved date filter.
+// @Tags reports
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/pdf
+// @Security BearerAuth
+// @Param id path string true "Saved report ID"
+// @Param period query string false "Relative period, e.g. this_month, last_quarter, last_12_months"
+// @Param start_date query string false "Start date (YYYY-MM-DD)"
+// @Param end_date query string false "End date (YYYY-MM-DD)"
+// @Param format query string false "Response format: json (default), csv, xlsx or pdf"
+// @Param locale query string false "Number format of exported files (e.g. en, sr, ru), default: Accept-Language"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CustomReportResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/reports/saved/{id}/run [get]
+func (h *CustomReportHandler) RunSaved(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	reportID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid report ID format")
+		return
+	}
+
+	format, exporting, err := exportFormat(r)
+	if err != nil {
+		writeValidationError(w, []dto.ValidationError{{Field: "format", Message: err.Error()}})
+		return
+	}
+
+	saved, err := h.customReportRepo.GetSaved(r.Context(), reportID)
+	if err != nil || saved.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Saved report not found")
+		return
+	}
+
+	var definition reportbuilder.Definition
+	if err := json.Unmarshal(saved.Definition, &definition); err != nil {
+		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "Saved report definition is corrupted")
+		return
+	}
+
+	// Date overrides
+	query := r.URL.Query()
+	if query.Get("period") != "" || query.Get("start_date") != "" || query.Get("end_date") != "" {
+		definition.Filters.Period = query.Get("period")
+		definition.Filters.StartDate = query.Get("start_date")
+		definition.Filters.EndDate = query.Get("end_date")
+	}
+
+	req := dto.CustomReportRequest{Definition: definition}
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	report, err := h.run(r, familyID, definition)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+	report.SavedReportID = &saved.ID
+	report.Name = &saved.Name
+
+	if exporting {
+		exportCustomReport(w, r, format, report)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, report)
+}
+
+// --- Helper functions ---
+
+// run builds and executes a validated definition
+func (h *CustomReportHandler) run(r *http.Request, familyID uuid.UUID, definition reportbuilder.Definition) (dto.CustomReportResponse, error) {
+	query, err := reportbuilder.Build(definition, familyID, time.Now())
+	if err != nil {
+		return dto.CustomReportResponse{}, err
+	}
+
+	rows, err := h.customReportRepo.Run(r.Context(), query)
+	if err != nil {
+		return dto.CustomReportResponse{}, err
+	}
+
+	baseCurrency, err := h.familyRepo.GetBaseCurrency(r.Context(), familyID)
+	if err != nil {
+		return dto.CustomReportResponse{}, err
+	}
+
+	limit := definition.Limit
+	if limit == 0 {
+		limit = reportbuilder.DefaultLimit
+	}
+
+	response := dto.CustomReportResponse{
+		ReportType:  "custom",
+		Currency:    baseCurrency,
+		Definition:  definition,
+		Columns:     query.Columns,
+		Rows:        make([]map[string]any, len(rows)),
+		RowCount:    len(rows),
+		Truncated:   len(rows) == limit,
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	if query.Start != nil || query.End != nil {
+		response.Period = &dto.ReportPeriod{}
+		if query.Start != nil {
+			response.Period.StartDate = query.Start.Format("2006-01-02")
+		}
+		if query.End != nil {
+			response.Period.EndDate = query.End.Format("2006-01-02")
+		}
+	}
+
+	for i, values := range rows {
+		row := make(map[string]any, len(values))
+		for j, v := range values {
+			if date, ok := v.(time.Time); ok {
+				v = date.Format("2006-01-02")
+			}
+			row[query.Columns[j].Name] = v
+		}
+		response.Rows[i] = row
+	}
+
+	return response, nil
+}
+
+// nameExists reports whether another saved report of the family has the name
+func (h *CustomReportHandler) nameExists(r *http.Request, familyID uuid.UUID, name string, exceptID uuid.UUID) (bool, error) {
+	reports, err := h.customReportRepo.ListSaved(r.Context(), familyID)
+	if err != nil {
+		return false, err
+	}
+	for _, report := range reports {
+		if report.ID != exceptID && strings.EqualFold(report.Name, name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func mapSavedReport(report sqlc.SavedReport) dto.SavedReportResponse {
+	response := dto.SavedReportResponse{
+		ID:        report.ID,
+		Name:      report.Name,
+		CreatedAt: report.CreatedAt,
+		UpdatedAt: report.UpdatedAt,
+	}
+
+	if report.Description.Valid {
+		response.Description = &report.Description.String
+	}
+	if report.CreatedBy.Valid {
+		createdBy := uuid.UUID(report.CreatedBy.Bytes)
+		response.CreatedBy = &createdBy
+	}
+
+	// Definitions are validated before saving
+	_ = json.Unmarshal(report.Definition, &response.Definition)
+
+	return response
+}
+
+// exportCustomReport writes a custom report as a file with one row per result row
+func exportCustomReport(w http.ResponseWriter, r *http.Request, format export.Format, report dto.CustomReportResponse) {
+	filename := "custom-report"
+	title := "Custom report"
+	if report.Name != nil {
+		title = *report.Name
+	}
+
+	ew, err := startExport(w, r, format, filename, export.Document{
+		Title:    title,
+		Period:   report.Period,
+		Currency: report.Currency,
+	})
+
+	columns := make([]export.Column, len(report.Columns))
+	for i, c := range report.Columns {
+		columns[i] = export.Column{Title: c.Name}
+		switch c.Type {
+		case reportbuilder.TypeDecimal:
+			columns[i].Kind = export.KindMoney
+		case reportbuilder.TypeInteger:
+			columns[i].Kind = export.KindInteger
+		}
+	}
+	if err == nil {
+		err = ew.Section("", columns)
+	}
+
+	for _, row := range report.Rows {
+		if err != nil {
+			break
+		}
+		values := make([]any, len(report.Columns))
+		for i, c := range report.Columns {
+			switch v := row[c.Name].(type) {
+			case uuid.UUID:
+				values[i] = v.String()
+			case decimal.Decimal, int64, string:
+				values[i] = v
+			default:
+				values[i] = fmt.Sprint(v)
+			}
+		}
+		err = ew.Row(values...)
+	}
+	if err == nil {
+		err = ew.Close()
+	}
+	if err != nil {
+		logExportError(filename, err)
+	}
+}
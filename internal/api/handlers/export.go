@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/DigitLock/expense-tracker/internal/export"
+)
+
+// exportFormat returns the file format requested by the format query parameter
+// or the Accept header. ok = false means a regular JSON response.
+func exportFormat(r *http.Request) (format export.Format, ok bool, err error) {
+	if name := r.URL.Query().Get("format"); name != "" {
+		if name == "json" {
+			return "", false, nil
+		}
+		format, ok := export.ParseFormat(name)
+		if !ok {
+			return "", false, fmt.Errorf("unsupported format %q, expected csv, xlsx or pdf", name)
+		}
+		return format, true, nil
+	}
+
+	format, ok = export.FormatFromAccept(r.Header.Get("Accept"))
+	return format, ok, nil
+}
+
+// exportLocale returns number formatting requested by the locale query parameter
+// or the Accept-Language header
+func exportLocale(r *http.Request) export.Locale {
+	if tag := r.URL.Query().Get("locale"); tag != "" {
+		return export.LocaleFor(tag)
+	}
+	return export.LocaleFromAcceptLanguage(r.Header.Get("Accept-Language"))
+}
+
+// startExport sends download headers and creates a writer streaming into the response
+func startExport(w http.ResponseWriter, r *http.Request, format export.Format, filename string, doc export.Document) (export.Writer, error) {
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, filename, format))
+	w.WriteHeader(http.StatusOK)
+
+	return export.NewWriter(w, format, doc, exportLocale(r))
+}
+
+// logExportError logs a failed export. Headers are already sent, so the client
+// only sees a truncated file.
+func logExportError(filename string, err error) {
+	log.Printf("❌ Failed to export %s: %v", filename, err)
+}
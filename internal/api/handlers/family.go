@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+
+	"github.com/DigitLock/expense-tracker/internal/api/middleware"
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+	"github.com/DigitLock/expense-tracker/internal/dto"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+type FamilyHandler struct {
+	familyRepo *repository.FamilyRepository
+	validate   *validator.Validate
+}
+
+func NewFamilyHandler(
+	familyRepo *repository.FamilyRepository,
+	currencyRepo *repository.CurrencyRepository,
+) *FamilyHandler {
+	return &FamilyHandler{
+		familyRepo: familyRepo,
+		validate:   newCurrencyValidator(currencyRepo),
+	}
+}
+
+// Get godoc
+// @Summary Get family
+// @Description Returns the family of the current user with its base currency
+// @Tags family
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.FamilyResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/family [get]
+func (h *FamilyHandler) Get(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	family, err := h.familyRepo.GetByID(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Family not found")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, mapFamily(family))
+}
+
+// Update godoc
+// @Summary Update family
+// @Description Updates family name or base currency (partial update). Changing the base currency recomputes the base amount of every family transaction with the exchange rate of its date and converts budgets and alert limits with the current rate; fails if a rate is missing.
+// @Tags family
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.UpdateFamilyRequest true "Family data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.UpdateFamilyResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/family [patch]
+func (h *FamilyHandler) Update(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	var req dto.UpdateFamilyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		req.Name = &name
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if _, err := h.familyRepo.GetByID(r.Context(), familyID); err != nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Family not found")
+		return
+	}
+
+	result, err := h.familyRepo.Update(r.Context(), repository.UpdateFamilyInput{
+		ID:           familyID,
+		Name:         req.Name,
+		BaseCurrency: req.BaseCurrency,
+		UpdatedBy:    userID,
+	})
+	if errors.Is(err, repository.ErrMissingExchangeRates) {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "base_currency", Message: fmt.Sprintf("Some transactions or budgets have no exchange rate to %s, add the missing rates first", *req.BaseCurrency)},
+		})
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update family")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, dto.UpdateFamilyResponse{
+		FamilyResponse:         mapFamily(result.Family),
+		RecomputedTransactions: result.RecomputedTransactions,
+		ConvertedAmounts:       result.ConvertedAmounts,
+	})
+}
+
+// --- Helper functions ---
+
+func mapFamily(f sqlc.Family) dto.FamilyResponse {
+	return dto.FamilyResponse{
+		ID:           f.ID,
+		Name:         f.Name,
+		BaseCurrency: f.BaseCurrency,
+		CreatedAt:    f.CreatedAt,
+		UpdatedAt:    f.UpdatedAt,
+	}
+}
+
+// familyBaseCurrency fetches the base currency of the family, writing an
+// error response on failure
+func familyBaseCurrency(w http.ResponseWriter, r *http.Request, familyRepo *repository.FamilyRepository, familyID uuid.UUID) (string, bool) {
+	baseCurrency, err := familyRepo.GetBaseCurrency(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch family")
+		return "", false
+	}
+	return baseCurrency, true
+}
@@ -0,0 +1,659 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/api/middleware"
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+	"github.com/DigitLock/expense-tracker/internal/dto"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+// goalHistoryMonths is the contribution history window used for projections
+const goalHistoryMonths = 6
+
+type GoalHandler struct {
+	goalRepo         *repository.SavingsGoalRepository
+	accountRepo      *repository.AccountRepository
+	exchangeRateRepo *repository.ExchangeRateRepository
+	familyRepo       *repository.FamilyRepository
+	currencyRepo     *repository.CurrencyRepository
+	validate         *validator.Validate
+}
+
+func NewGoalHandler(
+	goalRepo *repository.SavingsGoalRepository,
+	accountRepo *repository.AccountRepository,
+	exchangeRateRepo *repository.ExchangeRateRepository,
+	familyRepo *repository.FamilyRepository,
+	currencyRepo *repository.CurrencyRepository,
+) *GoalHandler {
+	return &GoalHandler{
+		goalRepo:         goalRepo,
+		accountRepo:      accountRepo,
+		exchangeRateRepo: exchangeRateRepo,
+		familyRepo:       familyRepo,
+		currencyRepo:     currencyRepo,
+		validate:         newCurrencyValidator(currencyRepo),
+	}
+}
+
+// List godoc
+// @Summary List savings goals
+// @Description Returns all savings goals with progress for the authenticated user's family
+// @Tags goals
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.SuccessResponse{data=dto.GoalListResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/goals [get]
+func (h *GoalHandler) List(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	goals, err := h.goalRepo.ListByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch goals")
+		return
+	}
+
+	links, err := h.goalRepo.ListAccountsByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch goal accounts")
+		return
+	}
+
+	linksByGoal := make(map[uuid.UUID][]sqlc.SavingsGoalAccount)
+	for _, l := range links {
+		linksByGoal[l.GoalID] = append(linksByGoal[l.GoalID], l)
+	}
+
+	progressData, err := h.loadGoalProgressData(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch goal accounts")
+		return
+	}
+
+	response := make([]dto.GoalResponse, len(goals))
+	for i, g := range goals {
+		response[i], err = h.buildGoalResponse(r.Context(), g, linksByGoal[g.ID], progressData)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to calculate goal progress")
+			return
+		}
+	}
+
+	writeSuccess(w, http.StatusOK, dto.GoalListResponse{Goals: response})
+}
+
+// Create godoc
+// @Summary Create savings goal
+// @Description Creates a new savings goal linked to one or more accounts
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateGoalRequest true "Goal data"
+// @Success 201 {object} dto.SuccessResponse{data=dto.GoalResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/goals [post]
+func (h *GoalHandler) Create(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	var req dto.CreateGoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+	if errors := validateMinorUnits(r.Context(), h.currencyRepo, "target_amount", req.TargetAmount, req.Currency); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	if errors := h.validateAccounts(r.Context(), familyID, req.Accounts); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	input := repository.CreateSavingsGoalInput{
+		FamilyID:     familyID,
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		Currency:     req.Currency,
+		Description:  &req.Description,
+		Accounts:     mapGoalAccountInputs(req.Accounts),
+		CreatedBy:    userID,
+	}
+	if req.Deadline != "" {
+		deadline, _ := time.Parse("2006-01-02", req.Deadline)
+		input.Deadline = &deadline
+	}
+
+	goal, err := h.goalRepo.Create(r.Context(), input)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to create goal")
+		return
+	}
+
+	h.writeGoal(r.Context(), w, http.StatusCreated, goal)
+}
+
+// Get godoc
+// @Summary Get savings goal
+// @Description Returns a savings goal with progress, required monthly contribution and projected completion date
+// @Tags goals
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Goal ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GoalResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/goals/{id} [get]
+func (h *GoalHandler) Get(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid goal ID format")
+		return
+	}
+
+	goal, err := h.goalRepo.GetByID(r.Context(), goalID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Goal not found")
+		return
+	}
+
+	// Check goal belongs to user's family
+	if goal.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Goal not found")
+		return
+	}
+
+	h.writeGoal(r.Context(), w, http.StatusOK, goal)
+}
+
+// Update godoc
+// @Summary Update savings goal
+// @Description Updates an existing savings goal (partial update). Linked accounts are replaced when provided.
+// @Tags goals
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Goal ID"
+// @Param request body dto.UpdateGoalRequest true "Goal data"
+// @Success 200 {object} dto.SuccessResponse{data=dto.GoalResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/goals/{id} [patch]
+func (h *GoalHandler) Update(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid goal ID format")
+		return
+	}
+
+	// Check goal exists and belongs to family
+	existing, err := h.goalRepo.GetByID(r.Context(), goalID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Goal not found")
+		return
+	}
+	if existing.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Goal not found")
+		return
+	}
+
+	var req dto.UpdateGoalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	targetAmount := existing.TargetAmount
+	if req.TargetAmount != nil {
+		targetAmount = *req.TargetAmount
+	}
+	currency := existing.Currency
+	if req.Currency != nil {
+		currency = *req.Currency
+	}
+	if errors := validateMinorUnits(r.Context(), h.currencyRepo, "target_amount", targetAmount, currency); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	// Build update input
+	input := repository.UpdateSavingsGoalInput{
+		ID:           goalID,
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		Currency:     req.Currency,
+		Description:  req.Description,
+		UpdatedBy:    userID,
+	}
+	if req.Deadline != nil {
+		if *req.Deadline == "" {
+			input.ClearDeadline = true
+		} else {
+			deadline, _ := time.Parse("2006-01-02", *req.Deadline)
+			input.Deadline = &deadline
+		}
+	}
+	if req.Accounts != nil {
+		if errors := h.validateAccounts(r.Context(), familyID, *req.Accounts); len(errors) > 0 {
+			writeValidationError(w, errors)
+			return
+		}
+		accounts := mapGoalAccountInputs(*req.Accounts)
+		input.Accounts = &accounts
+	}
+
+	goal, err := h.goalRepo.Update(r.Context(), input)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to update goal")
+		return
+	}
+
+	h.writeGoal(r.Context(), w, http.StatusOK, goal)
+}
+
+// Delete godoc
+// @Summary Delete savings goal
+// @Description Soft deletes a savings goal. Linked accounts are not affected.
+// @Tags goals
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Goal ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/goals/{id} [delete]
+func (h *GoalHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	goalID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid goal ID format")
+		return
+	}
+
+	// Check goal exists and belongs to family
+	existing, err := h.goalRepo.GetByID(r.Context(), goalID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Goal not found")
+		return
+	}
+	if existing.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Goal not found")
+		return
+	}
+
+	if err := h.goalRepo.Delete(r.Context(), goalID); err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete goal")
+		return
+	}
+
+	writeMessage(w, http.StatusOK, "Goal deleted successfully")
+}
+
+// --- Helper functions ---
+
+// validateAccounts checks that all linked accounts exist and belong to the family
+func (h *GoalHandler) validateAccounts(ctx context.Context, familyID uuid.UUID, accounts []dto.GoalAccountRequest) []dto.ValidationError {
+	var errors []dto.ValidationError
+
+	for _, a := range accounts {
+		account, err := h.accountRepo.GetByID(ctx, a.AccountID)
+		if err != nil || account.FamilyID != familyID {
+			errors = append(errors, dto.ValidationError{
+				Field:   "account_id",
+				Message: "Account not found: " + a.AccountID.String(),
+			})
+		}
+	}
+
+	return errors
+}
+
+// writeGoal loads linked accounts and writes goal with calculated progress
+func (h *GoalHandler) writeGoal(ctx context.Context, w http.ResponseWriter, status int, goal sqlc.SavingsGoal) {
+	links, err := h.goalRepo.ListAccounts(ctx, goal.ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch goal accounts")
+		return
+	}
+
+	progressData, err := h.loadGoalProgressData(ctx, goal.FamilyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch goal accounts")
+		return
+	}
+
+	response, err := h.buildGoalResponse(ctx, goal, links, progressData)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to calculate goal progress")
+		return
+	}
+
+	writeSuccess(w, status, response)
+}
+
+// goalProgressData holds the family data shared by all goals of a request
+type goalProgressData struct {
+	accounts     map[uuid.UUID]sqlc.Account
+	baseCurrency string
+	rates        *goalRateCache
+}
+
+// loadGoalProgressData loads all family accounts (including closed ones) at once
+func (h *GoalHandler) loadGoalProgressData(ctx context.Context, familyID uuid.UUID) (*goalProgressData, error) {
+	accounts, err := h.accountRepo.ListAllByFamily(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseCurrency, err := h.familyRepo.GetBaseCurrency(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &goalProgressData{
+		accounts:     make(map[uuid.UUID]sqlc.Account, len(accounts)),
+		baseCurrency: baseCurrency,
+		rates: &goalRateCache{
+			exchangeRateRepo: h.exchangeRateRepo,
+			currencyRepo:     h.currencyRepo,
+			date:             time.Now(),
+			rates:            make(map[[2]string]*decimal.Decimal),
+			currencies:       make(map[string]sqlc.Currency),
+		},
+	}
+	for _, a := range accounts {
+		data.accounts[a.ID] = a
+	}
+	return data, nil
+}
+
+// goalRateCache converts amounts into goal currencies, resolving each currency pair once per request
+type goalRateCache struct {
+	exchangeRateRepo *repository.ExchangeRateRepository
+	currencyRepo     *repository.CurrencyRepository
+	date             time.Time
+	rates            map[[2]string]*decimal.Decimal // nil if no rate is stored
+	currencies       map[string]sqlc.Currency
+}
+
+// convert converts amount rounded to the minor units of toCurrency.
+// Returns false if no exchange rate is stored for the pair.
+func (c *goalRateCache) convert(ctx context.Context, amount decimal.Decimal, fromCurrency, toCurrency string) (decimal.Decimal, bool, error) {
+	if fromCurrency == toCurrency {
+		return amount, true, nil
+	}
+
+	pair := [2]string{fromCurrency, toCurrency}
+	rate, ok := c.rates[pair]
+	if !ok {
+		resolved, _, err := c.exchangeRateRepo.ResolveRate(ctx, fromCurrency, toCurrency, c.date)
+		if err != nil && !errors.Is(err, repository.ErrExchangeRateNotFound) {
+			return decimal.Zero, false, err
+		}
+		if err == nil {
+			rate = &resolved
+		}
+		c.rates[pair] = rate
+	}
+	if rate == nil {
+		return decimal.Zero, false, nil
+	}
+
+	currency, ok := c.currencies[toCurrency]
+	if !ok {
+		var err error
+		currency, err = c.currencyRepo.Get(ctx, toCurrency)
+		if err != nil {
+			return decimal.Zero, false, err
+		}
+		c.currencies[toCurrency] = currency
+	}
+
+	return repository.RoundToMinorUnits(amount.Mul(*rate), currency), true, nil
+}
+
+// buildGoalResponse calculates goal progress from linked account balances.
+// Whole-balance accounts contribute their current balance, earmarked accounts
+// contribute at most the earmarked amount. Projection is based on net flow into
+// whole-balance accounts over the last goalHistoryMonths months. Accounts without
+// an exchange rate to the goal currency are flagged and do not count towards the goal.
+func (h *GoalHandler) buildGoalResponse(ctx context.Context, goal sqlc.SavingsGoal, links []sqlc.SavingsGoalAccount, data *goalProgressData) (dto.GoalResponse, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	accounts := make([]dto.GoalAccountResponse, len(links))
+	var historyAccountIDs []uuid.UUID
+	currentAmount := decimal.Zero
+	hasUnconverted := false
+
+	for i, l := range links {
+		account, ok := data.accounts[l.AccountID]
+		if !ok {
+			return dto.GoalResponse{}, fmt.Errorf("account %s not found", l.AccountID)
+		}
+
+		earmarked := numericToDecimalPtr(l.EarmarkedAmount)
+
+		// Closed accounts and negative balances do not count towards the goal
+		contribution := decimal.Zero
+		if account.IsActive && account.CurrentBalance.IsPositive() {
+			contribution = account.CurrentBalance
+			if earmarked != nil && earmarked.LessThan(contribution) {
+				contribution = *earmarked
+			}
+		}
+
+		converted, isConverted, err := data.rates.convert(ctx, contribution, account.Currency, goal.Currency)
+		if err != nil {
+			return dto.GoalResponse{}, err
+		}
+		if !isConverted {
+			hasUnconverted = true
+		}
+		currentAmount = currentAmount.Add(converted)
+
+		if account.IsActive && earmarked == nil {
+			historyAccountIDs = append(historyAccountIDs, account.ID)
+		}
+
+		accounts[i] = dto.GoalAccountResponse{
+			AccountID:       account.ID,
+			AccountName:     account.Name,
+			AccountCurrency: account.Currency,
+			CurrentBalance:  account.CurrentBalance,
+			EarmarkedAmount: earmarked,
+			Contribution:    converted,
+			IsConverted:     isConverted,
+			IsActive:        account.IsActive,
+		}
+	}
+
+	remaining := goal.TargetAmount.Sub(currentAmount)
+	if remaining.IsNegative() {
+		remaining = decimal.Zero
+	}
+
+	progress := dto.GoalProgress{
+		CurrentAmount:   currentAmount,
+		RemainingAmount: remaining,
+		ProgressPercent: currentAmount.Div(goal.TargetAmount).Mul(decimal.NewFromInt(100)).Round(1),
+		IsCompleted:     remaining.IsZero(),
+		HasUnconverted:  hasUnconverted,
+	}
+
+	// Required monthly contribution to reach the target by deadline
+	if goal.Deadline.Valid && !progress.IsCompleted {
+		months := monthsBetween(today, goal.Deadline.Time)
+		if months < 0 {
+			months = 0
+		}
+		progress.MonthsRemaining = &months
+
+		// Deadline this month or already passed - the rest is needed now
+		divisor := months
+		if divisor < 1 {
+			divisor = 1
+		}
+		required := remaining.Div(decimal.NewFromInt(int64(divisor))).Round(2)
+		progress.RequiredMonthlyContribution = &required
+	}
+
+	// Average monthly contribution over recent history (in family base currency, converted to goal currency)
+	if len(historyAccountIDs) > 0 {
+		startDate := today.AddDate(0, -goalHistoryMonths, 0)
+		netFlow, err := h.accountRepo.GetNetFlow(ctx, historyAccountIDs, startDate, today)
+		if err != nil {
+			return dto.GoalResponse{}, err
+		}
+		netFlow, isConverted, err := data.rates.convert(ctx, netFlow, data.baseCurrency, goal.Currency)
+		if err != nil {
+			return dto.GoalResponse{}, err
+		}
+		if isConverted {
+			progress.AverageMonthlyContribution = netFlow.Div(decimal.NewFromInt(goalHistoryMonths)).Round(2)
+		} else {
+			progress.HasUnconverted = true
+		}
+	}
+
+	// Projected completion date at the current contribution pace
+	if progress.IsCompleted {
+		onTrack := true
+		progress.IsOnTrack = &onTrack
+	} else if progress.AverageMonthlyContribution.IsPositive() {
+		months := remaining.Div(progress.AverageMonthlyContribution).Ceil().IntPart()
+		projected := today.AddDate(0, int(months), 0)
+		projectedStr := projected.Format("2006-01-02")
+		progress.ProjectedCompletionDate = &projectedStr
+
+		if goal.Deadline.Valid {
+			onTrack := !projected.After(goal.Deadline.Time)
+			progress.IsOnTrack = &onTrack
+		}
+	} else if goal.Deadline.Valid {
+		onTrack := false
+		progress.IsOnTrack = &onTrack
+	}
+
+	response := dto.GoalResponse{
+		ID:           goal.ID,
+		Name:         goal.Name,
+		TargetAmount: goal.TargetAmount,
+		Currency:     goal.Currency,
+		Accounts:     accounts,
+		Progress:     progress,
+		CreatedAt:    goal.CreatedAt,
+		UpdatedAt:    goal.UpdatedAt,
+	}
+	if goal.Deadline.Valid {
+		deadline := goal.Deadline.Time.Format("2006-01-02")
+		response.Deadline = &deadline
+	}
+	if goal.Description.Valid {
+		response.Description = &goal.Description.String
+	}
+
+	return response, nil
+}
+
+func mapGoalAccountInputs(accounts []dto.GoalAccountRequest) []repository.SavingsGoalAccountInput {
+	result := make([]repository.SavingsGoalAccountInput, len(accounts))
+	for i, a := range accounts {
+		result[i] = repository.SavingsGoalAccountInput{
+			AccountID:       a.AccountID,
+			EarmarkedAmount: a.EarmarkedAmount,
+		}
+	}
+	return result
+}
+
+func numericToDecimalPtr(n pgtype.Numeric) *decimal.Decimal {
+	if !n.Valid {
+		return nil
+	}
+	d := decimal.NewFromBigInt(n.Int, n.Exp)
+	return &d
+}
+
+// monthsBetween returns number of whole calendar months from start to end
+func monthsBetween(start, end time.Time) int {
+	months := (end.Year()-start.Year())*12 + int(end.Month()) - int(start.Month())
+	if end.Day() < start.Day() {
+		months--
+	}
+	return months
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/DigitLock/expense-tracker/internal/api/middleware"
+	"github.com/DigitLock/expense-tracker/internal/dto"
+	"github.com/DigitLock/expense-tracker/internal/insights"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+const (
+	defaultInsightMonths = 6
+	minInsightMonths     = 3
+	maxInsightMonths     = 24
+)
+
+type InsightHandler struct {
+	transactionRepo *repository.TransactionRepository
+	categoryRepo    *repository.CategoryRepository
+	familyRepo      *repository.FamilyRepository
+}
+
+func NewInsightHandler(
+	transactionRepo *repository.TransactionRepository,
+	categoryRepo *repository.CategoryRepository,
+	familyRepo *repository.FamilyRepository,
+) *InsightHandler {
+	return &InsightHandler{
+		transactionRepo: transactionRepo,
+		categoryRepo:    categoryRepo,
+		familyRepo:      familyRepo,
+	}
+}
+
+// List godoc
+// @Summary Spending insights
+// @Description Finds unusual expenses of a month compared with the trailing months: categories spiking above their usual monthly total, transactions much larger than usual for their category and large charges from new payees. Uses robust z-scores (median and median absolute deviation).
+// @Tags insights
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month (YYYY-MM), default: current month"
+// @Param months query int false "Trailing months used as history (default: 6, min: 3, max: 24)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.InsightsResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/insights [get]
+func (h *InsightHandler) List(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	// Parse parameters
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	if m := r.URL.Query().Get("month"); m != "" {
+		if parsed, err := time.Parse("2006-01", m); err == nil {
+			startDate = parsed
+		}
+	}
+
+	months := defaultInsightMonths
+	if m := r.URL.Query().Get("months"); m != "" {
+		parsed, err := strconv.Atoi(m)
+		if err != nil || parsed < minInsightMonths || parsed > maxInsightMonths {
+			writeValidationError(w, []dto.ValidationError{
+				{Field: "months", Message: fmt.Sprintf("Months must be between %d and %d", minInsightMonths, maxInsightMonths)},
+			})
+			return
+		}
+		months = parsed
+	}
+
+	endDate := startDate.AddDate(0, 1, -1)
+	historyStart := startDate.AddDate(0, -months, 0)
+	historyEnd := startDate.AddDate(0, 0, -1)
+
+	transactions, err := h.transactionRepo.ListByDateRange(r.Context(), familyID, historyStart, endDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to analyze transactions")
+		return
+	}
+
+	categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to analyze transactions")
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(categories))
+	system := make(map[uuid.UUID]bool)
+	for _, c := range categories {
+		names[c.ID] = c.Name
+		system[c.ID] = c.IsSystem
+	}
+
+	// Expenses in base currency; balance adjustments are not spending
+	var history, current []insights.Transaction
+	for _, t := range transactions {
+		if t.Type != "expense" || system[t.CategoryID] {
+			continue
+		}
+		item := insights.Transaction{
+			ID:          t.ID,
+			CategoryID:  t.CategoryID,
+			Description: t.Description.String,
+			Amount:      t.AmountBase,
+			Date:        t.TransactionDate.Time,
+		}
+		if item.Date.Before(startDate) {
+			history = append(history, item)
+		} else {
+			current = append(current, item)
+		}
+	}
+
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	found := insights.Detect(history, current, historyStart, months)
+
+	findings := make([]dto.InsightFinding, 0, len(found))
+	for _, f := range found {
+		name, ok := names[f.CategoryID]
+		if !ok {
+			name = "Unknown"
+		}
+
+		title, explanation := explainInsight(f, name, months, baseCurrency)
+		finding := dto.InsightFinding{
+			Kind:         f.Kind,
+			Severity:     f.Severity,
+			Title:        title,
+			Explanation:  explanation,
+			CategoryID:   f.CategoryID,
+			CategoryName: name,
+			Amount:       f.Amount,
+			Expected:     f.Expected,
+			Score:        f.Score,
+			Transactions: make([]dto.InsightTransaction, len(f.Transactions)),
+		}
+		for i, t := range f.Transactions {
+			var description *string
+			if t.Description != "" {
+				desc := t.Description
+				description = &desc
+			}
+			finding.Transactions[i] = dto.InsightTransaction{
+				ID:          t.ID,
+				Date:        t.Date.Format("2006-01-02"),
+				Amount:      t.Amount,
+				Description: description,
+				Link:        "/api/v1/transactions/" + t.ID.String(),
+			}
+		}
+		findings = append(findings, finding)
+	}
+
+	response := dto.InsightsResponse{
+		Period: dto.ReportPeriod{
+			StartDate: startDate.Format("2006-01-02"),
+			EndDate:   endDate.Format("2006-01-02"),
+		},
+		History: dto.ReportPeriod{
+			StartDate: historyStart.Format("2006-01-02"),
+			EndDate:   historyEnd.Format("2006-01-02"),
+		},
+		HistoryMonths: months,
+		Currency:      baseCurrency,
+		Findings:      findings,
+		GeneratedAt:   time.Now().UTC(),
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// explainInsight returns the title and a human-readable explanation of a finding
+func explainInsight(f insights.Finding, categoryName string, months int, currency string) (string, string) {
+	amount := f.Amount.StringFixed(2)
+	expected := f.Expected.StringFixed(2)
+
+	switch f.Kind {
+	case insights.KindCategorySpike:
+		ratio := "much more than"
+		if f.Expected.IsPositive() {
+			ratio = f.Amount.Div(f.Expected).StringFixed(1) + "x"
+		}
+		return fmt.Sprintf("Spending spike in %s", categoryName),
+			fmt.Sprintf("%s %s spent in %s this month, %s the usual %s %s per month (median of the last %d months)",
+				amount, currency, categoryName, ratio, expected, currency, months)
+	case insights.KindLargeTransaction:
+		return fmt.Sprintf("Unusually large expense in %s", categoryName),
+			fmt.Sprintf("A single expense of %s %s in %s, while a typical expense in this category is %s %s",
+				amount, currency, categoryName, expected, currency)
+	default:
+		return fmt.Sprintf("Large charge from new payee %q", f.Description),
+			fmt.Sprintf("First expense from %q in the last %d months is %s %s (%s), while a typical expense is %s %s",
+				f.Description, months, amount, currency, categoryName, expected, currency)
+	}
+}
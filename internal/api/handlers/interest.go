@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/api/middleware"
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+	"github.com/DigitLock/expense-tracker/internal/dto"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+// interestPreviewMonths is the projection horizon of the interest preview
+const interestPreviewMonths = 12
+
+type InterestHandler struct {
+	interestRepo *repository.InterestRepository
+	accountRepo  *repository.AccountRepository
+	categoryRepo *repository.CategoryRepository
+	currencyRepo *repository.CurrencyRepository
+	validate     *validator.Validate
+}
+
+func NewInterestHandler(
+	interestRepo *repository.InterestRepository,
+	accountRepo *repository.AccountRepository,
+	categoryRepo *repository.CategoryRepository,
+	currencyRepo *repository.CurrencyRepository,
+) *InterestHandler {
+	return &InterestHandler{
+		interestRepo: interestRepo,
+		accountRepo:  accountRepo,
+		categoryRepo: categoryRepo,
+		currencyRepo: currencyRepo,
+		validate:     validator.New(),
+	}
+}
+
+// Get godoc
+// @Summary Get interest settings
+// @Description Returns interest accrual settings of a savings account
+// @Tags accounts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.InterestSettingsResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/accounts/{id}/interest [get]
+func (h *InterestHandler) Get(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid account ID format")
+		return
+	}
+
+	// Check account exists and belongs to family
+	account, err := h.accountRepo.GetByID(r.Context(), accountID)
+	if err != nil || account.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Account not found")
+		return
+	}
+
+	settings, err := h.interestRepo.GetByAccount(r.Context(), accountID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Interest is not configured for this account")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, mapInterestSettings(settings))
+}
+
+// Put godoc
+// @Summary Configure interest
+// @Description Creates or replaces interest accrual settings of a savings account
+// @Tags accounts
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Param request body dto.InterestSettingsRequest true "Interest settings"
+// @Success 200 {object} dto.SuccessResponse{data=dto.InterestSettingsResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/accounts/{id}/interest [put]
+func (h *InterestHandler) Put(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid account ID format")
+		return
+	}
+
+	// Check account exists and belongs to family
+	account, err := h.accountRepo.GetByID(r.Context(), accountID)
+	if err != nil || account.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Account not found")
+		return
+	}
+	if account.Type != "savings" {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "account_id", Message: "Interest can only be configured for savings accounts"},
+		})
+		return
+	}
+
+	var req dto.InterestSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_JSON", "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		writeValidationError(w, formatValidationErrors(err))
+		return
+	}
+
+	if errors := req.ValidateBusiness(); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
+
+	// Validate income category
+	category, err := h.categoryRepo.GetByID(r.Context(), req.IncomeCategoryID)
+	if err != nil || category.FamilyID != familyID {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "income_category_id", Message: "Category not found"},
+		})
+		return
+	}
+	if category.Type != "income" {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "income_category_id", Message: "Category must be an income category"},
+		})
+		return
+	}
+	if category.IsSystem {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "income_category_id", Message: "System categories cannot be assigned manually"},
+		})
+		return
+	}
+
+	settings, err := h.interestRepo.Upsert(r.Context(), repository.UpsertInterestSettingsInput{
+		AccountID:          accountID,
+		FamilyID:           familyID,
+		AnnualRate:         req.AnnualRate,
+		Compounding:        req.Compounding,
+		PayoutDay:          req.PayoutDay,
+		WithholdingTaxRate: req.WithholdingTaxRate,
+		IncomeCategoryID:   req.IncomeCategoryID,
+		UpdatedBy:          userID,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to save interest settings")
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, mapInterestSettings(settings))
+}
+
+// Delete godoc
+// @Summary Remove interest settings
+// @Description Stops interest accrual for an account. Already posted transactions are kept.
+// @Tags accounts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Success 200 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/accounts/{id}/interest [delete]
+func (h *InterestHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	userID, ok := middleware.GetUserID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "User context not found")
+		return
+	}
+
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid account ID format")
+		return
+	}
+
+	// Check account exists and belongs to family
+	account, err := h.accountRepo.GetByIDIncludingInactive(r.Context(), accountID)
+	if err != nil || account.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Account not found")
+		return
+	}
+
+	if _, err := h.interestRepo.GetByAccount(r.Context(), accountID); err != nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Interest is not configured for this account")
+		return
+	}
+
+	if err := h.interestRepo.Delete(r.Context(), accountID, userID); err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to delete interest settings")
+		return
+	}
+
+	writeMessage(w, http.StatusOK, "Interest settings deleted successfully")
+}
+
+// Preview godoc
+// @Summary Preview interest
+// @Description Returns projected interest payouts for the next 12 months, assuming no other transactions on the account
+// @Tags accounts
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Account ID"
+// @Success 200 {object} dto.SuccessResponse{data=dto.InterestPreviewResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/accounts/{id}/interest/preview [get]
+func (h *InterestHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	accountID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid account ID format")
+		return
+	}
+
+	// Check account exists and belongs to family
+	account, err := h.accountRepo.GetByID(r.Context(), accountID)
+	if err != nil || account.FamilyID != familyID {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Account not found")
+		return
+	}
+
+	settings, err := h.interestRepo.GetByAccount(r.Context(), accountID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "Interest is not configured for this account")
+		return
+	}
+
+	currency, err := h.currencyRepo.Get(r.Context(), account.Currency)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to fetch account currency")
+		return
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	endDate := today.AddDate(0, interestPreviewMonths, 0)
+
+	// Project payouts with interest added to the balance (compounding)
+	balance := account.CurrentBalance
+	payouts := []dto.InterestPreviewItem{}
+	var totalGross, totalTax, totalNet decimal.Decimal
+
+	for payoutDate := repository.NextInterestPayoutDate(settings); !payoutDate.After(endDate); {
+		amount := repository.CalculateInterest(settings, balance, currency)
+
+		payouts = append(payouts, dto.InterestPreviewItem{
+			Date:           payoutDate.Format("2006-01-02"),
+			OpeningBalance: balance,
+			GrossInterest:  amount.Gross,
+			Tax:            amount.Tax,
+			NetInterest:    amount.Net,
+			ClosingBalance: balance.Add(amount.Net),
+		})
+
+		balance = balance.Add(amount.Net)
+		totalGross = totalGross.Add(amount.Gross)
+		totalTax = totalTax.Add(amount.Tax)
+		totalNet = totalNet.Add(amount.Net)
+
+		settings.LastPostedDate.Time = payoutDate
+		settings.LastPostedDate.Valid = true
+		payoutDate = repository.NextInterestPayoutDate(settings)
+	}
+
+	response := dto.InterestPreviewResponse{
+		AccountID:   account.ID,
+		Currency:    account.Currency,
+		AnnualRate:  settings.AnnualRate,
+		Compounding: settings.Compounding,
+		Period: dto.ReportPeriod{
+			StartDate: today.Format("2006-01-02"),
+			EndDate:   endDate.Format("2006-01-02"),
+		},
+		CurrentBalance:   account.CurrentBalance,
+		Payouts:          payouts,
+		TotalGross:       totalGross,
+		TotalTax:         totalTax,
+		TotalNet:         totalNet,
+		ProjectedBalance: balance,
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// --- Helper functions ---
+
+func mapInterestSettings(s sqlc.AccountInterestSetting) dto.InterestSettingsResponse {
+	response := dto.InterestSettingsResponse{
+		AccountID:          s.AccountID,
+		AnnualRate:         s.AnnualRate,
+		Compounding:        s.Compounding,
+		PayoutDay:          int(s.PayoutDay),
+		WithholdingTaxRate: s.WithholdingTaxRate,
+		IncomeCategoryID:   s.IncomeCategoryID,
+		NextPayoutDate:     repository.NextInterestPayoutDate(s).Format("2006-01-02"),
+		CreatedAt:          s.CreatedAt,
+		UpdatedAt:          s.UpdatedAt,
+	}
+	if s.LastPostedDate.Valid {
+		lastPosted := s.LastPostedDate.Time.Format("2006-01-02")
+		response.LastPostedDate = &lastPosted
+	}
+	return response
+}
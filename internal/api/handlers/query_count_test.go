@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/api/middleware"
+	"github.com/DigitLock/expense-tracker/internal/config"
+	"github.com/DigitLock/expense-tracker/internal/database"
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+// Query count checks need a database with all migrations applied. They run only
+// when TEST_DATABASE=true; the connection is configured by the DB_* variables.
+// Each family gets its own data, so the database may be shared with development.
+
+// queryCountCategories is the number of expense categories of the fixture family
+const queryCountCategories = 5
+
+type queryCountFixture struct {
+	repos      *repository.Repositories
+	familyID   uuid.UUID
+	userID     uuid.UUID
+	account    sqlc.Account
+	categories []sqlc.Category
+}
+
+func newQueryCountFixture(tb testing.TB) *queryCountFixture {
+	tb.Helper()
+	if os.Getenv("TEST_DATABASE") != "true" {
+		tb.Skip("TEST_DATABASE is not set")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		tb.Fatalf("failed to load config: %v", err)
+	}
+
+	cfg.Database.CountQueries = true
+
+	ctx := context.Background()
+	db, err := database.New(ctx, cfg.Database)
+	if err != nil {
+		tb.Fatalf("failed to connect to database: %v", err)
+	}
+	tb.Cleanup(db.Close)
+
+	repos := repository.New(db.Pool)
+	suffix := uuid.NewString()[:8]
+
+	family, err := repos.Families.Create(ctx, "Query count "+suffix, "RSD")
+	if err != nil {
+		tb.Fatalf("failed to create family: %v", err)
+	}
+	tb.Cleanup(func() {
+		if err := repos.Families.Delete(context.Background(), family.ID); err != nil {
+			tb.Logf("failed to delete family %s: %v", family.ID, err)
+		}
+	})
+
+	user, err := repos.Users.Create(ctx, repository.CreateUserInput{
+		FamilyID: family.ID,
+		Email:    "query-count-" + suffix + "@example.com",
+		Name:     "Query count",
+		Password: "query-count-" + suffix,
+	})
+	if err != nil {
+		tb.Fatalf("failed to create user: %v", err)
+	}
+
+	account, err := repos.Accounts.Create(ctx, repository.CreateAccountInput{
+		FamilyID:       family.ID,
+		Name:           "Checking",
+		Type:           "checking",
+		Currency:       "RSD",
+		InitialBalance: decimal.NewFromInt(100000),
+	})
+	if err != nil {
+		tb.Fatalf("failed to create account: %v", err)
+	}
+
+	f := &queryCountFixture{
+		repos:    repos,
+		familyID: family.ID,
+		userID:   user.ID,
+		account:  account,
+	}
+
+	for i := 0; i < queryCountCategories; i++ {
+		category, err := repos.Categories.Create(ctx, repository.CreateCategoryInput{
+			FamilyID: family.ID,
+			Name:     fmt.Sprintf("Category %d", i+1),
+			Type:     "expense",
+		})
+		if err != nil {
+			tb.Fatalf("failed to create category: %v", err)
+		}
+		f.categories = append(f.categories, category)
+	}
+
+	return f
+}
+
+// addTransactions creates n expenses of the current month spread over all categories
+func (f *queryCountFixture) addTransactions(tb testing.TB, n int) {
+	tb.Helper()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	for i := 0; i < n; i++ {
+		_, err := f.repos.Transactions.Create(context.Background(), repository.CreateTransactionInput{
+			FamilyID:        f.familyID,
+			AccountID:       f.account.ID,
+			CategoryID:      f.categories[i%len(f.categories)].ID,
+			Type:            "expense",
+			Amount:          decimal.NewFromInt(int64(100 + i)),
+			Currency:        f.account.Currency,
+			Description:     fmt.Sprintf("Expense %d", i+1),
+			TransactionDate: today,
+			CreatedBy:       f.userID,
+		})
+		if err != nil {
+			tb.Fatalf("failed to create transaction: %v", err)
+		}
+	}
+}
+
+// countQueries serves one request and returns the number of database round-trips
+func (f *queryCountFixture) countQueries(tb testing.TB, handler http.HandlerFunc, target string) int64 {
+	tb.Helper()
+
+	ctx, counter := database.WithQueryCounter(context.Background())
+	ctx = context.WithValue(ctx, middleware.FamilyIDKey, f.familyID)
+	ctx = context.WithValue(ctx, middleware.UserIDKey, f.userID)
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, target, nil).WithContext(ctx))
+	if rec.Code != http.StatusOK {
+		tb.Fatalf("GET %s: status %d: %s", target, rec.Code, rec.Body.String())
+	}
+	return counter.Load()
+}
+
+type queryCountCase struct {
+	name    string
+	target  string
+	handler http.HandlerFunc
+}
+
+func (f *queryCountFixture) cases() []queryCountCase {
+	r := f.repos
+	transactionHandler := NewTransactionHandler(r.Transactions, r.Accounts, r.Categories, r.Families, r.Currencies)
+	reportHandler := NewReportHandler(
+		r.Transactions,
+		r.Accounts,
+		r.Categories,
+		r.Budgets,
+		r.Interest,
+		r.Users,
+		r.Families,
+		r.ExchangeRates,
+		r.Currencies,
+	)
+
+	return []queryCountCase{
+		{"transactions", "/api/v1/transactions?per_page=100", transactionHandler.List},
+		{"spending_by_category", "/api/v1/reports/spending-by-category", reportHandler.SpendingByCategory},
+		{"monthly_summary", "/api/v1/reports/monthly-summary", reportHandler.MonthlySummary},
+		{"annual", "/api/v1/reports/annual", reportHandler.Annual},
+	}
+}
+
+// TestQueryCountIndependentOfRows fails if a handler issues queries per listed row
+func TestQueryCountIndependentOfRows(t *testing.T) {
+	f := newQueryCountFixture(t)
+	f.addTransactions(t, queryCountCategories)
+
+	cases := f.cases()
+	before := make([]int64, len(cases))
+	for i, c := range cases {
+		before[i] = f.countQueries(t, c.handler, c.target)
+	}
+
+	f.addTransactions(t, 10*queryCountCategories)
+
+	for i, c := range cases {
+		after := f.countQueries(t, c.handler, c.target)
+		t.Logf("%s: %d queries", c.name, after)
+		if after != before[i] {
+			t.Errorf("%s: %d queries with %d transactions, %d with %d",
+				c.name, before[i], queryCountCategories, after, 11*queryCountCategories)
+		}
+	}
+}
+
+// BenchmarkQueryCount reports database round-trips per request as queries/op
+func BenchmarkQueryCount(b *testing.B) {
+	f := newQueryCountFixture(b)
+	f.addTransactions(b, 10*queryCountCategories)
+
+	for _, c := range f.cases() {
+		b.Run(c.name, func(b *testing.B) {
+			var queries int64
+			for i := 0; i < b.N; i++ {
+				queries += f.countQueries(b, c.handler, c.target)
+			}
+			b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+		})
+	}
+}
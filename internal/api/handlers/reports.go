@@ -1,31 +1,77 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 
 	"github.com/DigitLock/expense-tracker/internal/api/middleware"
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
 	"github.com/DigitLock/expense-tracker/internal/dto"
+	"github.com/DigitLock/expense-tracker/internal/export"
+	"github.com/DigitLock/expense-tracker/internal/forecast"
 	"github.com/DigitLock/expense-tracker/internal/repository"
 )
 
+const (
+	maxTrendMonths      = 36 // longest period of the trends report
+	trendLookbackMonths = 11 // extra months loaded for the 12 month rolling average
+
+	defaultForecastDays  = 30
+	maxForecastDays      = 365
+	forecastLookbackDays = 400 // history used to detect recurring transactions, covers yearly ones
+	forecastLowPoints    = 3
+
+	maxDailyDays = 731 // two years of heatmap
+	dailyTopDays = 10
+
+	minAnnualYear             = 2000
+	annualTopPayees           = 10
+	annualLargestTransactions = 10
+)
+
 type ReportHandler struct {
 	transactionRepo *repository.TransactionRepository
 	accountRepo     *repository.AccountRepository
 	categoryRepo    *repository.CategoryRepository
+	budgetRepo      *repository.BudgetRepository
+	interestRepo    *repository.InterestRepository
+	userRepo        *repository.UserRepository
+	familyRepo      *repository.FamilyRepository
+	exchangeRepo    *repository.ExchangeRateRepository
+	currencyRepo    *repository.CurrencyRepository
 }
 
 func NewReportHandler(
 	transactionRepo *repository.TransactionRepository,
 	accountRepo *repository.AccountRepository,
 	categoryRepo *repository.CategoryRepository,
+	budgetRepo *repository.BudgetRepository,
+	interestRepo *repository.InterestRepository,
+	userRepo *repository.UserRepository,
+	familyRepo *repository.FamilyRepository,
+	exchangeRepo *repository.ExchangeRateRepository,
+	currencyRepo *repository.CurrencyRepository,
 ) *ReportHandler {
 	return &ReportHandler{
 		transactionRepo: transactionRepo,
 		accountRepo:     accountRepo,
 		categoryRepo:    categoryRepo,
+		budgetRepo:      budgetRepo,
+		interestRepo:    interestRepo,
+		userRepo:        userRepo,
+		familyRepo:      familyRepo,
+		exchangeRepo:    exchangeRepo,
+		currencyRepo:    currencyRepo,
 	}
 }
 
@@ -34,11 +80,18 @@ func NewReportHandler(
 // @Description Returns spending breakdown by category for a date range
 // @Tags reports
 // @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
 // @Security BearerAuth
 // @Param start_date query string false "Start date (YYYY-MM-DD), default: first day of current month"
 // @Param end_date query string false "End date (YYYY-MM-DD), default: today"
 // @Param type query string false "Transaction type: income or expense (default: expense)"
+// @Param group_by query string false "Grouping: category or root (child spending rolled into top-level parent), default: category"
+// @Param format query string false "Export format: csv, xlsx or pdf (default: JSON)"
+// @Param locale query string false "Number format of exports: en, sr or ru (default: Accept-Language)"
 // @Success 200 {object} dto.SuccessResponse{data=dto.SpendingByCategoryResponse}
+// @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Router /api/v1/reports/spending-by-category [get]
 func (h *ReportHandler) SpendingByCategory(w http.ResponseWriter, r *http.Request) {
@@ -70,13 +123,48 @@ func (h *ReportHandler) SpendingByCategory(w http.ResponseWriter, r *http.Reques
 		transactionType = "expense"
 	}
 
-	// Get summary by category
-	summaries, err := h.transactionRepo.GetSummaryByCategory(r.Context(), familyID, transactionType, startDate, endDate)
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy != "root" {
+		groupBy = "category"
+	}
+
+	format, exporting, err := exportFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_FORMAT", err.Error())
+		return
+	}
+
+	// Get summary by category, names are joined by the query
+	namedSummaries, err := h.transactionRepo.GetSummaryByCategoryWithNames(r.Context(), familyID, transactionType, startDate, endDate)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
 		return
 	}
 
+	names := make(map[uuid.UUID]string, len(namedSummaries))
+	summaries := make([]sqlc.GetTransactionsSummaryByCategoryRow, len(namedSummaries))
+	for i, s := range namedSummaries {
+		names[s.CategoryID] = s.CategoryName
+		summaries[i] = sqlc.GetTransactionsSummaryByCategoryRow{
+			CategoryID: s.CategoryID,
+			Count:      s.Count,
+			Total:      s.Total,
+		}
+	}
+
+	// Roll child spending into top-level categories
+	if groupBy == "root" {
+		categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+			return
+		}
+		for _, c := range categories {
+			names[c.ID] = c.Name
+		}
+		summaries = groupSummariesByRoot(summaries, rootCategoryIDs(categories))
+	}
+
 	// Calculate totals and build response
 	var totalAmount decimal.Decimal
 	var totalTransactions int
@@ -90,9 +178,9 @@ func (h *ReportHandler) SpendingByCategory(w http.ResponseWriter, r *http.Reques
 	categorySpending := make([]dto.CategorySpending, len(summaries))
 	for i, s := range summaries {
 		// Get category name
-		categoryName := "Unknown"
-		if cat, err := h.categoryRepo.GetByID(r.Context(), s.CategoryID); err == nil {
-			categoryName = cat.Name
+		categoryName, ok := names[s.CategoryID]
+		if !ok {
+			categoryName = "Unknown"
 		}
 
 		percentage := decimal.Zero
@@ -115,20 +203,31 @@ func (h *ReportHandler) SpendingByCategory(w http.ResponseWriter, r *http.Reques
 		}
 	}
 
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
 	response := dto.SpendingByCategoryResponse{
 		ReportType: "spending_by_category",
 		Period: dto.ReportPeriod{
 			StartDate: startDate.Format("2006-01-02"),
 			EndDate:   endDate.Format("2006-01-02"),
 		},
-		Currency:           "RSD",
+		Currency:           baseCurrency,
 		TransactionType:    transactionType,
+		GroupBy:            groupBy,
 		SpendingByCategory: categorySpending,
 		TotalAmount:        totalAmount,
 		TotalTransactions:  totalTransactions,
 		GeneratedAt:        time.Now().UTC(),
 	}
 
+	if exporting {
+		exportSpendingByCategory(w, r, format, response)
+		return
+	}
+
 	writeSuccess(w, http.StatusOK, response)
 }
 
@@ -137,9 +236,15 @@ func (h *ReportHandler) SpendingByCategory(w http.ResponseWriter, r *http.Reques
 // @Description Returns financial summary for a specific month
 // @Tags reports
 // @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
 // @Security BearerAuth
 // @Param month query string false "Month (YYYY-MM), default: current month"
+// @Param format query string false "Export format: csv, xlsx or pdf (default: JSON)"
+// @Param locale query string false "Number format of exports: en, sr or ru (default: Accept-Language)"
 // @Success 200 {object} dto.SuccessResponse{data=dto.MonthlySummaryResponse}
+// @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Router /api/v1/reports/monthly-summary [get]
 func (h *ReportHandler) MonthlySummary(w http.ResponseWriter, r *http.Request) {
@@ -162,6 +267,12 @@ func (h *ReportHandler) MonthlySummary(w http.ResponseWriter, r *http.Request) {
 	startDate := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
 	endDate := startDate.AddDate(0, 1, -1) // Last day of month
 
+	format, exporting, err := exportFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_FORMAT", err.Error())
+		return
+	}
+
 	// Get summary by type (income/expense totals)
 	typeSummaries, err := h.transactionRepo.GetSummaryByType(r.Context(), familyID, startDate, endDate)
 	if err != nil {
@@ -190,36 +301,42 @@ func (h *ReportHandler) MonthlySummary(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get income breakdown by category
-	incomeSummaries, _ := h.transactionRepo.GetSummaryByCategory(r.Context(), familyID, "income", startDate, endDate)
+	incomeSummaries, _ := h.transactionRepo.GetSummaryByCategoryWithNames(r.Context(), familyID, "income", startDate, endDate)
 	incomeBreakdown := make(map[string]decimal.Decimal)
 	for _, s := range incomeSummaries {
-		if cat, err := h.categoryRepo.GetByID(r.Context(), s.CategoryID); err == nil {
-			incomeBreakdown[cat.Name] = s.Total
-		}
+		incomeBreakdown[s.CategoryName] = s.Total
 	}
 
 	// Get expense breakdown by category
-	expenseSummaries, _ := h.transactionRepo.GetSummaryByCategory(r.Context(), familyID, "expense", startDate, endDate)
+	expenseSummaries, _ := h.transactionRepo.GetSummaryByCategoryWithNames(r.Context(), familyID, "expense", startDate, endDate)
 	expenseBreakdown := make(map[string]decimal.Decimal)
 	for _, s := range expenseSummaries {
-		if cat, err := h.categoryRepo.GetByID(r.Context(), s.CategoryID); err == nil {
-			expenseBreakdown[cat.Name] = s.Total
-		}
+		expenseBreakdown[s.CategoryName] = s.Total
+	}
+
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
 	}
 
-	// Get account balances
+	// Get account balances (converted from account currency to base currency)
 	accounts, _ := h.accountRepo.ListByFamily(r.Context(), familyID)
 	accountBalances := make(map[string]decimal.Decimal)
 	var totalBalance decimal.Decimal
 	for _, acc := range accounts {
-		accountBalances[acc.Name] = acc.CurrentBalance
-		totalBalance = totalBalance.Add(acc.CurrentBalance)
+		balance, err := h.exchangeRepo.Convert(r.Context(), acc.CurrentBalance, acc.Currency, baseCurrency, now)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "CONVERSION_ERROR", "Failed to convert account balance")
+			return
+		}
+		accountBalances[acc.Name] = balance
+		totalBalance = totalBalance.Add(balance)
 	}
 
 	response := dto.MonthlySummaryResponse{
 		ReportType: "monthly_summary",
 		Month:      startDate.Format("2006-01"),
-		Currency:   "RSD",
+		Currency:   baseCurrency,
 		Summary: dto.MonthlySummary{
 			TotalIncome:   totalIncome,
 			TotalExpenses: totalExpenses,
@@ -240,5 +357,1643 @@ func (h *ReportHandler) MonthlySummary(w http.ResponseWriter, r *http.Request) {
 		GeneratedAt: time.Now().UTC(),
 	}
 
+	if exporting {
+		exportMonthlySummary(w, r, format, response, startDate, endDate)
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// BudgetVsActual godoc
+// @Summary Budget vs actual report
+// @Description Returns planned, carried over, spent, remaining and percent used per budgeted or envelope expense category for a month. Spending of child categories rolls up to their parents.
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param month query string false "Month (YYYY-MM), default: current month"
+// @Success 200 {object} dto.SuccessResponse{data=dto.BudgetVsActualResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/reports/budget-vs-actual [get]
+func (h *ReportHandler) BudgetVsActual(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	// Parse month parameter
+	now := time.Now()
+	year, month := now.Year(), now.Month()
+
+	if m := r.URL.Query().Get("month"); m != "" {
+		if parsed, err := time.Parse("2006-01", m); err == nil {
+			year, month = parsed.Year(), parsed.Month()
+		}
+	}
+
+	startDate := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.AddDate(0, 1, -1) // Last day of month
+
+	plans, err := h.budgetRepo.PlanMonth(r.Context(), familyID, startDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	available, err := h.budgetRepo.GetAvailableToAssign(r.Context(), familyID, startDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	summaries, err := h.transactionRepo.GetSummaryByCategory(r.Context(), familyID, "expense", startDate, endDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	parents := make(map[uuid.UUID]uuid.UUID, len(categories))
+	byID := make(map[uuid.UUID]sqlc.Category, len(categories))
+	for _, c := range categories {
+		byID[c.ID] = c
+		if c.ParentID.Valid {
+			parents[c.ID] = c.ParentID.Bytes
+		}
+	}
+
+	rolled := rollUpCategoryTotals(summaries, parents)
+
+	budgeted := make(map[uuid.UUID]bool, len(plans))
+	for _, p := range plans {
+		budgeted[p.CategoryID] = true
+	}
+
+	var totalPlanned, totalCarriedOver, totalSpent, totalExpenses decimal.Decimal
+	for _, s := range summaries {
+		totalExpenses = totalExpenses.Add(s.Total)
+	}
+
+	items := make([]dto.BudgetVsActualItem, len(plans))
+	for i, p := range plans {
+		spent := rolled[p.CategoryID]
+		availableAmount := p.Available()
+
+		item := dto.BudgetVsActualItem{
+			CategoryID:       p.CategoryID,
+			CategoryName:     "Unknown",
+			RolloverMode:     p.RolloverMode,
+			Assigned:         p.Assigned,
+			Planned:          p.Planned,
+			CarriedOver:      p.CarriedOver,
+			Available:        availableAmount,
+			Spent:            spent.amount,
+			Remaining:        availableAmount.Sub(spent.amount),
+			PercentUsed:      percentOf(spent.amount, availableAmount),
+			IsOverBudget:     spent.amount.GreaterThan(availableAmount),
+			TransactionCount: spent.count,
+		}
+		if p.Budget != nil {
+			budgetID := p.Budget.ID
+			item.BudgetID = &budgetID
+			item.IsDefaultBudget = !p.Budget.Month.Valid
+		}
+		if cat, ok := byID[p.CategoryID]; ok {
+			item.CategoryName = cat.Name
+		}
+		if parentID, ok := parents[p.CategoryID]; ok {
+			item.ParentID = &parentID
+		}
+		items[i] = item
+
+		// Nested budgets are already covered by the budget of their ancestor
+		if !hasBudgetedAncestor(p.CategoryID, parents, budgeted) {
+			totalPlanned = totalPlanned.Add(p.Planned)
+			totalCarriedOver = totalCarriedOver.Add(p.CarriedOver)
+			totalSpent = totalSpent.Add(spent.amount)
+		}
+	}
+
+	totalAvailable := totalPlanned.Add(totalCarriedOver)
+
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	response := dto.BudgetVsActualResponse{
+		ReportType:        "budget_vs_actual",
+		Month:             startDate.Format("2006-01"),
+		Currency:          baseCurrency,
+		Categories:        items,
+		TotalPlanned:      totalPlanned,
+		TotalCarriedOver:  totalCarriedOver,
+		TotalSpent:        totalSpent,
+		TotalRemaining:    totalAvailable.Sub(totalSpent),
+		PercentUsed:       percentOf(totalSpent, totalAvailable),
+		UnbudgetedSpent:   totalExpenses.Sub(totalSpent),
+		AvailableToAssign: available.Available,
+		GeneratedAt:       time.Now().UTC(),
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// Trends godoc
+// @Summary Spending trends report
+// @Description Returns per-month totals grouped by category, type or account with month-over-month change and rolling 3/6/12 month averages
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First month (YYYY-MM), default: 5 months before to"
+// @Param to query string false "Last month (YYYY-MM), default: current month"
+// @Param group_by query string false "Grouping: category, type or account (default: category)"
+// @Param type query string false "Transaction type: income or expense (default: expense, both for group_by=type)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.TrendsResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/reports/trends [get]
+func (h *ReportHandler) Trends(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	// Parse parameters
+	to := repository.BudgetMonth(time.Now())
+	if t := r.URL.Query().Get("to"); t != "" {
+		if parsed, err := time.Parse("2006-01", t); err == nil {
+			to = parsed
+		}
+	}
+
+	from := to.AddDate(0, -5, 0)
+	if f := r.URL.Query().Get("from"); f != "" {
+		if parsed, err := time.Parse("2006-01", f); err == nil {
+			from = parsed
+		}
+	}
+
+	if from.After(to) {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "from", Message: "From month must not be after to month"},
+		})
+		return
+	}
+	if monthsBetween(from, to) >= maxTrendMonths {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "from", Message: "Period cannot be longer than 36 months"},
+		})
+		return
+	}
+
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy != "type" && groupBy != "account" {
+		groupBy = "category"
+	}
+
+	var transactionType *string
+	if t := r.URL.Query().Get("type"); t == "income" || t == "expense" {
+		transactionType = &t
+	} else if groupBy != "type" {
+		expense := "expense"
+		transactionType = &expense
+	}
+
+	// Load extra months before the period for rolling averages and the first change
+	queryStart := from.AddDate(0, -trendLookbackMonths, 0)
+	endDate := to.AddDate(0, 1, -1) // Last day of month
+
+	rows, err := h.transactionRepo.GetMonthlyTrend(r.Context(), familyID, groupBy, transactionType, queryStart, endDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	names, err := h.trendGroupNames(r, familyID, groupBy)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	var months []time.Time
+	for m := queryStart; !m.After(to); m = m.AddDate(0, 1, 0) {
+		months = append(months, m)
+	}
+	index := make(map[time.Time]int, len(months))
+	for i, m := range months {
+		index[m] = i
+	}
+	offset := index[from]
+
+	totals := make(map[string][]decimal.Decimal)
+	counts := make(map[string][]int)
+	var keys []string
+	overallTotals := make([]decimal.Decimal, len(months))
+	overallCounts := make([]int, len(months))
+
+	for _, row := range rows {
+		i, ok := index[repository.BudgetMonth(row.Month.Time)]
+		if !ok {
+			continue
+		}
+		if _, exists := totals[row.GroupKey]; !exists {
+			totals[row.GroupKey] = make([]decimal.Decimal, len(months))
+			counts[row.GroupKey] = make([]int, len(months))
+			keys = append(keys, row.GroupKey)
+		}
+		totals[row.GroupKey][i] = row.Total
+		counts[row.GroupKey][i] = int(row.Count)
+		overallTotals[i] = overallTotals[i].Add(row.Total)
+		overallCounts[i] += int(row.Count)
+	}
+
+	periodMonths := decimal.NewFromInt(int64(len(months) - offset))
+	series := make([]dto.TrendSeries, 0, len(keys))
+	for _, key := range keys {
+		var periodTotal decimal.Decimal
+		var periodCount int
+		for i := offset; i < len(months); i++ {
+			periodTotal = periodTotal.Add(totals[key][i])
+			periodCount += counts[key][i]
+		}
+		// Skip groups that only appear in the lookback months
+		if periodCount == 0 {
+			continue
+		}
+
+		name, ok := names[key]
+		if !ok {
+			name = "Unknown"
+		}
+
+		series = append(series, dto.TrendSeries{
+			Key:     key,
+			Name:    name,
+			Points:  trendPoints(months, totals[key], counts[key], offset),
+			Total:   periodTotal,
+			Average: periodTotal.Div(periodMonths).Round(2),
+		})
+	}
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].Total.GreaterThan(series[j].Total)
+	})
+
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	response := dto.TrendsResponse{
+		ReportType:  "trends",
+		From:        from.Format("2006-01"),
+		To:          to.Format("2006-01"),
+		Currency:    baseCurrency,
+		GroupBy:     groupBy,
+		Series:      series,
+		GeneratedAt: time.Now().UTC(),
+	}
+	// Income and expense cannot be added up
+	if transactionType != nil {
+		response.TransactionType = *transactionType
+		response.Totals = trendPoints(months, overallTotals, overallCounts, offset)
+	}
+
 	writeSuccess(w, http.StatusOK, response)
 }
+
+// Comparison godoc
+// @Summary Period comparison report
+// @Description Compares per-category totals of two date ranges. Without previous range, compares with the preceding period of the same length or the same period a year earlier.
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Current period start (YYYY-MM-DD), default: first day of current month"
+// @Param end_date query string false "Current period end (YYYY-MM-DD), default: today"
+// @Param previous_start_date query string false "Previous period start (YYYY-MM-DD)"
+// @Param previous_end_date query string false "Previous period end (YYYY-MM-DD)"
+// @Param compare_to query string false "Default previous period: previous_period or previous_year (default: previous_period)"
+// @Param type query string false "Transaction type: income or expense (default: expense)"
+// @Param group_by query string false "Grouping: category or root (default: category)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.PeriodComparisonResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/reports/comparison [get]
+func (h *ReportHandler) Comparison(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	// Parse parameters
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if sd := r.URL.Query().Get("start_date"); sd != "" {
+		if parsed, err := time.Parse("2006-01-02", sd); err == nil {
+			startDate = parsed
+		}
+	}
+
+	if ed := r.URL.Query().Get("end_date"); ed != "" {
+		if parsed, err := time.Parse("2006-01-02", ed); err == nil {
+			endDate = parsed
+		}
+	}
+
+	if startDate.After(endDate) {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "start_date", Message: "Start date must not be after end date"},
+		})
+		return
+	}
+
+	// Default previous period
+	var previousStart, previousEnd time.Time
+	if r.URL.Query().Get("compare_to") == "previous_year" {
+		previousStart = startDate.AddDate(-1, 0, 0)
+		previousEnd = endDate.AddDate(-1, 0, 0)
+	} else {
+		days := int(endDate.Sub(startDate).Hours()/24) + 1
+		previousEnd = startDate.AddDate(0, 0, -1)
+		previousStart = previousEnd.AddDate(0, 0, -(days - 1))
+	}
+
+	if sd := r.URL.Query().Get("previous_start_date"); sd != "" {
+		if parsed, err := time.Parse("2006-01-02", sd); err == nil {
+			previousStart = parsed
+		}
+	}
+
+	if ed := r.URL.Query().Get("previous_end_date"); ed != "" {
+		if parsed, err := time.Parse("2006-01-02", ed); err == nil {
+			previousEnd = parsed
+		}
+	}
+
+	if previousStart.After(previousEnd) {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "previous_start_date", Message: "Previous start date must not be after previous end date"},
+		})
+		return
+	}
+
+	transactionType := r.URL.Query().Get("type")
+	if transactionType != "income" && transactionType != "expense" {
+		transactionType = "expense"
+	}
+
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy != "root" {
+		groupBy = "category"
+	}
+
+	current, err := h.transactionRepo.GetSummaryByCategory(r.Context(), familyID, transactionType, startDate, endDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	previous, err := h.transactionRepo.GetSummaryByCategory(r.Context(), familyID, transactionType, previousStart, previousEnd)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	// Roll child spending into top-level categories
+	if groupBy == "root" {
+		roots := rootCategoryIDs(categories)
+		current = groupSummariesByRoot(current, roots)
+		previous = groupSummariesByRoot(previous, roots)
+	}
+
+	names := make(map[uuid.UUID]string, len(categories))
+	for _, c := range categories {
+		names[c.ID] = c.Name
+	}
+
+	var currentTotal, previousTotal decimal.Decimal
+	for _, s := range current {
+		currentTotal = currentTotal.Add(s.Total)
+	}
+	for _, s := range previous {
+		previousTotal = previousTotal.Add(s.Total)
+	}
+
+	comparisons := compareCategories(current, previous, names)
+
+	appeared := []uuid.UUID{}
+	disappeared := []uuid.UUID{}
+	for _, c := range comparisons {
+		switch c.Status {
+		case "new":
+			appeared = append(appeared, c.CategoryID)
+		case "disappeared":
+			disappeared = append(disappeared, c.CategoryID)
+		}
+	}
+
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		return comparisons[i].Change.Abs().GreaterThan(comparisons[j].Change.Abs())
+	})
+
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	response := dto.PeriodComparisonResponse{
+		ReportType: "period_comparison",
+		Current: dto.ReportPeriod{
+			StartDate: startDate.Format("2006-01-02"),
+			EndDate:   endDate.Format("2006-01-02"),
+		},
+		Previous: dto.ReportPeriod{
+			StartDate: previousStart.Format("2006-01-02"),
+			EndDate:   previousEnd.Format("2006-01-02"),
+		},
+		Currency:              baseCurrency,
+		TransactionType:       transactionType,
+		GroupBy:               groupBy,
+		Categories:            comparisons,
+		CurrentTotal:          currentTotal,
+		PreviousTotal:         previousTotal,
+		Change:                currentTotal.Sub(previousTotal),
+		ChangePercent:         changePercent(currentTotal, previousTotal),
+		AppearedCategories:    appeared,
+		DisappearedCategories: disappeared,
+		GeneratedAt:           time.Now().UTC(),
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// Forecast godoc
+// @Summary Cash-flow forecast
+// @Description Projects daily balances of each account for the next N days from current balances, recurring transactions detected in the history (same payee, amount and interval) and scheduled interest payouts. Returns the expected low points of each account.
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param days query int false "Forecast horizon in days (default: 30, max: 365)"
+// @Param account_id query string false "Forecast a single account"
+// @Success 200 {object} dto.SuccessResponse{data=dto.CashFlowForecastResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /api/v1/reports/forecast [get]
+func (h *ReportHandler) Forecast(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	// Parse parameters
+	days := defaultForecastDays
+	if d := r.URL.Query().Get("days"); d != "" {
+		parsed, err := strconv.Atoi(d)
+		if err != nil || parsed < 1 || parsed > maxForecastDays {
+			writeValidationError(w, []dto.ValidationError{
+				{Field: "days", Message: fmt.Sprintf("Days must be between 1 and %d", maxForecastDays)},
+			})
+			return
+		}
+		days = parsed
+	}
+
+	accounts, err := h.accountRepo.ListByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	if a := r.URL.Query().Get("account_id"); a != "" {
+		accountID, err := uuid.Parse(a)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_ID", "Invalid account ID format")
+			return
+		}
+
+		var selected []sqlc.Account
+		for _, account := range accounts {
+			if account.ID == accountID {
+				selected = append(selected, account)
+			}
+		}
+		if len(selected) == 0 {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "Account not found")
+			return
+		}
+		accounts = selected
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	until := today.AddDate(0, 0, days)
+
+	history, err := h.transactionRepo.ListByDateRange(r.Context(), familyID, today.AddDate(0, 0, -forecastLookbackDays), today)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(categories))
+	for _, c := range categories {
+		names[c.ID] = c.Name
+	}
+
+	forecasts := make([]dto.AccountForecast, 0, len(accounts))
+	for _, account := range accounts {
+		var events []forecast.Event
+
+		// Scheduled interest payouts; interest already posted is not treated as a recurring pattern
+		var interestCategoryID *uuid.UUID
+		if account.Type == "savings" {
+			settings, err := h.interestRepo.GetByAccount(r.Context(), account.ID)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+				return
+			}
+			if err == nil {
+				currency, err := h.currencyRepo.Get(r.Context(), account.Currency)
+				if err != nil {
+					writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+					return
+				}
+				interestCategoryID = &settings.IncomeCategoryID
+				events = append(events, interestEvents(settings, currency, account.CurrentBalance, today, until)...)
+			}
+		}
+
+		var occurrences []forecast.Occurrence
+		for _, t := range history {
+			if t.AccountID != account.ID {
+				continue
+			}
+			if interestCategoryID != nil && t.CategoryID == *interestCategoryID {
+				continue
+			}
+			occurrences = append(occurrences, forecast.Occurrence{
+				AccountID:   t.AccountID,
+				CategoryID:  t.CategoryID,
+				Type:        t.Type,
+				Description: t.Description.String,
+				Amount:      t.Amount,
+				Date:        t.TransactionDate.Time,
+			})
+		}
+
+		for _, p := range forecast.DetectRecurring(occurrences) {
+			events = append(events, p.Events(today, until)...)
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].Date.Before(events[j].Date)
+		})
+
+		projected := forecast.Project(account.CurrentBalance, events, today, until)
+
+		item := dto.AccountForecast{
+			AccountID:      account.ID,
+			AccountName:    account.Name,
+			AccountType:    account.Type,
+			Currency:       account.Currency,
+			CurrentBalance: account.CurrentBalance,
+			LowestBalance:  account.CurrentBalance,
+			LowPoints:      []dto.ForecastDay{},
+			Events:         make([]dto.ForecastEvent, 0, len(events)),
+			Days:           make([]dto.ForecastDay, 0, len(projected)),
+		}
+
+		for _, d := range projected {
+			date := d.Date.Format("2006-01-02")
+			if d.Balance.LessThan(item.LowestBalance) {
+				item.LowestBalance = d.Balance
+				item.LowestBalanceDate = &date
+			}
+			if item.FirstNegativeDate == nil && d.Balance.IsNegative() {
+				item.FirstNegativeDate = &date
+			}
+			item.Days = append(item.Days, mapForecastDay(d))
+		}
+
+		for _, d := range forecast.LowPoints(projected, forecastLowPoints) {
+			item.LowPoints = append(item.LowPoints, mapForecastDay(d))
+		}
+
+		for _, e := range events {
+			name, ok := names[e.CategoryID]
+			if !ok {
+				name = "Unknown"
+			}
+			var description *string
+			if e.Description != "" {
+				desc := e.Description
+				description = &desc
+			}
+			item.Events = append(item.Events, dto.ForecastEvent{
+				Date:         e.Date.Format("2006-01-02"),
+				Type:         e.Type,
+				Amount:       e.Amount,
+				CategoryID:   e.CategoryID,
+				CategoryName: name,
+				Description:  description,
+				Source:       e.Source,
+				Interval:     e.Interval,
+			})
+		}
+
+		forecasts = append(forecasts, item)
+	}
+
+	response := dto.CashFlowForecastResponse{
+		ReportType: "cash_flow_forecast",
+		Period: dto.ReportPeriod{
+			StartDate: today.AddDate(0, 0, 1).Format("2006-01-02"),
+			EndDate:   until.Format("2006-01-02"),
+		},
+		Days:         days,
+		LookbackDays: forecastLookbackDays,
+		Accounts:     forecasts,
+		GeneratedAt:  time.Now().UTC(),
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// Members godoc
+// @Summary Spending by family member
+// @Description Breaks down income and expenses by the family member who recorded the transactions, with category breakdowns and shares of household totals
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD), default: first day of current month"
+// @Param end_date query string false "End date (YYYY-MM-DD), default: today"
+// @Param group_by query string false "Category grouping: category or root (default: category)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.MemberSpendingResponse}
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/reports/members [get]
+func (h *ReportHandler) Members(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	// Parse parameters
+	now := time.Now()
+	startDate := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	endDate := now
+
+	if sd := r.URL.Query().Get("start_date"); sd != "" {
+		if parsed, err := time.Parse("2006-01-02", sd); err == nil {
+			startDate = parsed
+		}
+	}
+
+	if ed := r.URL.Query().Get("end_date"); ed != "" {
+		if parsed, err := time.Parse("2006-01-02", ed); err == nil {
+			endDate = parsed
+		}
+	}
+
+	groupBy := r.URL.Query().Get("group_by")
+	if groupBy != "root" {
+		groupBy = "category"
+	}
+
+	summaries, err := h.transactionRepo.GetSummaryByMember(r.Context(), familyID, startDate, endDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	users, err := h.userRepo.ListByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	names := make(map[uuid.UUID]string, len(categories))
+	for _, c := range categories {
+		names[c.ID] = c.Name
+	}
+
+	// Active members first, then former members who still have transactions
+	members := make([]*dto.MemberSpending, 0, len(users))
+	byUser := make(map[uuid.UUID]*dto.MemberSpending, len(users))
+	for _, u := range users {
+		m := &dto.MemberSpending{UserID: u.ID, Name: u.Name, IsActive: true}
+		members = append(members, m)
+		byUser[u.ID] = m
+	}
+
+	type memberKey struct {
+		userID          uuid.UUID
+		transactionType string
+	}
+	byCategory := make(map[memberKey][]sqlc.GetTransactionsSummaryByCategoryRow)
+
+	var totalIncome, totalExpense decimal.Decimal
+	for _, s := range summaries {
+		m, exists := byUser[s.CreatedBy]
+		if !exists {
+			m = &dto.MemberSpending{UserID: s.CreatedBy, Name: "Former member"}
+			members = append(members, m)
+			byUser[s.CreatedBy] = m
+		}
+
+		if s.Type == "income" {
+			m.TotalIncome = m.TotalIncome.Add(s.Total)
+			m.IncomeCount += int(s.Count)
+			totalIncome = totalIncome.Add(s.Total)
+		} else {
+			m.TotalExpense = m.TotalExpense.Add(s.Total)
+			m.ExpenseCount += int(s.Count)
+			totalExpense = totalExpense.Add(s.Total)
+		}
+
+		k := memberKey{s.CreatedBy, s.Type}
+		byCategory[k] = append(byCategory[k], sqlc.GetTransactionsSummaryByCategoryRow{
+			CategoryID: s.CategoryID,
+			Count:      s.Count,
+			Total:      s.Total,
+		})
+	}
+
+	var roots map[uuid.UUID]uuid.UUID
+	if groupBy == "root" {
+		roots = rootCategoryIDs(categories)
+	}
+
+	breakdown := func(userID uuid.UUID, transactionType string, total decimal.Decimal) []dto.CategorySpending {
+		rows := byCategory[memberKey{userID, transactionType}]
+		// Roll child spending into top-level categories
+		if roots != nil {
+			rows = groupSummariesByRoot(rows, roots)
+		}
+
+		items := make([]dto.CategorySpending, 0, len(rows))
+		for _, row := range rows {
+			name, ok := names[row.CategoryID]
+			if !ok {
+				name = "Unknown"
+			}
+			var average decimal.Decimal
+			if row.Count > 0 {
+				average = row.Total.Div(decimal.NewFromInt(row.Count)).Round(2)
+			}
+			items = append(items, dto.CategorySpending{
+				CategoryID:            row.CategoryID,
+				CategoryName:          name,
+				TotalAmount:           row.Total,
+				TransactionCount:      int(row.Count),
+				Percentage:            percentOf(row.Total, total),
+				AveragePerTransaction: average,
+			})
+		}
+		return items
+	}
+
+	result := make([]dto.MemberSpending, 0, len(members))
+	for _, m := range members {
+		m.Net = m.TotalIncome.Sub(m.TotalExpense)
+		m.IncomeShare = percentOf(m.TotalIncome, totalIncome)
+		m.ExpenseShare = percentOf(m.TotalExpense, totalExpense)
+		m.IncomeCategories = breakdown(m.UserID, "income", m.TotalIncome)
+		m.ExpenseCategories = breakdown(m.UserID, "expense", m.TotalExpense)
+		result = append(result, *m)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].TotalExpense.GreaterThan(result[j].TotalExpense)
+	})
+
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	response := dto.MemberSpendingResponse{
+		ReportType: "member_spending",
+		Period: dto.ReportPeriod{
+			StartDate: startDate.Format("2006-01-02"),
+			EndDate:   endDate.Format("2006-01-02"),
+		},
+		Currency:     baseCurrency,
+		GroupBy:      groupBy,
+		Members:      result,
+		TotalIncome:  totalIncome,
+		TotalExpense: totalExpense,
+		GeneratedAt:  time.Now().UTC(),
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// Daily godoc
+// @Summary Daily spending report
+// @Description Returns totals per day for a calendar heatmap (every day of the range, including days without transactions), averages per weekday and the days with the highest totals
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date (YYYY-MM-DD), default: one year before end date"
+// @Param end_date query string false "End date (YYYY-MM-DD), default: today"
+// @Param type query string false "Transaction type: income or expense (default: expense)"
+// @Success 200 {object} dto.SuccessResponse{data=dto.DailySpendingResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/reports/daily [get]
+func (h *ReportHandler) Daily(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	// Parse parameters
+	now := time.Now()
+	endDate := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	if ed := r.URL.Query().Get("end_date"); ed != "" {
+		if parsed, err := time.Parse("2006-01-02", ed); err == nil {
+			endDate = parsed
+		}
+	}
+
+	startDate := endDate.AddDate(-1, 0, 1)
+	if sd := r.URL.Query().Get("start_date"); sd != "" {
+		if parsed, err := time.Parse("2006-01-02", sd); err == nil {
+			startDate = parsed
+		}
+	}
+
+	if startDate.After(endDate) {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "start_date", Message: "Start date must not be after end date"},
+		})
+		return
+	}
+
+	dayCount := int(endDate.Sub(startDate).Hours()/24) + 1
+	if dayCount > maxDailyDays {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "start_date", Message: fmt.Sprintf("Period must not be longer than %d days", maxDailyDays)},
+		})
+		return
+	}
+
+	transactionType := r.URL.Query().Get("type")
+	if transactionType != "income" && transactionType != "expense" {
+		transactionType = "expense"
+	}
+
+	rows, err := h.transactionRepo.GetDailyTotals(r.Context(), familyID, transactionType, startDate, endDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	byDate := make(map[time.Time]sqlc.GetTransactionsDailyTotalsRow, len(rows))
+	for _, row := range rows {
+		byDate[row.Day.Time] = row
+	}
+
+	// Weekdays from Monday
+	weekdays := make([]dto.WeekdayAverage, 7)
+	for i := range weekdays {
+		weekdays[i].Weekday = strings.ToLower(time.Weekday((i + 1) % 7).String())
+	}
+
+	days := make([]dto.DailyTotal, 0, dayCount)
+	var totalAmount, maxDayAmount decimal.Decimal
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		row := byDate[date]
+		day := dto.DailyTotal{
+			Date:             date.Format("2006-01-02"),
+			Weekday:          strings.ToLower(date.Weekday().String()),
+			TotalAmount:      row.Total,
+			TransactionCount: int(row.Count),
+		}
+		days = append(days, day)
+
+		wd := &weekdays[(int(date.Weekday())+6)%7]
+		wd.Days++
+		wd.TotalAmount = wd.TotalAmount.Add(row.Total)
+		wd.TransactionCount += int(row.Count)
+
+		totalAmount = totalAmount.Add(row.Total)
+		if row.Total.GreaterThan(maxDayAmount) {
+			maxDayAmount = row.Total
+		}
+	}
+
+	for i := range weekdays {
+		wd := &weekdays[i]
+		if wd.Days > 0 {
+			n := decimal.NewFromInt(int64(wd.Days))
+			wd.AverageAmount = wd.TotalAmount.Div(n).Round(2)
+			wd.AverageCount = decimal.NewFromInt(int64(wd.TransactionCount)).Div(n).Round(2)
+		}
+	}
+
+	topDays := make([]dto.DailyTotal, 0, dailyTopDays)
+	for _, d := range days {
+		if d.TransactionCount > 0 {
+			topDays = append(topDays, d)
+		}
+	}
+	sort.SliceStable(topDays, func(i, j int) bool {
+		return topDays[i].TotalAmount.GreaterThan(topDays[j].TotalAmount)
+	})
+	if len(topDays) > dailyTopDays {
+		topDays = topDays[:dailyTopDays]
+	}
+
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	response := dto.DailySpendingResponse{
+		ReportType: "daily_spending",
+		Period: dto.ReportPeriod{
+			StartDate: startDate.Format("2006-01-02"),
+			EndDate:   endDate.Format("2006-01-02"),
+		},
+		Currency:        baseCurrency,
+		TransactionType: transactionType,
+		Days:            days,
+		Weekdays:        weekdays,
+		TopDays:         topDays,
+		TotalAmount:     totalAmount,
+		AveragePerDay:   totalAmount.Div(decimal.NewFromInt(int64(dayCount))).Round(2),
+		MaxDayAmount:    maxDayAmount,
+		GeneratedAt:     time.Now().UTC(),
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// Annual godoc
+// @Summary Annual report
+// @Description Summarizes a year: monthly totals and savings rates, totals per category, top payees by description, largest expenses, net change of each account and comparison with the previous year. For the current year the report runs up to today and is compared with the same part of the previous year.
+// @Tags reports
+// @Produce json
+// @Security BearerAuth
+// @Param year query int false "Year (YYYY), default: current year"
+// @Success 200 {object} dto.SuccessResponse{data=dto.AnnualReportResponse}
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Router /api/v1/reports/annual [get]
+func (h *ReportHandler) Annual(w http.ResponseWriter, r *http.Request) {
+	familyID, ok := middleware.GetFamilyID(r.Context())
+	if !ok {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Family context not found")
+		return
+	}
+
+	// Parse year parameter
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	year := today.Year()
+
+	if y := r.URL.Query().Get("year"); y != "" {
+		parsed, err := strconv.Atoi(y)
+		if err != nil || parsed < minAnnualYear || parsed > today.Year() {
+			writeValidationError(w, []dto.ValidationError{
+				{Field: "year", Message: fmt.Sprintf("Year must be between %d and %d", minAnnualYear, today.Year())},
+			})
+			return
+		}
+		year = parsed
+	}
+
+	startDate := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC)
+	if endDate.After(today) {
+		endDate = today // year to date
+	}
+	previousStart := startDate.AddDate(-1, 0, 0)
+	previousEnd := endDate.AddDate(-1, 0, 0)
+
+	// Monthly totals per type
+	current, err := h.transactionRepo.GetMonthlyTrend(r.Context(), familyID, "type", nil, startDate, endDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	previous, err := h.transactionRepo.GetMonthlyTrend(r.Context(), familyID, "type", nil, previousStart, previousEnd)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	months := make([]dto.AnnualMonth, 0, 12)
+	monthIndex := make(map[time.Month]int, 12)
+	for m := startDate; !m.After(endDate); m = m.AddDate(0, 1, 0) {
+		monthIndex[m.Month()] = len(months)
+		months = append(months, dto.AnnualMonth{Month: m.Format("2006-01")})
+	}
+
+	var totals, previousTotals dto.AnnualTotals
+	for _, row := range current {
+		i, ok := monthIndex[row.Month.Time.Month()]
+		if !ok {
+			continue
+		}
+		if row.GroupKey == "income" {
+			months[i].TotalIncome = row.Total
+			months[i].IncomeCount = int(row.Count)
+			totals.TotalIncome = totals.TotalIncome.Add(row.Total)
+			totals.IncomeCount += int(row.Count)
+		} else {
+			months[i].TotalExpenses = row.Total
+			months[i].ExpenseCount = int(row.Count)
+			totals.TotalExpenses = totals.TotalExpenses.Add(row.Total)
+			totals.ExpenseCount += int(row.Count)
+		}
+	}
+	for _, row := range previous {
+		i, ok := monthIndex[row.Month.Time.Month()]
+		if !ok {
+			continue
+		}
+		if row.GroupKey == "income" {
+			months[i].PreviousIncome = row.Total
+			previousTotals.TotalIncome = previousTotals.TotalIncome.Add(row.Total)
+			previousTotals.IncomeCount += int(row.Count)
+		} else {
+			months[i].PreviousExpense = row.Total
+			previousTotals.TotalExpenses = previousTotals.TotalExpenses.Add(row.Total)
+			previousTotals.ExpenseCount += int(row.Count)
+		}
+	}
+
+	for i := range months {
+		months[i].NetSavings = months[i].TotalIncome.Sub(months[i].TotalExpenses)
+		months[i].SavingsRate = percentOf(months[i].NetSavings, months[i].TotalIncome)
+	}
+	totals.NetSavings = totals.TotalIncome.Sub(totals.TotalExpenses)
+	totals.SavingsRate = percentOf(totals.NetSavings, totals.TotalIncome)
+	previousTotals.NetSavings = previousTotals.TotalIncome.Sub(previousTotals.TotalExpenses)
+	previousTotals.SavingsRate = percentOf(previousTotals.NetSavings, previousTotals.TotalIncome)
+
+	// Totals per category
+	incomeSummaries, err := h.transactionRepo.GetSummaryByCategoryWithNames(r.Context(), familyID, "income", startDate, endDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	expenseSummaries, err := h.transactionRepo.GetSummaryByCategoryWithNames(r.Context(), familyID, "expense", startDate, endDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	previousExpenseSummaries, err := h.transactionRepo.GetSummaryByCategoryWithNames(r.Context(), familyID, "expense", previousStart, previousEnd)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	names := make(map[uuid.UUID]string)
+	unnamed := func(rows []sqlc.GetTransactionsSummaryByCategoryWithNamesRow) []sqlc.GetTransactionsSummaryByCategoryRow {
+		summaries := make([]sqlc.GetTransactionsSummaryByCategoryRow, len(rows))
+		for i, s := range rows {
+			names[s.CategoryID] = s.CategoryName
+			summaries[i] = sqlc.GetTransactionsSummaryByCategoryRow{
+				CategoryID: s.CategoryID,
+				Count:      s.Count,
+				Total:      s.Total,
+			}
+		}
+		return summaries
+	}
+
+	categoryComparisons := compareCategories(unnamed(expenseSummaries), unnamed(previousExpenseSummaries), names)
+	sort.SliceStable(categoryComparisons, func(i, j int) bool {
+		return categoryComparisons[i].Change.Abs().GreaterThan(categoryComparisons[j].Change.Abs())
+	})
+
+	// Top payees and largest expenses
+	payees, err := h.transactionRepo.GetTopDescriptions(r.Context(), familyID, "expense", startDate, endDate, annualTopPayees)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	topPayees := make([]dto.AnnualPayee, len(payees))
+	for i, p := range payees {
+		topPayees[i] = dto.AnnualPayee{
+			Description:      p.Description,
+			TotalAmount:      p.Total,
+			TransactionCount: int(p.Count),
+			Percentage:       percentOf(p.Total, totals.TotalExpenses),
+		}
+	}
+
+	largest, err := h.transactionRepo.ListLargest(r.Context(), familyID, "expense", startDate, endDate, annualLargestTransactions)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	largestTransactions := make([]dto.TransactionResponse, len(largest))
+	for i, t := range largest {
+		largestTransactions[i] = mapTransaction(t, baseCurrency)
+	}
+
+	// Net change per account
+	accounts, err := h.accountRepo.ListAllByFamily(r.Context(), familyID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	balances, err := h.accountRepo.ListBalancesBetween(r.Context(), familyID, startDate, endDate)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "DATABASE_ERROR", "Failed to generate report")
+		return
+	}
+
+	balanceByAccount := make(map[uuid.UUID]sqlc.ListAccountBalancesBetweenRow, len(balances))
+	for _, b := range balances {
+		balanceByAccount[b.AccountID] = b
+	}
+
+	accountChanges := make([]dto.AnnualAccountChange, 0, len(accounts))
+	for _, account := range accounts {
+		opening := balanceByAccount[account.ID].OpeningBalance
+		closing := balanceByAccount[account.ID].ClosingBalance
+
+		// Accounts closed before the year or without balance in it are left out
+		if !account.IsActive && opening.IsZero() && closing.IsZero() {
+			continue
+		}
+
+		accountChanges = append(accountChanges, dto.AnnualAccountChange{
+			AccountID:      account.ID,
+			AccountName:    account.Name,
+			AccountType:    account.Type,
+			Currency:       account.Currency,
+			OpeningBalance: opening,
+			ClosingBalance: closing,
+			NetChange:      closing.Sub(opening),
+		})
+	}
+
+	response := dto.AnnualReportResponse{
+		ReportType: "annual",
+		Year:       year,
+		Period: dto.ReportPeriod{
+			StartDate: startDate.Format("2006-01-02"),
+			EndDate:   endDate.Format("2006-01-02"),
+		},
+		Currency:            baseCurrency,
+		Totals:              totals,
+		Months:              months,
+		IncomeCategories:    namedCategorySpending(incomeSummaries, totals.TotalIncome),
+		ExpenseCategories:   namedCategorySpending(expenseSummaries, totals.TotalExpenses),
+		TopPayees:           topPayees,
+		LargestTransactions: largestTransactions,
+		Accounts:            accountChanges,
+		PreviousYear: dto.AnnualComparison{
+			Year:                 year - 1,
+			Totals:               previousTotals,
+			IncomeChange:         totals.TotalIncome.Sub(previousTotals.TotalIncome),
+			IncomeChangePercent:  changePercent(totals.TotalIncome, previousTotals.TotalIncome),
+			ExpenseChange:        totals.TotalExpenses.Sub(previousTotals.TotalExpenses),
+			ExpenseChangePercent: changePercent(totals.TotalExpenses, previousTotals.TotalExpenses),
+			ExpenseCategories:    categoryComparisons,
+		},
+		GeneratedAt: time.Now().UTC(),
+	}
+
+	writeSuccess(w, http.StatusOK, response)
+}
+
+// groupSummariesByRoot merges category summaries into their top-level categories,
+// sorted by total descending
+func groupSummariesByRoot(
+	summaries []sqlc.GetTransactionsSummaryByCategoryRow,
+	roots map[uuid.UUID]uuid.UUID,
+) []sqlc.GetTransactionsSummaryByCategoryRow {
+	grouped := make(map[uuid.UUID]*sqlc.GetTransactionsSummaryByCategoryRow)
+	var order []uuid.UUID
+
+	for _, s := range summaries {
+		rootID, ok := roots[s.CategoryID]
+		if !ok {
+			rootID = s.CategoryID
+		}
+
+		g, exists := grouped[rootID]
+		if !exists {
+			g = &sqlc.GetTransactionsSummaryByCategoryRow{CategoryID: rootID}
+			grouped[rootID] = g
+			order = append(order, rootID)
+		}
+		g.Count += s.Count
+		g.Total = g.Total.Add(s.Total)
+	}
+
+	result := make([]sqlc.GetTransactionsSummaryByCategoryRow, 0, len(order))
+	for _, id := range order {
+		result = append(result, *grouped[id])
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Total.GreaterThan(result[j].Total)
+	})
+
+	return result
+}
+
+// rollUpCategoryTotals adds totals of every category to the category itself
+// and all of its ancestors
+func rollUpCategoryTotals(
+	summaries []sqlc.GetTransactionsSummaryByCategoryRow,
+	parents map[uuid.UUID]uuid.UUID,
+) map[uuid.UUID]categoryTotal {
+	rolled := make(map[uuid.UUID]categoryTotal)
+
+	for _, s := range summaries {
+		id := s.CategoryID
+		for depth := 0; depth <= len(parents); depth++ {
+			t := rolled[id]
+			t.amount = t.amount.Add(s.Total)
+			t.count += int(s.Count)
+			rolled[id] = t
+
+			parentID, ok := parents[id]
+			if !ok {
+				break
+			}
+			id = parentID
+		}
+	}
+
+	return rolled
+}
+
+// hasBudgetedAncestor reports whether any ancestor of the category has a budget
+func hasBudgetedAncestor(categoryID uuid.UUID, parents map[uuid.UUID]uuid.UUID, budgeted map[uuid.UUID]bool) bool {
+	id := categoryID
+	for depth := 0; depth < len(parents); depth++ {
+		parentID, ok := parents[id]
+		if !ok {
+			return false
+		}
+		if budgeted[parentID] {
+			return true
+		}
+		id = parentID
+	}
+	return false
+}
+
+// percentOf returns part as a percentage of whole, rounded to one decimal place
+func percentOf(part, whole decimal.Decimal) decimal.Decimal {
+	if whole.IsZero() {
+		return decimal.Zero
+	}
+	return part.Div(whole).Mul(decimal.NewFromInt(100)).Round(1)
+}
+
+// namedCategorySpending builds category totals with their share of total
+func namedCategorySpending(rows []sqlc.GetTransactionsSummaryByCategoryWithNamesRow, total decimal.Decimal) []dto.CategorySpending {
+	items := make([]dto.CategorySpending, len(rows))
+	for i, row := range rows {
+		var average decimal.Decimal
+		if row.Count > 0 {
+			average = row.Total.Div(decimal.NewFromInt(row.Count)).Round(2)
+		}
+		items[i] = dto.CategorySpending{
+			CategoryID:            row.CategoryID,
+			CategoryName:          row.CategoryName,
+			TotalAmount:           row.Total,
+			TransactionCount:      int(row.Count),
+			Percentage:            percentOf(row.Total, total),
+			AveragePerTransaction: average,
+		}
+	}
+	return items
+}
+
+// trendGroupNames returns display names of trend groups by key
+func (h *ReportHandler) trendGroupNames(r *http.Request, familyID uuid.UUID, groupBy string) (map[string]string, error) {
+	names := make(map[string]string)
+
+	switch groupBy {
+	case "category":
+		categories, err := h.categoryRepo.ListAllByFamily(r.Context(), familyID)
+		if err != nil {
+			return nil, err
+		}
+		for _, c := range categories {
+			names[c.ID.String()] = c.Name
+		}
+	case "account":
+		accounts, err := h.accountRepo.ListAllByFamily(r.Context(), familyID)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range accounts {
+			names[a.ID.String()] = a.Name
+		}
+	default:
+		names["income"] = "Income"
+		names["expense"] = "Expense"
+	}
+
+	return names, nil
+}
+
+// trendPoints builds points of a monthly series starting at offset. Values
+// before offset are only used for the change and rolling averages.
+func trendPoints(months []time.Time, totals []decimal.Decimal, counts []int, offset int) []dto.TrendPoint {
+	points := make([]dto.TrendPoint, 0, len(months)-offset)
+
+	for i := offset; i < len(months); i++ {
+		point := dto.TrendPoint{
+			Month:            months[i].Format("2006-01"),
+			Total:            totals[i],
+			TransactionCount: counts[i],
+			RollingAvg3:      rollingAverage(totals, i, 3),
+			RollingAvg6:      rollingAverage(totals, i, 6),
+			RollingAvg12:     rollingAverage(totals, i, 12),
+		}
+		if i > 0 {
+			previous := totals[i-1]
+			point.Change = totals[i].Sub(previous)
+			point.ChangePercent = changePercent(totals[i], previous)
+		}
+		points = append(points, point)
+	}
+
+	return points
+}
+
+// compareCategories pairs category totals of two periods, in order of first appearance
+func compareCategories(current, previous []sqlc.GetTransactionsSummaryByCategoryRow, names map[uuid.UUID]string) []dto.CategoryComparison {
+	byCategory := make(map[uuid.UUID]*dto.CategoryComparison)
+	var order []uuid.UUID
+	item := func(categoryID uuid.UUID) *dto.CategoryComparison {
+		c, exists := byCategory[categoryID]
+		if !exists {
+			name, ok := names[categoryID]
+			if !ok {
+				name = "Unknown"
+			}
+			c = &dto.CategoryComparison{CategoryID: categoryID, CategoryName: name}
+			byCategory[categoryID] = c
+			order = append(order, categoryID)
+		}
+		return c
+	}
+
+	for _, s := range current {
+		c := item(s.CategoryID)
+		c.CurrentAmount = s.Total
+		c.CurrentCount = int(s.Count)
+	}
+	for _, s := range previous {
+		c := item(s.CategoryID)
+		c.PreviousAmount = s.Total
+		c.PreviousCount = int(s.Count)
+	}
+
+	comparisons := make([]dto.CategoryComparison, 0, len(order))
+	for _, id := range order {
+		c := byCategory[id]
+		c.Change = c.CurrentAmount.Sub(c.PreviousAmount)
+		c.ChangePercent = changePercent(c.CurrentAmount, c.PreviousAmount)
+
+		switch {
+		case c.PreviousCount == 0:
+			c.Status = "new"
+		case c.CurrentCount == 0:
+			c.Status = "disappeared"
+		default:
+			c.Status = "continued"
+		}
+
+		comparisons = append(comparisons, *c)
+	}
+
+	return comparisons
+}
+
+// rollingAverage returns the average of n values ending at index i
+func rollingAverage(values []decimal.Decimal, i, n int) decimal.Decimal {
+	start := i - n + 1
+	if start < 0 {
+		start = 0
+	}
+
+	var sum decimal.Decimal
+	for _, v := range values[start : i+1] {
+		sum = sum.Add(v)
+	}
+
+	return sum.Div(decimal.NewFromInt(int64(i - start + 1))).Round(2)
+}
+
+// changePercent returns the change from previous to current in percent,
+// nil if previous is zero
+func changePercent(current, previous decimal.Decimal) *decimal.Decimal {
+	if previous.IsZero() {
+		return nil
+	}
+	change := current.Sub(previous).Div(previous).Mul(decimal.NewFromInt(100)).Round(1)
+	return &change
+}
+
+// interestEvents returns interest payouts scheduled up to until.
+// Interest is estimated on the current balance.
+func interestEvents(settings sqlc.AccountInterestSetting, currency sqlc.Currency, balance decimal.Decimal, today, until time.Time) []forecast.Event {
+	var events []forecast.Event
+	for {
+		payoutDate := repository.NextInterestPayoutDate(settings)
+		if payoutDate.After(until) {
+			break
+		}
+		settings.LastPostedDate = pgtype.Date{Time: payoutDate, Valid: true}
+
+		// Overdue payouts are posted by the interest job on its next run
+		if !payoutDate.After(today) {
+			payoutDate = today.AddDate(0, 0, 1)
+		}
+
+		amount := repository.CalculateInterest(settings, balance, currency)
+		if !amount.Net.IsPositive() {
+			continue
+		}
+		events = append(events, forecast.Event{
+			Date:       payoutDate,
+			AccountID:  settings.AccountID,
+			CategoryID: settings.IncomeCategoryID,
+			Type:       "income",
+			Amount:     amount.Net,
+			Source:     forecast.SourceInterest,
+			Interval:   settings.Compounding,
+		})
+	}
+	return events
+}
+
+func mapForecastDay(d forecast.Day) dto.ForecastDay {
+	return dto.ForecastDay{
+		Date:    d.Date.Format("2006-01-02"),
+		Balance: d.Balance,
+		Income:  d.Income,
+		Expense: d.Expense,
+	}
+}
+
+// exportSpendingByCategory writes the spending by category report as a file
+func exportSpendingByCategory(w http.ResponseWriter, r *http.Request, format export.Format, report dto.SpendingByCategoryResponse) {
+	filename := fmt.Sprintf("spending-by-category-%s-%s", report.Period.StartDate, report.Period.EndDate)
+	title := "Spending by category"
+	if report.TransactionType == "income" {
+		title = "Income by category"
+	}
+
+	ew, err := startExport(w, r, format, filename, export.Document{
+		Title:    title,
+		Period:   &report.Period,
+		Currency: report.Currency,
+	})
+	if err == nil {
+		err = ew.Section("", []export.Column{
+			{Title: "Category"},
+			{Title: "Transactions", Kind: export.KindInteger},
+			{Title: "Amount", Kind: export.KindMoney},
+			{Title: "Share", Kind: export.KindPercent},
+			{Title: "Average", Kind: export.KindMoney},
+		})
+	}
+	for _, c := range report.SpendingByCategory {
+		if err != nil {
+			break
+		}
+		err = ew.Row(c.CategoryName, c.TransactionCount, c.TotalAmount, c.Percentage, c.AveragePerTransaction)
+	}
+	if err == nil {
+		err = ew.Row("Total", report.TotalTransactions, report.TotalAmount, decimal.NewFromInt(100), "")
+	}
+	if err == nil {
+		err = ew.Close()
+	}
+	if err != nil {
+		logExportError(filename, err)
+	}
+}
+
+// exportMonthlySummary writes the monthly summary as a file with summary,
+// breakdown and account balance sections
+func exportMonthlySummary(w http.ResponseWriter, r *http.Request, format export.Format, report dto.MonthlySummaryResponse, startDate, endDate time.Time) {
+	filename := "monthly-summary-" + report.Month
+	ew, err := startExport(w, r, format, filename, export.Document{
+		Title: "Monthly summary " + report.Month,
+		Period: &dto.ReportPeriod{
+			StartDate: startDate.Format("2006-01-02"),
+			EndDate:   endDate.Format("2006-01-02"),
+		},
+		Currency: report.Currency,
+	})
+	if err != nil {
+		logExportError(filename, err)
+		return
+	}
+
+	// section writes a titled two-column table
+	section := func(title, nameColumn, valueColumn string, valueKind export.Kind, rows [][2]any) {
+		if err != nil {
+			return
+		}
+		err = ew.Section(title, []export.Column{{Title: nameColumn}, {Title: valueColumn, Kind: valueKind}})
+		for _, row := range rows {
+			if err != nil {
+				return
+			}
+			err = ew.Row(row[0], row[1])
+		}
+	}
+
+	// amounts sorts a breakdown map, largest amounts first
+	amounts := func(totals map[string]decimal.Decimal) [][2]any {
+		names := make([]string, 0, len(totals))
+		for name := range totals {
+			names = append(names, name)
+		}
+		sort.SliceStable(names, func(i, j int) bool {
+			return totals[names[i]].GreaterThan(totals[names[j]])
+		})
+
+		rows := make([][2]any, len(names))
+		for i, name := range names {
+			rows[i] = [2]any{name, totals[name]}
+		}
+		return rows
+	}
+
+	section("Summary", "Metric", "Amount", export.KindMoney, [][2]any{
+		{"Total income", report.Summary.TotalIncome},
+		{"Total expenses", report.Summary.TotalExpenses},
+		{"Net savings", report.Summary.NetSavings},
+	})
+	section("", "Metric", "Value", export.KindPercent, [][2]any{
+		{"Savings rate", report.Summary.SavingsRate},
+	})
+	section("Transactions", "Type", "Count", export.KindInteger, [][2]any{
+		{"Income", report.TransactionCounts.IncomeTransactions},
+		{"Expense", report.TransactionCounts.ExpenseTransactions},
+		{"Total", report.TransactionCounts.TotalTransactions},
+	})
+	section("Income by category", "Category", "Amount", export.KindMoney, amounts(report.IncomeBreakdown))
+	section("Expenses by category", "Category", "Amount", export.KindMoney, amounts(report.ExpenseBreakdown))
+	section("Account balances", "Account", "Balance", export.KindMoney, append(
+		amounts(report.AccountBalances.Accounts),
+		[2]any{"Total", report.AccountBalances.Total},
+	))
+
+	if err == nil {
+		err = ew.Close()
+	}
+	if err != nil {
+		logExportError(filename, err)
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/DigitLock/expense-tracker/internal/api/middleware"
 	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
 	"github.com/DigitLock/expense-tracker/internal/dto"
+	"github.com/DigitLock/expense-tracker/internal/export"
 	"github.com/DigitLock/expense-tracker/internal/repository"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
@@ -20,7 +22,8 @@ type TransactionHandler struct {
 	transactionRepo *repository.TransactionRepository
 	accountRepo     *repository.AccountRepository
 	categoryRepo    *repository.CategoryRepository
-	userRepo        *repository.UserRepository
+	familyRepo      *repository.FamilyRepository
+	currencyRepo    *repository.CurrencyRepository
 	validate        *validator.Validate
 }
 
@@ -28,29 +31,37 @@ func NewTransactionHandler(
 	transactionRepo *repository.TransactionRepository,
 	accountRepo *repository.AccountRepository,
 	categoryRepo *repository.CategoryRepository,
-	userRepo *repository.UserRepository,
+	familyRepo *repository.FamilyRepository,
+	currencyRepo *repository.CurrencyRepository,
 ) *TransactionHandler {
 	return &TransactionHandler{
 		transactionRepo: transactionRepo,
 		accountRepo:     accountRepo,
 		categoryRepo:    categoryRepo,
-		userRepo:        userRepo,
-		validate:        validator.New(),
+		familyRepo:      familyRepo,
+		currencyRepo:    currencyRepo,
+		validate:        newCurrencyValidator(currencyRepo),
 	}
 }
 
 // List godoc
 // @Summary List transactions
-// @Description Returns transactions for the authenticated user's family with pagination
+// @Description Returns transactions for the authenticated user's family with pagination. With format (or Accept: text/csv, xlsx, application/pdf) all matching transactions are streamed as a file.
 // @Tags transactions
 // @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
 // @Security BearerAuth
 // @Param type query string false "Filter by type: income or expense"
 // @Param account_id query string false "Filter by account ID"
 // @Param month query string false "Filter by month (YYYY-MM)"
 // @Param page query int false "Page number (default: 1)"
 // @Param per_page query int false "Items per page (default: 50, max: 100)"
+// @Param format query string false "Export format: csv, xlsx or pdf (default: JSON)"
+// @Param locale query string false "Number format of exports: en, sr or ru (default: Accept-Language)"
 // @Success 200 {object} dto.SuccessResponse{data=dto.TransactionListResponse}
+// @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Router /api/v1/transactions [get]
 func (h *TransactionHandler) List(w http.ResponseWriter, r *http.Request) {
@@ -101,6 +112,22 @@ func (h *TransactionHandler) List(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	format, exporting, err := exportFormat(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_FORMAT", err.Error())
+		return
+	}
+
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	if exporting {
+		h.exportTransactions(w, r, format, filter, month, baseCurrency)
+		return
+	}
+
 	// Get transactions
 	transactions, total, err := h.transactionRepo.ListFiltered(r.Context(), filter)
 	if err != nil {
@@ -120,7 +147,7 @@ func (h *TransactionHandler) List(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for i, t := range transactions {
-		response.Transactions[i] = h.mapTransaction(r.Context(), t)
+		response.Transactions[i] = mapTransaction(t, baseCurrency)
 	}
 
 	writeSuccess(w, http.StatusOK, response)
@@ -158,7 +185,7 @@ func (h *TransactionHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Struct validation
-	if err := h.validate.Struct(req); err != nil {
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
 		writeValidationError(w, formatValidationErrors(err))
 		return
 	}
@@ -168,6 +195,10 @@ func (h *TransactionHandler) Create(w http.ResponseWriter, r *http.Request) {
 		writeValidationError(w, errors)
 		return
 	}
+	if errors := validateMinorUnits(r.Context(), h.currencyRepo, "amount", req.Amount, req.Currency); len(errors) > 0 {
+		writeValidationError(w, errors)
+		return
+	}
 
 	// Validate account belongs to family
 	account, err := h.accountRepo.GetByID(r.Context(), req.AccountID)
@@ -192,6 +223,12 @@ func (h *TransactionHandler) Create(w http.ResponseWriter, r *http.Request) {
 		})
 		return
 	}
+	if category.IsSystem {
+		writeValidationError(w, []dto.ValidationError{
+			{Field: "category_id", Message: "System categories cannot be assigned manually"},
+		})
+		return
+	}
 
 	// Parse date
 	date, _ := time.Parse("2006-01-02", req.Date)
@@ -213,7 +250,12 @@ func (h *TransactionHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeSuccess(w, http.StatusCreated, h.mapTransaction(r.Context(), transaction))
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	writeSuccess(w, http.StatusCreated, h.mapSavedTransaction(r.Context(), transaction, baseCurrency))
 }
 
 // Get godoc
@@ -240,7 +282,7 @@ func (h *TransactionHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	transaction, err := h.transactionRepo.GetByID(r.Context(), transactionID)
+	transaction, err := h.transactionRepo.GetByIDWithDetails(r.Context(), transactionID)
 	if err != nil {
 		writeError(w, http.StatusNotFound, "NOT_FOUND", "Transaction not found")
 		return
@@ -251,7 +293,12 @@ func (h *TransactionHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeSuccess(w, http.StatusOK, h.mapTransaction(r.Context(), transaction))
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, mapTransaction(transaction, baseCurrency))
 }
 
 // Update godoc
@@ -304,7 +351,7 @@ func (h *TransactionHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.validate.Struct(req); err != nil {
+	if err := h.validate.StructCtx(r.Context(), req); err != nil {
 		writeValidationError(w, formatValidationErrors(err))
 		return
 	}
@@ -325,6 +372,12 @@ func (h *TransactionHandler) Update(w http.ResponseWriter, r *http.Request) {
 			})
 			return
 		}
+		if category.IsSystem {
+			writeValidationError(w, []dto.ValidationError{
+				{Field: "category_id", Message: "System categories cannot be assigned manually"},
+			})
+			return
+		}
 		categoryID = *req.CategoryID
 	}
 
@@ -338,6 +391,13 @@ func (h *TransactionHandler) Update(w http.ResponseWriter, r *http.Request) {
 		currency = *req.Currency
 	}
 
+	if req.Amount != nil || req.Currency != nil {
+		if errors := validateMinorUnits(r.Context(), h.currencyRepo, "amount", amount, currency); len(errors) > 0 {
+			writeValidationError(w, errors)
+			return
+		}
+	}
+
 	description := ""
 	if existing.Description.Valid {
 		description = existing.Description.String
@@ -366,7 +426,12 @@ func (h *TransactionHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeSuccess(w, http.StatusOK, h.mapTransaction(r.Context(), transaction))
+	baseCurrency, ok := familyBaseCurrency(w, r, h.familyRepo, familyID)
+	if !ok {
+		return
+	}
+
+	writeSuccess(w, http.StatusOK, h.mapSavedTransaction(r.Context(), transaction, baseCurrency))
 }
 
 // Delete godoc
@@ -420,16 +485,78 @@ func (h *TransactionHandler) Delete(w http.ResponseWriter, r *http.Request) {
 
 // --- Helper functions ---
 
-func (h *TransactionHandler) mapTransaction(ctx context.Context, t sqlc.Transaction) dto.TransactionResponse {
+// exportTransactions streams all transactions matching the filter as a file
+func (h *TransactionHandler) exportTransactions(w http.ResponseWriter, r *http.Request, format export.Format, filter repository.TransactionFilter, month, baseCurrency string) {
+	filename := "transactions"
+	doc := export.Document{Title: "Transactions"}
+	if filter.StartDate != nil && filter.EndDate != nil {
+		doc.Period = &dto.ReportPeriod{
+			StartDate: filter.StartDate.Format("2006-01-02"),
+			EndDate:   filter.EndDate.Format("2006-01-02"),
+		}
+		filename += "-" + month
+	}
+	if filter.Type != nil {
+		doc.Meta = append(doc.Meta, "Type: "+*filter.Type)
+	}
+
+	ew, err := startExport(w, r, format, filename, doc)
+	if err == nil {
+		err = ew.Section("", []export.Column{
+			{Title: "Date"},
+			{Title: "Type"},
+			{Title: "Category"},
+			{Title: "Account"},
+			{Title: "Description"},
+			{Title: "Amount", Kind: export.KindMoney},
+			{Title: "Currency"},
+			{Title: fmt.Sprintf("Amount (%s)", baseCurrency), Kind: export.KindMoney},
+			{Title: "Created by"},
+		})
+	}
+	if err == nil {
+		err = h.transactionRepo.EachFiltered(r.Context(), filter, func(t repository.TransactionWithDetails) error {
+			return ew.Row(
+				t.TransactionDate.Time.Format("2006-01-02"),
+				t.Type,
+				t.CategoryName,
+				t.AccountName,
+				t.Description.String,
+				t.Amount,
+				t.Currency,
+				t.AmountBase,
+				t.CreatedByName,
+			)
+		})
+	}
+	if err == nil {
+		err = ew.Close()
+	}
+	if err != nil {
+		logExportError(filename, err)
+	}
+}
+
+// mapSavedTransaction maps a created or updated transaction, loading related names in one query
+func (h *TransactionHandler) mapSavedTransaction(ctx context.Context, t sqlc.Transaction, baseCurrency string) dto.TransactionResponse {
+	details, err := h.transactionRepo.GetByIDWithDetails(ctx, t.ID)
+	if err != nil {
+		details = repository.TransactionWithDetails{Transaction: t}
+	}
+	return mapTransaction(details, baseCurrency)
+}
+
+func mapTransaction(t repository.TransactionWithDetails, baseCurrency string) dto.TransactionResponse {
 	response := dto.TransactionResponse{
 		ID:           t.ID,
 		Type:         t.Type,
 		Amount:       t.Amount,
 		Currency:     t.Currency,
 		AmountBase:   t.AmountBase,
-		BaseCurrency: "RSD", // MVP: always RSD
+		BaseCurrency: baseCurrency,
 		Date:         t.TransactionDate.Time.Format("2006-01-02"),
 		CreatedAt:    t.CreatedAt,
+		CreatedBy:    t.CreatedByName,
 	}
 
 	// Get description
@@ -437,28 +564,22 @@ func (h *TransactionHandler) mapTransaction(ctx context.Context, t sqlc.Transact
 		response.Description = &t.Description.String
 	}
 
-	// Get category info
-	if category, err := h.categoryRepo.GetByID(ctx, t.CategoryID); err == nil {
+	// Category and account names are joined by the query
+	if t.CategoryName != "" {
 		response.Category = dto.TransactionCategoryInfo{
-			ID:   category.ID,
-			Name: category.Name,
-			Type: category.Type,
+			ID:   t.CategoryID,
+			Name: t.CategoryName,
+			Type: t.CategoryType,
 		}
 	}
 
-	// Get account info
-	if account, err := h.accountRepo.GetByID(ctx, t.AccountID); err == nil {
+	if t.AccountName != "" {
 		response.Account = dto.TransactionAccountInfo{
-			ID:   account.ID,
-			Name: account.Name,
-			Type: account.Type,
+			ID:   t.AccountID,
+			Name: t.AccountName,
+			Type: t.AccountType,
 		}
 	}
 
-	// Get creator name
-	if user, err := h.userRepo.GetByID(ctx, t.CreatedBy); err == nil {
-		response.CreatedBy = user.Name
-	}
-
 	return response
 }
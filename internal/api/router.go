@@ -38,20 +38,46 @@ func NewRouter(cfg *config.Config, db *pgxpool.Pool, repos *repository.Repositor
 	// --- Handlers ---
 	healthHandler := handlers.NewHealthHandler(db)
 	authHandler := handlers.NewAuthHandler(repos.Users, jwtService)
-	accountHandler := handlers.NewAccountHandler(repos.Accounts)
-	categoryHandler := handlers.NewCategoryHandler(repos.Categories)
+	accountHandler := handlers.NewAccountHandler(repos.Accounts, repos.Transactions, repos.Currencies)
+	interestHandler := handlers.NewInterestHandler(
+		repos.Interest,
+		repos.Accounts,
+		repos.Categories,
+		repos.Currencies,
+	)
+	categoryHandler := handlers.NewCategoryHandler(repos.Categories, repos.Transactions, repos.Families)
+	categoryTemplateHandler := handlers.NewCategoryTemplateHandler(repos.Categories)
 	transactionHandler := handlers.NewTransactionHandler(
 		repos.Transactions,
 		repos.Accounts,
 		repos.Categories,
-		repos.Users,
+		repos.Families,
+		repos.Currencies,
 	)
 	reportHandler := handlers.NewReportHandler(
 		repos.Transactions,
 		repos.Accounts,
 		repos.Categories,
+		repos.Budgets,
+		repos.Interest,
+		repos.Users,
+		repos.Families,
+		repos.ExchangeRates,
+		repos.Currencies,
 	)
-	currencyHandler := handlers.NewCurrencyHandler(repos.ExchangeRates)
+	customReportHandler := handlers.NewCustomReportHandler(repos.CustomReports, repos.Families)
+	insightHandler := handlers.NewInsightHandler(repos.Transactions, repos.Categories, repos.Families)
+	budgetHandler := handlers.NewBudgetHandler(repos.Budgets, repos.Categories)
+	alertHandler := handlers.NewAlertHandler(repos.Alerts, repos.Categories, repos.Families)
+	currencyHandler := handlers.NewCurrencyHandler(repos.ExchangeRates, repos.Families, repos.Currencies)
+	goalHandler := handlers.NewGoalHandler(
+		repos.SavingsGoals,
+		repos.Accounts,
+		repos.ExchangeRates,
+		repos.Families,
+		repos.Currencies,
+	)
+	familyHandler := handlers.NewFamilyHandler(repos.Families, repos.Currencies)
 
 	// --- Public Routes (no auth required) ---
 	r.Get("/health", healthHandler.Health)
@@ -76,15 +102,30 @@ func NewRouter(cfg *config.Config, db *pgxpool.Pool, repos *repository.Repositor
 				r.Patch("/{id}", accountHandler.Update)
 				r.Delete("/{id}", accountHandler.Delete)
 				r.Get("/{id}/balance", accountHandler.GetBalance)
+				r.Post("/{id}/adjust-balance", accountHandler.AdjustBalance)
+				r.Get("/{id}/interest", interestHandler.Get)
+				r.Put("/{id}/interest", interestHandler.Put)
+				r.Delete("/{id}/interest", interestHandler.Delete)
+				r.Get("/{id}/interest/preview", interestHandler.Preview)
 			})
 
 			// Categories
 			r.Route("/categories", func(r chi.Router) {
 				r.Get("/", categoryHandler.List)
 				r.Post("/", categoryHandler.Create)
+				r.Get("/tree", categoryHandler.Tree)
+				r.Post("/reorder", categoryHandler.Reorder)
 				r.Get("/{id}", categoryHandler.Get)
 				r.Patch("/{id}", categoryHandler.Update)
 				r.Delete("/{id}", categoryHandler.Delete)
+				r.Post("/{id}/merge", categoryHandler.Merge)
+				r.Post("/{id}/move", categoryHandler.Move)
+			})
+
+			// Category templates
+			r.Route("/category-templates", func(r chi.Router) {
+				r.Get("/", categoryTemplateHandler.List)
+				r.Post("/{key}/apply", categoryTemplateHandler.Apply)
 			})
 
 			// Transactions
@@ -96,17 +137,74 @@ func NewRouter(cfg *config.Config, db *pgxpool.Pool, repos *repository.Repositor
 				r.Delete("/{id}", transactionHandler.Delete)
 			})
 
+			// Savings goals
+			r.Route("/goals", func(r chi.Router) {
+				r.Get("/", goalHandler.List)
+				r.Post("/", goalHandler.Create)
+				r.Get("/{id}", goalHandler.Get)
+				r.Patch("/{id}", goalHandler.Update)
+				r.Delete("/{id}", goalHandler.Delete)
+			})
+
+			// Budgets
+			r.Route("/budgets", func(r chi.Router) {
+				r.Get("/", budgetHandler.List)
+				r.Post("/", budgetHandler.Create)
+				r.Get("/assignments", budgetHandler.ListAssignments)
+				r.Put("/assignments", budgetHandler.Assign)
+				r.Get("/{id}", budgetHandler.Get)
+				r.Patch("/{id}", budgetHandler.Update)
+				r.Delete("/{id}", budgetHandler.Delete)
+			})
+
+			// Alerts
+			r.Route("/alerts", func(r chi.Router) {
+				r.Get("/", alertHandler.List)
+				r.Post("/read-all", alertHandler.MarkAllRead)
+				r.Post("/{id}/read", alertHandler.MarkRead)
+				r.Post("/{id}/acknowledge", alertHandler.Acknowledge)
+				r.Get("/thresholds", alertHandler.ListThresholds)
+				r.Post("/thresholds", alertHandler.CreateThreshold)
+				r.Patch("/thresholds/{id}", alertHandler.UpdateThreshold)
+				r.Delete("/thresholds/{id}", alertHandler.DeleteThreshold)
+			})
+
 			// Reports
 			r.Route("/reports", func(r chi.Router) {
 				r.Get("/spending-by-category", reportHandler.SpendingByCategory)
 				r.Get("/monthly-summary", reportHandler.MonthlySummary)
+				r.Get("/budget-vs-actual", reportHandler.BudgetVsActual)
+				r.Get("/trends", reportHandler.Trends)
+				r.Get("/comparison", reportHandler.Comparison)
+				r.Get("/forecast", reportHandler.Forecast)
+				r.Get("/members", reportHandler.Members)
+				r.Get("/daily", reportHandler.Daily)
+				r.Get("/annual", reportHandler.Annual)
+
+				// Custom reports
+				r.Post("/custom", customReportHandler.Run)
+				r.Get("/custom/options", customReportHandler.Options)
+				r.Get("/saved", customReportHandler.ListSaved)
+				r.Post("/saved", customReportHandler.CreateSaved)
+				r.Get("/saved/{id}", customReportHandler.GetSaved)
+				r.Patch("/saved/{id}", customReportHandler.UpdateSaved)
+				r.Delete("/saved/{id}", customReportHandler.DeleteSaved)
+				r.Get("/saved/{id}/run", customReportHandler.RunSaved)
 			})
 
+			// Insights
+			r.Get("/insights", insightHandler.List)
+
 			// Currencies
 			r.Route("/currencies", func(r chi.Router) {
+				r.Get("/", currencyHandler.List)
 				r.Get("/rates", currencyHandler.GetRates)
 				r.Get("/convert", currencyHandler.Convert)
 			})
+
+			// Family
+			r.Get("/family", familyHandler.Get)
+			r.Patch("/family", familyHandler.Update)
 		})
 	})
 
@@ -0,0 +1,168 @@
+package categorytemplates
+
+import "sort"
+
+// Default is the template applied to every new family
+const Default = "serbian_household_en"
+
+// Category is a category of a template. Children inherit the parent type.
+type Category struct {
+	Name        string
+	Type        string // income, expense
+	Description string
+	Children    []Category
+}
+
+// Template is a named set of categories that can be applied to a family
+type Template struct {
+	Key         string
+	Name        string
+	Description string
+	Language    string // en, ru, sr
+	Categories  []Category
+}
+
+// Count returns total number of categories in the template, including children
+func (t Template) Count() int {
+	var count func(categories []Category) int
+	count = func(categories []Category) int {
+		n := len(categories)
+		for _, c := range categories {
+			n += count(c.Children)
+		}
+		return n
+	}
+	return count(t.Categories)
+}
+
+// Get returns a built-in template by key
+func Get(key string) (Template, bool) {
+	t, ok := templates[key]
+	return t, ok
+}
+
+// All returns all built-in templates sorted by key
+func All() []Template {
+	result := make([]Template, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, t)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+var templates = map[string]Template{
+	"serbian_household_en": {
+		Key:         "serbian_household_en",
+		Name:        "Serbian household",
+		Description: "Typical expenses and income of a household in Serbia",
+		Language:    "en",
+		Categories: []Category{
+			{Name: "Food & Dining", Type: "expense", Description: "All food-related expenses", Children: []Category{
+				{Name: "Groceries", Type: "expense", Description: "Supermarket shopping"},
+				{Name: "Restaurants", Type: "expense", Description: "Dining out"},
+				{Name: "Food Delivery", Type: "expense", Description: "Delivery services"},
+			}},
+			{Name: "Transport", Type: "expense", Description: "Transportation expenses", Children: []Category{
+				{Name: "Gas", Type: "expense", Description: "Fuel for car"},
+				{Name: "Public Transport", Type: "expense", Description: "Bus, taxi, etc"},
+				{Name: "Parking", Type: "expense", Description: "Parking fees"},
+				{Name: "Car Registration", Type: "expense", Description: "Registration, insurance, technical inspection"},
+			}},
+			{Name: "Housing", Type: "expense", Description: "Home-related expenses", Children: []Category{
+				{Name: "Rent", Type: "expense", Description: "Monthly rent"},
+				{Name: "Utilities", Type: "expense", Description: "Infostan, electricity (EPS), heating"},
+				{Name: "Internet & Phone", Type: "expense", Description: "Communication services"},
+			}},
+			{Name: "Entertainment", Type: "expense", Description: "Movies, games, hobbies"},
+			{Name: "Shopping", Type: "expense", Description: "Clothes, electronics, etc"},
+			{Name: "Health & Fitness", Type: "expense", Description: "Medical, pharmacy, gym"},
+			{Name: "Education", Type: "expense", Description: "Courses, books, training"},
+			{Name: "Salary", Type: "income", Description: "Monthly salary"},
+			{Name: "Freelance", Type: "income", Description: "Freelance projects"},
+			{Name: "Investments", Type: "income", Description: "Investment returns"},
+			{Name: "Other Income", Type: "income", Description: "Misc income"},
+		},
+	},
+	"serbian_household_ru": {
+		Key:         "serbian_household_ru",
+		Name:        "Сербское домохозяйство",
+		Description: "Типичные расходы и доходы семьи в Сербии",
+		Language:    "ru",
+		Categories: []Category{
+			{Name: "Еда", Type: "expense", Description: "Все расходы на еду", Children: []Category{
+				{Name: "Продукты", Type: "expense", Description: "Покупки в супермаркете"},
+				{Name: "Рестораны", Type: "expense", Description: "Кафе и рестораны"},
+				{Name: "Доставка еды", Type: "expense", Description: "Сервисы доставки"},
+			}},
+			{Name: "Транспорт", Type: "expense", Description: "Расходы на транспорт", Children: []Category{
+				{Name: "Топливо", Type: "expense", Description: "Бензин, дизель"},
+				{Name: "Общественный транспорт", Type: "expense", Description: "Автобус, такси и т.д."},
+				{Name: "Парковка", Type: "expense", Description: "Оплата парковки"},
+				{Name: "Регистрация автомобиля", Type: "expense", Description: "Регистрация, страховка, техосмотр"},
+			}},
+			{Name: "Жильё", Type: "expense", Description: "Расходы на жильё", Children: []Category{
+				{Name: "Аренда", Type: "expense", Description: "Ежемесячная аренда"},
+				{Name: "Коммунальные услуги", Type: "expense", Description: "Infostan, электричество (EPS), отопление"},
+				{Name: "Интернет и связь", Type: "expense", Description: "Услуги связи"},
+			}},
+			{Name: "Развлечения", Type: "expense", Description: "Кино, игры, хобби"},
+			{Name: "Покупки", Type: "expense", Description: "Одежда, электроника и т.д."},
+			{Name: "Здоровье и спорт", Type: "expense", Description: "Врачи, аптека, спортзал"},
+			{Name: "Образование", Type: "expense", Description: "Курсы, книги, обучение"},
+			{Name: "Зарплата", Type: "income", Description: "Ежемесячная зарплата"},
+			{Name: "Фриланс", Type: "income", Description: "Проектная работа"},
+			{Name: "Инвестиции", Type: "income", Description: "Доход от инвестиций"},
+			{Name: "Прочие доходы", Type: "income", Description: "Разные доходы"},
+		},
+	},
+	"serbian_household_sr": {
+		Key:         "serbian_household_sr",
+		Name:        "Srpsko domaćinstvo",
+		Description: "Uobičajeni troškovi i prihodi domaćinstva u Srbiji",
+		Language:    "sr",
+		Categories: []Category{
+			{Name: "Hrana", Type: "expense", Description: "Svi troškovi za hranu", Children: []Category{
+				{Name: "Namirnice", Type: "expense", Description: "Kupovina u supermarketu"},
+				{Name: "Restorani", Type: "expense", Description: "Izlasci i restorani"},
+				{Name: "Dostava hrane", Type: "expense", Description: "Usluge dostave"},
+			}},
+			{Name: "Prevoz", Type: "expense", Description: "Troškovi prevoza", Children: []Category{
+				{Name: "Gorivo", Type: "expense", Description: "Benzin, dizel"},
+				{Name: "Javni prevoz", Type: "expense", Description: "Autobus, taksi itd."},
+				{Name: "Parking", Type: "expense", Description: "Naknade za parking"},
+				{Name: "Registracija vozila", Type: "expense", Description: "Registracija, osiguranje, tehnički pregled"},
+			}},
+			{Name: "Stanovanje", Type: "expense", Description: "Troškovi stanovanja", Children: []Category{
+				{Name: "Kirija", Type: "expense", Description: "Mesečna kirija"},
+				{Name: "Komunalije", Type: "expense", Description: "Infostan, struja (EPS), grejanje"},
+				{Name: "Internet i telefon", Type: "expense", Description: "Usluge komunikacije"},
+			}},
+			{Name: "Zabava", Type: "expense", Description: "Bioskop, igre, hobiji"},
+			{Name: "Kupovina", Type: "expense", Description: "Odeća, elektronika itd."},
+			{Name: "Zdravlje i sport", Type: "expense", Description: "Lekar, apoteka, teretana"},
+			{Name: "Obrazovanje", Type: "expense", Description: "Kursevi, knjige, obuke"},
+			{Name: "Plata", Type: "income", Description: "Mesečna plata"},
+			{Name: "Honorarni rad", Type: "income", Description: "Honorarni projekti"},
+			{Name: "Investicije", Type: "income", Description: "Prinosi od investicija"},
+			{Name: "Ostali prihodi", Type: "income", Description: "Razni prihodi"},
+		},
+	},
+	"minimalist_en": {
+		Key:         "minimalist_en",
+		Name:        "Minimalist",
+		Description: "A few broad categories without subcategories",
+		Language:    "en",
+		Categories: []Category{
+			{Name: "Food", Type: "expense", Description: "Groceries and eating out"},
+			{Name: "Housing", Type: "expense", Description: "Rent, utilities, internet"},
+			{Name: "Transport", Type: "expense", Description: "Fuel, public transport, car"},
+			{Name: "Personal", Type: "expense", Description: "Health, shopping, entertainment"},
+			{Name: "Other Expenses", Type: "expense", Description: "Everything else"},
+			{Name: "Salary", Type: "income", Description: "Monthly salary"},
+			{Name: "Other Income", Type: "income", Description: "Misc income"},
+		},
+	},
+}
@@ -10,9 +10,12 @@ import (
 )
 
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	JWT      JWTConfig
+	Database      DatabaseConfig
+	Server        ServerConfig
+	JWT           JWTConfig
+	Jobs          JobsConfig
+	Categories    CategoriesConfig
+	ExchangeRates ExchangeRatesConfig
 }
 
 type DatabaseConfig struct {
@@ -22,6 +25,9 @@ type DatabaseConfig struct {
 	Password string
 	DBName   string
 	SSLMode  string
+
+	// CountQueries enables database.WithQueryCounter; set by query count tests only
+	CountQueries bool
 }
 
 type ServerConfig struct {
@@ -37,6 +43,22 @@ type JWTConfig struct {
 	ExpirationHours int
 }
 
+type JobsConfig struct {
+	Enabled         bool
+	IntervalMinutes int
+}
+
+type CategoriesConfig struct {
+	MaxDepth int // maximum number of levels in category hierarchy
+}
+
+type ExchangeRatesConfig struct {
+	Providers      []string // provider names in fallback order: nbs, ecb
+	NBSURL         string   // NBS exchange rate list XML, nbs provider is skipped if empty
+	ECBURL         string   // ECB euro reference rates XML
+	TimeoutSeconds int      // HTTP timeout of a provider request
+}
+
 func (d DatabaseConfig) DSN() string {
 	return fmt.Sprintf(
 		"postgres://%s:%s@%s:%d/%s?sslmode=%s",
@@ -63,6 +85,34 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("invalid JWT_EXPIRATION_HOURS: %w", err)
 	}
 
+	jobsInterval, err := strconv.Atoi(getEnv("JOBS_INTERVAL_MINUTES", "60"))
+	if err != nil || jobsInterval < 1 {
+		return nil, fmt.Errorf("invalid JOBS_INTERVAL_MINUTES: %q", getEnv("JOBS_INTERVAL_MINUTES", "60"))
+	}
+
+	categoryMaxDepth, err := strconv.Atoi(getEnv("CATEGORY_MAX_DEPTH", "5"))
+	if err != nil || categoryMaxDepth < 1 {
+		return nil, fmt.Errorf("invalid CATEGORY_MAX_DEPTH: %q", getEnv("CATEGORY_MAX_DEPTH", "5"))
+	}
+
+	rateTimeout, err := strconv.Atoi(getEnv("EXCHANGE_RATE_TIMEOUT_SECONDS", "15"))
+	if err != nil || rateTimeout < 1 {
+		return nil, fmt.Errorf("invalid EXCHANGE_RATE_TIMEOUT_SECONDS: %q", getEnv("EXCHANGE_RATE_TIMEOUT_SECONDS", "15"))
+	}
+
+	var rateProviders []string
+	for _, name := range strings.Split(getEnv("EXCHANGE_RATE_PROVIDERS", "nbs,ecb"), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "":
+			continue
+		case "nbs", "ecb":
+			rateProviders = append(rateProviders, name)
+		default:
+			return nil, fmt.Errorf("invalid EXCHANGE_RATE_PROVIDERS: unknown provider %q", name)
+		}
+	}
+
 	// Parse CORS origins
 	originsStr := getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:3000,http://localhost:5173")
 	origins := strings.Split(originsStr, ",")
@@ -90,6 +140,19 @@ func Load() (*Config, error) {
 			Secret:          getEnv("JWT_SECRET", ""),
 			ExpirationHours: jwtExpiration,
 		},
+		Jobs: JobsConfig{
+			Enabled:         getEnv("JOBS_ENABLED", "true") == "true",
+			IntervalMinutes: jobsInterval,
+		},
+		Categories: CategoriesConfig{
+			MaxDepth: categoryMaxDepth,
+		},
+		ExchangeRates: ExchangeRatesConfig{
+			Providers:      rateProviders,
+			NBSURL:         getEnv("NBS_RATES_URL", ""),
+			ECBURL:         getEnv("ECB_RATES_URL", "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"),
+			TimeoutSeconds: rateTimeout,
+		},
 	}, nil
 }
 
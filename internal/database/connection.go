@@ -31,6 +31,11 @@ func New(ctx context.Context, cfg config.DatabaseConfig) (*DB, error) {
 	poolConfig.MaxConnIdleTime = 30 * time.Minute
 	poolConfig.HealthCheckPeriod = time.Minute
 
+	// Count queries of contexts from WithQueryCounter (tests and benchmarks)
+	if cfg.CountQueries {
+		poolConfig.ConnConfig.Tracer = queryCountTracer{}
+	}
+
 	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection pool: %w", err)
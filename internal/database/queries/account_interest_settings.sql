@@ -0,0 +1,39 @@
+-- name: GetAccountInterestSettings :one
+SELECT * FROM account_interest_settings
+WHERE account_id = $1;
+
+-- name: ListInterestSettingsForPosting :many
+SELECT ais.* FROM account_interest_settings ais
+                      JOIN accounts a ON a.id = ais.account_id
+WHERE a.is_active = true
+ORDER BY ais.created_at;
+
+-- name: UpsertAccountInterestSettings :one
+INSERT INTO account_interest_settings (
+    id, account_id, family_id, annual_rate, compounding, payout_day, withholding_tax_rate, income_category_id, created_by
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7, $8, $9
+         )
+ON CONFLICT (account_id) DO UPDATE
+    SET annual_rate = EXCLUDED.annual_rate,
+        compounding = EXCLUDED.compounding,
+        payout_day = EXCLUDED.payout_day,
+        withholding_tax_rate = EXCLUDED.withholding_tax_rate,
+        income_category_id = EXCLUDED.income_category_id,
+        updated_at = NOW()
+RETURNING *;
+
+-- name: UpdateInterestLastPostedDate :execrows
+UPDATE account_interest_settings
+SET last_posted_date = $2
+WHERE id = $1
+  AND (last_posted_date IS NULL OR last_posted_date < $2);
+
+-- name: DeleteAccountInterestSettings :exec
+DELETE FROM account_interest_settings
+WHERE account_id = $1;
+
+-- name: ReassignInterestIncomeCategory :exec
+UPDATE account_interest_settings
+SET income_category_id = sqlc.arg(target_category_id), updated_at = NOW()
+WHERE income_category_id = sqlc.arg(source_category_id);
@@ -52,4 +52,64 @@ SELECT
     COALESCE(SUM(current_balance), 0)::numeric as total_balance,
     COUNT(*) as account_count
 FROM accounts
-WHERE family_id = $1 AND is_active = true;
\ No newline at end of file
+WHERE family_id = $1 AND is_active = true;
+
+-- name: UpdateAccountInitialBalance :one
+UPDATE accounts
+SET
+    initial_balance = $2,
+    current_balance = $2 + COALESCE((
+        SELECT SUM(t.amount * CASE WHEN t.type = 'income' THEN 1 ELSE -1 END)
+        FROM transactions t
+        WHERE t.account_id = accounts.id
+          AND t.is_active = true
+    ), 0),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetAccountBalanceAsOf :one
+SELECT
+    (a.initial_balance + COALESCE(SUM(
+        t.amount * CASE WHEN t.type = 'income' THEN 1 ELSE -1 END
+    ), 0))::numeric as balance
+FROM accounts a
+         LEFT JOIN transactions t
+                   ON t.account_id = a.id
+                       AND t.is_active = true
+                       AND t.transaction_date <= $2
+WHERE a.id = $1
+GROUP BY a.id, a.initial_balance, a.currency;
+
+-- name: ListAccountBalancesBetween :many
+SELECT
+    a.id as account_id,
+    (a.initial_balance + COALESCE(SUM(
+        t.amount * CASE WHEN t.type = 'income' THEN 1 ELSE -1 END
+    ) FILTER (WHERE t.transaction_date < sqlc.arg(start_date)), 0))::numeric as opening_balance,
+    (a.initial_balance + COALESCE(SUM(
+        t.amount * CASE WHEN t.type = 'income' THEN 1 ELSE -1 END
+    ), 0))::numeric as closing_balance
+FROM accounts a
+         LEFT JOIN transactions t
+                   ON t.account_id = a.id
+                       AND t.is_active = true
+                       AND t.transaction_date <= sqlc.arg(end_date)
+WHERE a.family_id = sqlc.arg(family_id)
+GROUP BY a.id, a.initial_balance;
+
+-- name: GetAccountsNetFlow :one
+SELECT
+    COALESCE(SUM(
+        CASE
+            WHEN type = 'income' THEN amount_base
+            WHEN type = 'expense' THEN -amount_base
+            ELSE 0
+            END
+    ), 0)::numeric as net_flow
+FROM transactions
+WHERE account_id = ANY(sqlc.arg(account_ids)::uuid[])
+  AND transaction_date >= sqlc.arg(start_date)
+  AND transaction_date <= sqlc.arg(end_date)
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true);
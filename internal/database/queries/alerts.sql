@@ -0,0 +1,164 @@
+-- name: GetAlertThreshold :one
+SELECT * FROM alert_thresholds
+WHERE id = $1 AND is_active = true;
+
+-- name: ListAlertThresholdsByFamily :many
+SELECT * FROM alert_thresholds
+WHERE family_id = $1 AND is_active = true
+ORDER BY category_id NULLS FIRST, created_at;
+
+-- name: ListApplicableAlertThresholds :many
+-- Thresholds of the category, its ancestors and overall family spending
+WITH RECURSIVE chain AS (
+    SELECT c.id, c.parent_id FROM categories c WHERE c.id = sqlc.arg(category_id)
+    UNION ALL
+    SELECT c.id, c.parent_id FROM categories c
+    JOIN chain ON c.id = chain.parent_id
+)
+SELECT t.* FROM alert_thresholds t
+WHERE t.family_id = sqlc.arg(family_id)
+  AND t.is_active = true
+  AND (t.category_id IS NULL OR t.category_id IN (SELECT id FROM chain));
+
+-- name: CreateAlertThreshold :one
+INSERT INTO alert_thresholds (
+    id, family_id, category_id, limit_amount, percentages, created_by
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         )
+RETURNING *;
+
+-- name: UpdateAlertThreshold :one
+UPDATE alert_thresholds
+SET limit_amount = $2,
+    percentages = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteAlertThreshold :exec
+UPDATE alert_thresholds
+SET is_active = false, updated_at = NOW()
+WHERE id = $1;
+
+-- name: GetCategorySubtreeExpenseTotal :one
+-- Expenses of the category and all its subcategories in a period
+WITH RECURSIVE subtree AS (
+    SELECT c.id FROM categories c WHERE c.id = sqlc.arg(category_id)
+    UNION ALL
+    SELECT c.id FROM categories c
+    JOIN subtree ON c.parent_id = subtree.id
+)
+SELECT COALESCE(SUM(amount_base), 0)::numeric AS total
+FROM transactions
+WHERE category_id IN (SELECT id FROM subtree)
+  AND type = 'expense'
+  AND transaction_date >= sqlc.arg(start_date)
+  AND transaction_date <= sqlc.arg(end_date)
+  AND is_active = true;
+
+-- name: GetFamilyExpenseTotal :one
+SELECT COALESCE(SUM(amount_base), 0)::numeric AS total
+FROM transactions
+WHERE family_id = $1
+  AND type = 'expense'
+  AND transaction_date >= $2
+  AND transaction_date <= $3
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true);
+
+-- name: GetEffectiveBudgetAmount :one
+-- Budget of the month, otherwise the default budget
+SELECT amount FROM budgets
+WHERE category_id = $1
+  AND (month = $2 OR month IS NULL)
+  AND is_active = true
+ORDER BY month NULLS LAST
+LIMIT 1;
+
+-- name: CreateAlert :execrows
+INSERT INTO alerts (
+    id, family_id, threshold_id, category_id, period, percentage, limit_amount, spent_amount
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7, $8
+         )
+ON CONFLICT (threshold_id, period, percentage) DO NOTHING;
+
+-- name: GetAlert :one
+SELECT * FROM alerts
+WHERE id = $1;
+
+-- name: ListAlertsForUser :many
+SELECT
+    a.id, a.family_id, a.threshold_id, a.category_id, a.period, a.percentage,
+    a.limit_amount, a.spent_amount, a.created_at,
+    r.read_at, r.acknowledged_at
+FROM alerts a
+LEFT JOIN alert_reads r ON r.alert_id = a.id AND r.user_id = sqlc.arg(user_id)
+WHERE a.family_id = sqlc.arg(family_id)
+  AND (NOT sqlc.arg(unread_only)::boolean OR r.read_at IS NULL)
+ORDER BY a.created_at DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountUnreadAlerts :one
+SELECT COUNT(*) AS total
+FROM alerts a
+WHERE a.family_id = $1
+  AND NOT EXISTS (
+    SELECT 1 FROM alert_reads r WHERE r.alert_id = a.id AND r.user_id = $2
+);
+
+-- name: MarkAlertRead :exec
+INSERT INTO alert_reads (alert_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT (alert_id, user_id) DO NOTHING;
+
+-- name: AcknowledgeAlert :exec
+INSERT INTO alert_reads (alert_id, user_id, acknowledged_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (alert_id, user_id) DO UPDATE
+    SET acknowledged_at = COALESCE(alert_reads.acknowledged_at, NOW());
+
+-- name: MarkAllAlertsRead :execrows
+INSERT INTO alert_reads (alert_id, user_id)
+SELECT a.id, sqlc.arg(user_id)::uuid FROM alerts a
+WHERE a.family_id = sqlc.arg(family_id)
+ON CONFLICT (alert_id, user_id) DO NOTHING;
+
+-- name: DeactivateMergedAlertThreshold :exec
+-- Threshold of source is dropped if target already has its own
+UPDATE alert_thresholds
+SET is_active = false, updated_at = NOW()
+WHERE category_id = sqlc.arg(source_category_id)
+  AND is_active = true
+  AND EXISTS (
+    SELECT 1 FROM alert_thresholds t
+    WHERE t.category_id = sqlc.arg(target_category_id)
+      AND t.is_active = true
+);
+
+-- name: ReassignAlertThresholdsCategory :exec
+UPDATE alert_thresholds
+SET category_id = sqlc.arg(target_category_id), updated_at = NOW()
+WHERE category_id = sqlc.arg(source_category_id)
+  AND is_active = true;
+
+-- name: ReassignAlertsCategory :exec
+UPDATE alerts
+SET category_id = sqlc.arg(target_category_id)
+WHERE category_id = sqlc.arg(source_category_id);
+
+-- name: ConvertAlertThresholdLimits :execrows
+-- Multiplies fixed threshold limits by rate when the family base currency changes
+UPDATE alert_thresholds
+SET limit_amount = GREATEST(ROUND(limit_amount * sqlc.arg(rate)::numeric, sqlc.arg(minor_units)::int), power(10::numeric, -sqlc.arg(minor_units)::int)),
+    updated_at = NOW()
+WHERE family_id = sqlc.arg(family_id)
+  AND limit_amount IS NOT NULL;
+
+-- name: ConvertAlertAmounts :execrows
+-- Multiplies limit and spent amounts of raised alerts by rate when the family base currency changes
+UPDATE alerts
+SET limit_amount = ROUND(limit_amount * sqlc.arg(rate)::numeric, sqlc.arg(minor_units)::int),
+    spent_amount = ROUND(spent_amount * sqlc.arg(rate)::numeric, sqlc.arg(minor_units)::int)
+WHERE family_id = sqlc.arg(family_id);
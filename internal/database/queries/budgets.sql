@@ -0,0 +1,132 @@
+-- name: GetBudget :one
+SELECT * FROM budgets
+WHERE id = $1 AND is_active = true;
+
+-- name: GetBudgetByCategoryMonth :one
+SELECT * FROM budgets
+WHERE category_id = $1
+  AND month IS NOT DISTINCT FROM $2
+  AND is_active = true;
+
+-- name: ListBudgetsByFamily :many
+SELECT * FROM budgets
+WHERE family_id = $1 AND is_active = true
+ORDER BY month NULLS FIRST, created_at;
+
+-- name: ListBudgetsForMonth :many
+-- Effective budgets: budget of the month, otherwise the default budget
+SELECT DISTINCT ON (category_id) * FROM budgets
+WHERE family_id = $1
+  AND (month = $2 OR month IS NULL)
+  AND is_active = true
+ORDER BY category_id, month NULLS LAST;
+
+-- name: CreateBudget :one
+INSERT INTO budgets (
+    id, family_id, category_id, month, amount, rollover_mode, created_by
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7
+         )
+RETURNING *;
+
+-- name: UpdateBudget :one
+UPDATE budgets
+SET amount = $2,
+    rollover_mode = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteBudget :exec
+UPDATE budgets
+SET is_active = false, updated_at = NOW()
+WHERE id = $1;
+
+-- name: ListBudgetAssignmentsForMonth :many
+SELECT * FROM budget_assignments
+WHERE family_id = $1 AND month = $2
+ORDER BY created_at;
+
+-- name: ListBudgetAssignmentsUntil :many
+SELECT * FROM budget_assignments
+WHERE family_id = $1 AND month <= $2
+ORDER BY month, created_at;
+
+-- name: UpsertBudgetAssignment :one
+INSERT INTO budget_assignments (
+    id, family_id, category_id, month, amount, created_by
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         )
+ON CONFLICT (category_id, month) DO UPDATE
+    SET amount = EXCLUDED.amount,
+        updated_at = NOW()
+RETURNING *;
+
+-- name: GetBudgetAssignmentTotals :one
+-- Assigned in the month and in all previous months
+SELECT
+    COALESCE(SUM(amount) FILTER (WHERE month = sqlc.arg(month)), 0)::numeric AS month_total,
+    COALESCE(SUM(amount) FILTER (WHERE month < sqlc.arg(month)), 0)::numeric AS previous_total
+FROM budget_assignments
+WHERE family_id = sqlc.arg(family_id)
+  AND month <= sqlc.arg(month);
+
+-- name: AddMergedBudgetAmounts :exec
+-- Source budgets are added to active target budgets of the same month (or default)
+UPDATE budgets t
+SET amount = t.amount + s.amount, updated_at = NOW()
+FROM budgets s
+WHERE s.category_id = sqlc.arg(source_category_id)
+  AND s.is_active = true
+  AND t.category_id = sqlc.arg(target_category_id)
+  AND t.is_active = true
+  AND t.month IS NOT DISTINCT FROM s.month;
+
+-- name: DeactivateMergedBudgets :exec
+UPDATE budgets s
+SET is_active = false, updated_at = NOW()
+WHERE s.category_id = sqlc.arg(source_category_id)
+  AND s.is_active = true
+  AND EXISTS (
+    SELECT 1 FROM budgets t
+    WHERE t.category_id = sqlc.arg(target_category_id)
+      AND t.is_active = true
+      AND t.month IS NOT DISTINCT FROM s.month
+);
+
+-- name: ReassignBudgetsCategory :exec
+UPDATE budgets
+SET category_id = sqlc.arg(target_category_id), updated_at = NOW()
+WHERE category_id = sqlc.arg(source_category_id)
+  AND is_active = true;
+
+-- name: MergeBudgetAssignments :exec
+-- Assignments of source are added to target assignments of the same month
+INSERT INTO budget_assignments (
+    family_id, category_id, month, amount, created_by
+)
+SELECT family_id, sqlc.arg(target_category_id), month, amount, created_by
+FROM budget_assignments
+WHERE category_id = sqlc.arg(source_category_id)
+ON CONFLICT (category_id, month) DO UPDATE
+    SET amount = budget_assignments.amount + EXCLUDED.amount,
+        updated_at = NOW();
+
+-- name: DeleteBudgetAssignmentsByCategory :exec
+DELETE FROM budget_assignments
+WHERE category_id = $1;
+
+-- name: ConvertBudgetAmounts :execrows
+-- Multiplies budget amounts by rate when the family base currency changes
+UPDATE budgets
+SET amount = GREATEST(ROUND(amount * sqlc.arg(rate)::numeric, sqlc.arg(minor_units)::int), power(10::numeric, -sqlc.arg(minor_units)::int)),
+    updated_at = NOW()
+WHERE family_id = sqlc.arg(family_id);
+
+-- name: ConvertBudgetAssignmentAmounts :execrows
+-- Multiplies assigned amounts by rate when the family base currency changes
+UPDATE budget_assignments
+SET amount = ROUND(amount * sqlc.arg(rate)::numeric, sqlc.arg(minor_units)::int),
+    updated_at = NOW()
+WHERE family_id = sqlc.arg(family_id);
@@ -6,36 +6,40 @@ WHERE id = $1 AND is_active = true;
 SELECT * FROM categories
 WHERE id = $1;
 
+-- name: GetCategoryByName :one
+SELECT * FROM categories
+WHERE family_id = $1 AND name = $2 AND type = $3;
+
 -- name: ListCategoriesByFamily :many
 SELECT * FROM categories
 WHERE family_id = $1 AND is_active = true
-ORDER BY type, name;
+ORDER BY type, sort_order, name;
 
 -- name: ListAllCategoriesByFamily :many
 SELECT * FROM categories
 WHERE family_id = $1
-ORDER BY type, name;
+ORDER BY type, sort_order, name;
 
 -- name: ListCategoriesByType :many
 SELECT * FROM categories
 WHERE family_id = $1 AND type = $2 AND is_active = true
-ORDER BY name;
+ORDER BY sort_order, name;
 
 -- name: ListRootCategories :many
 SELECT * FROM categories
 WHERE family_id = $1 AND parent_id IS NULL AND is_active = true
-ORDER BY type, name;
+ORDER BY type, sort_order, name;
 
 -- name: ListChildCategories :many
 SELECT * FROM categories
 WHERE parent_id = $1 AND is_active = true
-ORDER BY name;
+ORDER BY sort_order, name;
 
 -- name: CreateCategory :one
 INSERT INTO categories (
-    id, family_id, name, type, parent_id
+    id, family_id, name, type, parent_id, description, icon, color, sort_order
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6, $7, $8, $9
          )
 RETURNING *;
 
@@ -45,6 +49,10 @@ SET
     name = $2,
     parent_id = $3,
     is_active = $4,
+    description = $5,
+    icon = $6,
+    color = $7,
+    sort_order = $8,
     updated_at = NOW()
 WHERE id = $1
 RETURNING *;
@@ -52,4 +60,73 @@ RETURNING *;
 -- name: DeleteCategory :exec
 UPDATE categories
 SET is_active = false, updated_at = NOW()
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: GetSystemCategory :one
+SELECT * FROM categories
+WHERE family_id = $1
+  AND type = $2
+  AND is_system = true
+ORDER BY created_at
+LIMIT 1;
+
+-- name: CreateSystemCategory :one
+INSERT INTO categories (
+    id, family_id, name, type, is_system
+) VALUES (
+             $1, $2, $3, $4, true
+         )
+ON CONFLICT (family_id, name, type) DO NOTHING
+RETURNING *;
+
+-- name: MoveChildCategories :execrows
+UPDATE categories
+SET parent_id = sqlc.arg(new_parent_id), updated_at = NOW()
+WHERE parent_id = sqlc.arg(old_parent_id);
+
+-- name: ListCategoryAncestorIDs :many
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id, 1 AS depth
+    FROM categories
+    WHERE id = $1
+    UNION ALL
+    SELECT c.id, c.parent_id, a.depth + 1
+    FROM categories c
+             JOIN ancestors a ON c.id = a.parent_id
+    WHERE a.depth < 100
+)
+SELECT id FROM ancestors
+ORDER BY depth;
+
+-- name: GetCategorySubtreeDepth :one
+WITH RECURSIVE subtree AS (
+    SELECT id, 1 AS depth
+    FROM categories
+    WHERE id = $1
+    UNION ALL
+    SELECT c.id, s.depth + 1
+    FROM categories c
+             JOIN subtree s ON c.parent_id = s.id
+    WHERE s.depth < 100
+)
+SELECT MAX(depth)::int AS depth FROM subtree;
+
+-- name: SetCategoryParent :one
+UPDATE categories
+SET parent_id = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: GetNextCategorySortOrder :one
+SELECT COALESCE(MAX(sort_order) + 1, 0)::int AS next_sort_order
+FROM categories
+WHERE family_id = $1
+  AND type = $2
+  AND parent_id IS NOT DISTINCT FROM $3
+  AND is_active = true;
+
+-- name: UpdateCategorySortOrders :execrows
+UPDATE categories c
+SET sort_order = o.position - 1, updated_at = NOW()
+FROM unnest(sqlc.arg(ids)::uuid[]) WITH ORDINALITY AS o(id, position)
+WHERE c.id = o.id;
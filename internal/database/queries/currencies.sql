@@ -0,0 +1,12 @@
+-- name: GetCurrency :one
+SELECT * FROM currencies
+WHERE code = $1;
+
+-- name: ListCurrencies :many
+SELECT * FROM currencies
+ORDER BY code;
+
+-- name: ListEnabledCurrencies :many
+SELECT * FROM currencies
+WHERE is_enabled = true
+ORDER BY code;
@@ -27,18 +27,18 @@ ORDER BY date DESC;
 
 -- name: CreateExchangeRate :one
 INSERT INTO exchange_rates (
-    id, from_currency, to_currency, rate, date
+    id, from_currency, to_currency, rate, date, source
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6
          )
 RETURNING *;
 
 -- name: UpsertExchangeRate :one
 INSERT INTO exchange_rates (
-    id, from_currency, to_currency, rate, date
+    id, from_currency, to_currency, rate, date, source
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6
          )
 ON CONFLICT (from_currency, to_currency, date)
-    DO UPDATE SET rate = EXCLUDED.rate
+    DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source
 RETURNING *;
\ No newline at end of file
@@ -31,4 +31,23 @@ RETURNING *;
 -- name: DeleteFamily :exec
 UPDATE families
 SET is_active = false, updated_at = NOW()
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: GetFamilyBaseCurrency :one
+SELECT base_currency FROM families
+WHERE id = $1 AND is_active = true;
+
+-- name: LockFamilyBaseCurrency :one
+-- Base currency for computing amount_base; blocks while the base currency is being changed
+SELECT base_currency FROM families
+WHERE id = $1 AND is_active = true
+FOR SHARE;
+
+-- name: CountFamilyBaseCurrencyAmounts :one
+-- Budgets, assignments, threshold limits and alerts stored in the family base currency
+SELECT (
+    (SELECT COUNT(*) FROM budgets b WHERE b.family_id = sqlc.arg(family_id))
+    + (SELECT COUNT(*) FROM budget_assignments ba WHERE ba.family_id = sqlc.arg(family_id))
+    + (SELECT COUNT(*) FROM alert_thresholds at WHERE at.family_id = sqlc.arg(family_id) AND at.limit_amount IS NOT NULL)
+    + (SELECT COUNT(*) FROM alerts al WHERE al.family_id = sqlc.arg(family_id))
+)::bigint AS total;
@@ -0,0 +1,81 @@
+-- name: GetMonthlyAggregatesSummaryByType :many
+-- Same as GetTransactionsSummaryByType for whole months, read from monthly aggregates
+SELECT
+    a.type,
+    COALESCE(SUM(a.transaction_count), 0)::bigint AS count,
+    COALESCE(SUM(a.total_base), 0)::numeric AS total
+FROM transaction_monthly_aggregates a
+JOIN categories c ON c.id = a.category_id
+WHERE a.family_id = sqlc.arg(family_id)
+  AND a.month >= sqlc.arg(start_month)
+  AND a.month <= sqlc.arg(end_month)
+  AND c.is_system = false
+GROUP BY a.type;
+
+-- name: GetMonthlyAggregatesSummaryByCategory :many
+-- Same as GetTransactionsSummaryByCategory for whole months, read from monthly aggregates
+SELECT
+    a.category_id,
+    COALESCE(SUM(a.transaction_count), 0)::bigint AS count,
+    COALESCE(SUM(a.total_base), 0)::numeric AS total
+FROM transaction_monthly_aggregates a
+JOIN categories c ON c.id = a.category_id
+WHERE a.family_id = sqlc.arg(family_id)
+  AND a.type = sqlc.arg(type)
+  AND a.month >= sqlc.arg(start_month)
+  AND a.month <= sqlc.arg(end_month)
+  AND c.is_system = false
+GROUP BY a.category_id
+ORDER BY total DESC;
+
+-- name: RebuildMonthlyAggregates :one
+-- Recalculates aggregates from raw transactions; nil UUID = all families
+SELECT rebuild_transaction_monthly_aggregates(
+    NULLIF(sqlc.arg(family_id)::uuid, '00000000-0000-0000-0000-000000000000'::uuid)
+)::integer AS aggregate_rows;
+
+-- name: ListMonthlyAggregateMismatches :many
+-- Aggregates differing from raw transactions (missing, extra or wrong totals); nil UUID = all families
+WITH raw AS (
+    SELECT
+        family_id,
+        date_trunc('month', transaction_date)::date AS month,
+        category_id,
+        type,
+        currency,
+        COUNT(*) AS transaction_count,
+        SUM(amount) AS total_amount,
+        SUM(amount_base) AS total_base
+    FROM transactions
+    WHERE is_active = true
+      AND (sqlc.arg(family_id)::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR family_id = sqlc.arg(family_id))
+    GROUP BY family_id, date_trunc('month', transaction_date)::date, category_id, type, currency
+),
+agg AS (
+    SELECT family_id, month, category_id, type, currency, transaction_count, total_amount, total_base
+    FROM transaction_monthly_aggregates
+    WHERE sqlc.arg(family_id)::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR family_id = sqlc.arg(family_id)
+)
+SELECT
+    COALESCE(r.family_id, a.family_id)::uuid AS family_id,
+    COALESCE(r.month, a.month)::date AS month,
+    COALESCE(r.category_id, a.category_id)::uuid AS category_id,
+    COALESCE(r.type, a.type)::text AS type,
+    COALESCE(r.currency, a.currency)::text AS currency,
+    COALESCE(r.transaction_count, 0)::bigint AS expected_count,
+    COALESCE(a.transaction_count, 0)::bigint AS actual_count,
+    COALESCE(r.total_base, 0)::numeric AS expected_total,
+    COALESCE(a.total_base, 0)::numeric AS actual_total
+FROM raw r
+FULL OUTER JOIN agg a
+    ON a.family_id = r.family_id
+   AND a.month = r.month
+   AND a.category_id = r.category_id
+   AND a.type = r.type
+   AND a.currency = r.currency
+WHERE r.family_id IS NULL
+   OR a.family_id IS NULL
+   OR r.transaction_count <> a.transaction_count
+   OR r.total_amount <> a.total_amount
+   OR r.total_base <> a.total_base
+ORDER BY 1, 2, 3, 4, 5;
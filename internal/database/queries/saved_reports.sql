@@ -0,0 +1,30 @@
+-- name: GetSavedReport :one
+SELECT * FROM saved_reports
+WHERE id = $1 AND is_active = true;
+
+-- name: ListSavedReportsByFamily :many
+SELECT * FROM saved_reports
+WHERE family_id = $1 AND is_active = true
+ORDER BY lower(name);
+
+-- name: CreateSavedReport :one
+INSERT INTO saved_reports (
+    id, family_id, name, description, definition, created_by
+) VALUES (
+             $1, $2, $3, $4, $5, $6
+         )
+RETURNING *;
+
+-- name: UpdateSavedReport :one
+UPDATE saved_reports
+SET name = $2,
+    description = $3,
+    definition = $4,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSavedReport :exec
+UPDATE saved_reports
+SET is_active = false, updated_at = NOW()
+WHERE id = $1;
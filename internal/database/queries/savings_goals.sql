@@ -0,0 +1,54 @@
+-- name: GetSavingsGoal :one
+SELECT * FROM savings_goals
+WHERE id = $1 AND is_active = true;
+
+-- name: ListSavingsGoalsByFamily :many
+SELECT * FROM savings_goals
+WHERE family_id = $1 AND is_active = true
+ORDER BY deadline NULLS LAST, name;
+
+-- name: CreateSavingsGoal :one
+INSERT INTO savings_goals (
+    id, family_id, name, target_amount, currency, deadline, description, created_by
+) VALUES (
+             $1, $2, $3, $4, $5, $6, $7, $8
+         )
+RETURNING *;
+
+-- name: UpdateSavingsGoal :one
+UPDATE savings_goals
+SET name = $2,
+    target_amount = $3,
+    currency = $4,
+    deadline = $5,
+    description = $6,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteSavingsGoal :exec
+UPDATE savings_goals
+SET is_active = false, updated_at = NOW()
+WHERE id = $1;
+
+-- name: ListSavingsGoalAccounts :many
+SELECT * FROM savings_goal_accounts
+WHERE goal_id = $1
+ORDER BY created_at;
+
+-- name: ListSavingsGoalAccountsByFamily :many
+SELECT sga.* FROM savings_goal_accounts sga
+                      JOIN savings_goals sg ON sg.id = sga.goal_id
+WHERE sg.family_id = $1 AND sg.is_active = true
+ORDER BY sga.created_at;
+
+-- name: AddSavingsGoalAccount :exec
+INSERT INTO savings_goal_accounts (
+    goal_id, account_id, earmarked_amount
+) VALUES (
+             $1, $2, $3
+         );
+
+-- name: DeleteSavingsGoalAccounts :exec
+DELETE FROM savings_goal_accounts
+WHERE goal_id = $1;
@@ -68,6 +68,7 @@ WHERE family_id = $1
   AND transaction_date >= $2
   AND transaction_date <= $3
   AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
 GROUP BY type;
 
 -- name: GetTransactionsSummaryByCategory :many
@@ -81,9 +82,59 @@ WHERE family_id = $1
   AND transaction_date >= $3
   AND transaction_date <= $4
   AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
 GROUP BY category_id
 ORDER BY total DESC;
 
+-- name: GetTransactionsMonthlySummaryByCategory :many
+SELECT
+    date_trunc('month', transaction_date)::date as month,
+    category_id,
+    COUNT(*) as count,
+    COALESCE(SUM(amount_base), 0)::numeric as total
+FROM transactions
+WHERE family_id = $1
+  AND type = $2
+  AND transaction_date >= $3
+  AND transaction_date <= $4
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+GROUP BY date_trunc('month', transaction_date), category_id
+ORDER BY month;
+
+-- name: GetTransactionsMonthlyTrend :many
+-- Monthly totals grouped by category, type or account in one pass
+SELECT
+    date_trunc('month', transaction_date)::date AS month,
+    (CASE sqlc.arg(group_by)::text
+        WHEN 'category' THEN category_id::text
+        WHEN 'account' THEN account_id::text
+        ELSE type
+    END)::text AS group_key,
+    COUNT(*) AS count,
+    COALESCE(SUM(amount_base), 0)::numeric AS total
+FROM transactions
+WHERE family_id = sqlc.arg(family_id)
+  AND transaction_date >= sqlc.arg(start_date)
+  AND transaction_date <= sqlc.arg(end_date)
+  AND (sqlc.narg(type)::text IS NULL OR type = sqlc.narg(type))
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+GROUP BY 1, 2
+ORDER BY 1, 2;
+
+-- name: GetIncomeTotalsForMonth :one
+-- Income in the month and in all previous months
+SELECT
+    COALESCE(SUM(amount_base) FILTER (WHERE transaction_date >= sqlc.arg(month_start)), 0)::numeric AS month_total,
+    COALESCE(SUM(amount_base) FILTER (WHERE transaction_date < sqlc.arg(month_start)), 0)::numeric AS previous_total
+FROM transactions
+WHERE family_id = sqlc.arg(family_id)
+  AND type = 'income'
+  AND transaction_date <= sqlc.arg(month_end)
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true);
+
 -- name: CountTransactionsByFamily :one
 SELECT COUNT(*) as total
 FROM transactions
@@ -93,16 +144,84 @@ WHERE family_id = $1 AND is_active = true;
 SELECT * FROM transactions
 WHERE id = $1;
 
--- name: ListTransactionsFiltered :many
-SELECT * FROM transactions
-WHERE family_id = $1
-  AND is_active = true
-  AND ($2 = '' OR type = $2)
-  AND ($3 = '00000000-0000-0000-0000-000000000000'::uuid OR account_id = $3)
-  AND ($4::date IS NULL OR transaction_date >= $4)
-  AND ($5::date IS NULL OR transaction_date <= $5)
-ORDER BY transaction_date DESC, created_at DESC
-LIMIT $6 OFFSET $7;
+-- name: GetTransactionWithDetails :one
+-- Transaction with names of its category, account and creator
+SELECT
+    t.*,
+    c.name AS category_name,
+    c.type AS category_type,
+    a.name AS account_name,
+    a.type AS account_type,
+    u.name AS created_by_name
+FROM transactions t
+JOIN categories c ON c.id = t.category_id
+JOIN accounts a ON a.id = t.account_id
+JOIN users u ON u.id = t.created_by
+WHERE t.id = $1 AND t.is_active = true;
+
+-- name: ListTransactionsFilteredWithDetails :many
+-- Filtered page of transactions with names of category, account and creator in one query
+SELECT
+    t.*,
+    c.name AS category_name,
+    c.type AS category_type,
+    a.name AS account_name,
+    a.type AS account_type,
+    u.name AS created_by_name
+FROM transactions t
+JOIN categories c ON c.id = t.category_id
+JOIN accounts a ON a.id = t.account_id
+JOIN users u ON u.id = t.created_by
+WHERE t.family_id = sqlc.arg(family_id)
+  AND t.is_active = true
+  AND (sqlc.arg(type_filter)::text = '' OR t.type = sqlc.arg(type_filter))
+  AND (sqlc.arg(account_filter)::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR t.account_id = sqlc.arg(account_filter))
+  AND (sqlc.narg(start_date)::date IS NULL OR t.transaction_date >= sqlc.narg(start_date))
+  AND (sqlc.narg(end_date)::date IS NULL OR t.transaction_date <= sqlc.narg(end_date))
+ORDER BY t.transaction_date DESC, t.created_at DESC, t.id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: ListTransactionsFilteredAfter :many
+-- Next batch of filtered transactions after the given row (keyset pagination), same columns as ListTransactionsFilteredWithDetails
+SELECT
+    t.*,
+    c.name AS category_name,
+    c.type AS category_type,
+    a.name AS account_name,
+    a.type AS account_type,
+    u.name AS created_by_name
+FROM transactions t
+JOIN categories c ON c.id = t.category_id
+JOIN accounts a ON a.id = t.account_id
+JOIN users u ON u.id = t.created_by
+WHERE t.family_id = sqlc.arg(family_id)
+  AND t.is_active = true
+  AND (sqlc.arg(type_filter)::text = '' OR t.type = sqlc.arg(type_filter))
+  AND (sqlc.arg(account_filter)::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR t.account_id = sqlc.arg(account_filter))
+  AND (sqlc.narg(start_date)::date IS NULL OR t.transaction_date >= sqlc.narg(start_date))
+  AND (sqlc.narg(end_date)::date IS NULL OR t.transaction_date <= sqlc.narg(end_date))
+  AND (sqlc.narg(after_id)::uuid IS NULL
+    OR (t.transaction_date, t.created_at, t.id) < (sqlc.narg(after_date)::date, sqlc.narg(after_created_at)::timestamp, sqlc.narg(after_id)::uuid))
+ORDER BY t.transaction_date DESC, t.created_at DESC, t.id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetTransactionsSummaryByCategoryWithNames :many
+-- Category summary including category names
+SELECT
+    t.category_id,
+    c.name AS category_name,
+    COUNT(*) AS count,
+    COALESCE(SUM(t.amount_base), 0)::numeric AS total
+FROM transactions t
+JOIN categories c ON c.id = t.category_id
+WHERE t.family_id = sqlc.arg(family_id)
+  AND t.type = sqlc.arg(type)
+  AND t.transaction_date >= sqlc.arg(start_date)
+  AND t.transaction_date <= sqlc.arg(end_date)
+  AND t.is_active = true
+  AND c.is_system = false
+GROUP BY t.category_id, c.name
+ORDER BY total DESC;
 
 -- name: CountTransactionsFiltered :one
 SELECT COUNT(*) as total
@@ -112,4 +231,107 @@ WHERE family_id = $1
   AND ($2 = '' OR type = $2)
   AND ($3 = '00000000-0000-0000-0000-000000000000'::uuid OR account_id = $3)
   AND ($4::date IS NULL OR transaction_date >= $4)
-  AND ($5::date IS NULL OR transaction_date <= $5);
\ No newline at end of file
+  AND ($5::date IS NULL OR transaction_date <= $5);
+
+-- name: CountTransactionsByCategory :one
+SELECT COUNT(*) as total
+FROM transactions
+WHERE category_id = $1 AND is_active = true;
+
+-- name: ReassignTransactionsCategory :execrows
+UPDATE transactions
+SET category_id = sqlc.arg(target_category_id), updated_at = NOW()
+WHERE category_id = sqlc.arg(source_category_id);
+
+-- name: GetTransactionsSummaryByMember :many
+-- Totals per family member (created_by), type and category
+SELECT
+    created_by,
+    type,
+    category_id,
+    COUNT(*) AS count,
+    COALESCE(SUM(amount_base), 0)::numeric AS total
+FROM transactions
+WHERE family_id = sqlc.arg(family_id)
+  AND transaction_date >= sqlc.arg(start_date)
+  AND transaction_date <= sqlc.arg(end_date)
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+GROUP BY created_by, type, category_id
+ORDER BY created_by, type, total DESC;
+
+-- name: GetTransactionsTopDescriptions :many
+-- Totals per description (payee), case and surrounding spaces ignored
+SELECT
+    (array_agg(trim(description) ORDER BY transaction_date DESC))[1]::text AS description,
+    COUNT(*) AS count,
+    COALESCE(SUM(amount_base), 0)::numeric AS total
+FROM transactions
+WHERE family_id = sqlc.arg(family_id)
+  AND type = sqlc.arg(type)
+  AND transaction_date >= sqlc.arg(start_date)
+  AND transaction_date <= sqlc.arg(end_date)
+  AND is_active = true
+  AND trim(COALESCE(description, '')) <> ''
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+GROUP BY lower(trim(description))
+ORDER BY total DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListLargestTransactionsWithDetails :many
+-- Largest transactions of a type in a period with category, account and creator names
+SELECT
+    t.*,
+    c.name AS category_name,
+    c.type AS category_type,
+    a.name AS account_name,
+    a.type AS account_type,
+    u.name AS created_by_name
+FROM transactions t
+JOIN categories c ON c.id = t.category_id
+JOIN accounts a ON a.id = t.account_id
+JOIN users u ON u.id = t.created_by
+WHERE t.family_id = sqlc.arg(family_id)
+  AND t.type = sqlc.arg(type)
+  AND t.transaction_date >= sqlc.arg(start_date)
+  AND t.transaction_date <= sqlc.arg(end_date)
+  AND t.is_active = true
+  AND c.is_system = false
+ORDER BY t.amount_base DESC, t.transaction_date DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetTransactionsDailyTotals :many
+-- Totals per day of one type, filtered in index order of idx_transactions_family_date_type
+SELECT
+    transaction_date AS day,
+    COUNT(*) AS count,
+    COALESCE(SUM(amount_base), 0)::numeric AS total
+FROM transactions
+WHERE family_id = sqlc.arg(family_id)
+  AND transaction_date >= sqlc.arg(start_date)
+  AND transaction_date <= sqlc.arg(end_date)
+  AND type = sqlc.arg(type)
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+GROUP BY transaction_date
+ORDER BY transaction_date;
+
+-- name: CountTransactionsWithoutExchangeRate :one
+-- Transactions that cannot be converted to the base currency (no direct, inverse or cross rate up to their date)
+SELECT COUNT(*) AS total
+FROM transactions t
+WHERE t.family_id = sqlc.arg(family_id)
+  AND find_exchange_rate(t.currency, sqlc.arg(base_currency)::text, t.transaction_date) IS NULL;
+
+-- name: RecomputeTransactionsAmountBase :execrows
+-- Converts amount to the base currency with the latest rate up to the transaction date (direct, inverse or cross rate through EUR or RSD), rounded to its minor units
+UPDATE transactions t
+SET amount_base = CASE
+        WHEN t.currency = sqlc.arg(base_currency)::text THEN t.amount
+        ELSE GREATEST(ROUND(t.amount * find_exchange_rate(t.currency, sqlc.arg(base_currency)::text, t.transaction_date),
+            c.minor_units), power(10::numeric, -c.minor_units))
+    END,
+    updated_at = NOW()
+FROM currencies c
+WHERE c.code = sqlc.arg(base_currency)::text
+  AND t.family_id = sqlc.arg(family_id);
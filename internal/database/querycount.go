@@ -0,0 +1,30 @@
+package database
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type queryCounterKey struct{}
+
+// WithQueryCounter returns a context that counts database queries issued with it.
+// The counter is incremented by the pool tracer for every query, including
+// transaction control statements; the pool must be created with CountQueries set.
+func WithQueryCounter(ctx context.Context) (context.Context, *atomic.Int64) {
+	counter := new(atomic.Int64)
+	return context.WithValue(ctx, queryCounterKey{}, counter), counter
+}
+
+// queryCountTracer increments the query counter of the context, if any
+type queryCountTracer struct{}
+
+func (queryCountTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	if counter, ok := ctx.Value(queryCounterKey{}).(*atomic.Int64); ok {
+		counter.Add(1)
+	}
+	return ctx
+}
+
+func (queryCountTracer) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
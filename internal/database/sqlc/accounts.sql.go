@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
@@ -108,6 +109,32 @@ func (q *Queries) GetAccountBalance(ctx context.Context, id uuid.UUID) (GetAccou
 	return i, err
 }
 
+const getAccountBalanceAsOf = `-- name: GetAccountBalanceAsOf :one
+SELECT
+    (a.initial_balance + COALESCE(SUM(
+        t.amount * CASE WHEN t.type = 'income' THEN 1 ELSE -1 END
+    ), 0))::numeric as balance
+FROM accounts a
+         LEFT JOIN transactions t
+                   ON t.account_id = a.id
+                       AND t.is_active = true
+                       AND t.transaction_date <= $2
+WHERE a.id = $1
+GROUP BY a.id, a.initial_balance, a.currency
+`
+
+type GetAccountBalanceAsOfParams struct {
+	ID              uuid.UUID   `json:"id"`
+	TransactionDate pgtype.Date `json:"transaction_date"`
+}
+
+func (q *Queries) GetAccountBalanceAsOf(ctx context.Context, arg GetAccountBalanceAsOfParams) (decimal.Decimal, error) {
+	row := q.db.QueryRow(ctx, getAccountBalanceAsOf, arg.ID, arg.TransactionDate)
+	var balance decimal.Decimal
+	err := row.Scan(&balance)
+	return balance, err
+}
+
 const getAccountIncludingInactive = `-- name: GetAccountIncludingInactive :one
 SELECT id, family_id, name, type, currency, initial_balance, current_balance, description, created_at, updated_at, is_active FROM accounts
 WHERE id = $1
@@ -132,6 +159,36 @@ func (q *Queries) GetAccountIncludingInactive(ctx context.Context, id uuid.UUID)
 	return i, err
 }
 
+const getAccountsNetFlow = `-- name: GetAccountsNetFlow :one
+SELECT
+    COALESCE(SUM(
+        CASE
+            WHEN type = 'income' THEN amount_base
+            WHEN type = 'expense' THEN -amount_base
+            ELSE 0
+            END
+    ), 0)::numeric as net_flow
+FROM transactions
+WHERE account_id = ANY($1::uuid[])
+  AND transaction_date >= $2
+  AND transaction_date <= $3
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+`
+
+type GetAccountsNetFlowParams struct {
+	AccountIds []uuid.UUID `json:"account_ids"`
+	StartDate  pgtype.Date `json:"start_date"`
+	EndDate    pgtype.Date `json:"end_date"`
+}
+
+func (q *Queries) GetAccountsNetFlow(ctx context.Context, arg GetAccountsNetFlowParams) (decimal.Decimal, error) {
+	row := q.db.QueryRow(ctx, getAccountsNetFlow, arg.AccountIds, arg.StartDate, arg.EndDate)
+	var net_flow decimal.Decimal
+	err := row.Scan(&net_flow)
+	return net_flow, err
+}
+
 const getTotalBalanceByFamily = `-- name: GetTotalBalanceByFamily :one
 SELECT
     COALESCE(SUM(current_balance), 0)::numeric as total_balance,
@@ -152,6 +209,56 @@ func (q *Queries) GetTotalBalanceByFamily(ctx context.Context, familyID uuid.UUI
 	return i, err
 }
 
+const listAccountBalancesBetween = `-- name: ListAccountBalancesBetween :many
+SELECT
+    a.id as account_id,
+    (a.initial_balance + COALESCE(SUM(
+        t.amount * CASE WHEN t.type = 'income' THEN 1 ELSE -1 END
+    ) FILTER (WHERE t.transaction_date < $1), 0))::numeric as opening_balance,
+    (a.initial_balance + COALESCE(SUM(
+        t.amount * CASE WHEN t.type = 'income' THEN 1 ELSE -1 END
+    ), 0))::numeric as closing_balance
+FROM accounts a
+         LEFT JOIN transactions t
+                   ON t.account_id = a.id
+                       AND t.is_active = true
+                       AND t.transaction_date <= $2
+WHERE a.family_id = $3
+GROUP BY a.id, a.initial_balance
+`
+
+type ListAccountBalancesBetweenParams struct {
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+	FamilyID  uuid.UUID   `json:"family_id"`
+}
+
+type ListAccountBalancesBetweenRow struct {
+	AccountID      uuid.UUID       `json:"account_id"`
+	OpeningBalance decimal.Decimal `json:"opening_balance"`
+	ClosingBalance decimal.Decimal `json:"closing_balance"`
+}
+
+func (q *Queries) ListAccountBalancesBetween(ctx context.Context, arg ListAccountBalancesBetweenParams) ([]ListAccountBalancesBetweenRow, error) {
+	rows, err := q.db.Query(ctx, listAccountBalancesBetween, arg.StartDate, arg.EndDate, arg.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAccountBalancesBetweenRow{}
+	for rows.Next() {
+		var i ListAccountBalancesBetweenRow
+		if err := rows.Scan(&i.AccountID, &i.OpeningBalance, &i.ClosingBalance); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listAccountsByFamily = `-- name: ListAccountsByFamily :many
 SELECT id, family_id, name, type, currency, initial_balance, current_balance, description, created_at, updated_at, is_active FROM accounts
 WHERE family_id = $1 AND is_active = true
@@ -305,3 +412,42 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (A
 	)
 	return i, err
 }
+
+const updateAccountInitialBalance = `-- name: UpdateAccountInitialBalance :one
+UPDATE accounts
+SET
+    initial_balance = $2,
+    current_balance = $2 + COALESCE((
+        SELECT SUM(t.amount * CASE WHEN t.type = 'income' THEN 1 ELSE -1 END)
+        FROM transactions t
+        WHERE t.account_id = accounts.id
+          AND t.is_active = true
+    ), 0),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING id, family_id, name, type, currency, initial_balance, current_balance, description, created_at, updated_at, is_active
+`
+
+type UpdateAccountInitialBalanceParams struct {
+	ID             uuid.UUID       `json:"id"`
+	InitialBalance decimal.Decimal `json:"initial_balance"`
+}
+
+func (q *Queries) UpdateAccountInitialBalance(ctx context.Context, arg UpdateAccountInitialBalanceParams) (Account, error) {
+	row := q.db.QueryRow(ctx, updateAccountInitialBalance, arg.ID, arg.InitialBalance)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.FamilyID,
+		&i.Name,
+		&i.Type,
+		&i.Currency,
+		&i.InitialBalance,
+		&i.CurrentBalance,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+	)
+	return i, err
+}
@@ -14,19 +14,23 @@ import (
 
 const createCategory = `-- name: CreateCategory :one
 INSERT INTO categories (
-    id, family_id, name, type, parent_id
+    id, family_id, name, type, parent_id, description, icon, color, sort_order
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6, $7, $8, $9
          )
-RETURNING id, family_id, name, type, parent_id, description, created_at, updated_at, is_active
+RETURNING id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order
 `
 
 type CreateCategoryParams struct {
-	ID       uuid.UUID   `json:"id"`
-	FamilyID uuid.UUID   `json:"family_id"`
-	Name     string      `json:"name"`
-	Type     string      `json:"type"`
-	ParentID pgtype.UUID `json:"parent_id"`
+	ID          uuid.UUID   `json:"id"`
+	FamilyID    uuid.UUID   `json:"family_id"`
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	ParentID    pgtype.UUID `json:"parent_id"`
+	Description pgtype.Text `json:"description"`
+	Icon        pgtype.Text `json:"icon"`
+	Color       pgtype.Text `json:"color"`
+	SortOrder   int32       `json:"sort_order"`
 }
 
 func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error) {
@@ -36,6 +40,10 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 		arg.Name,
 		arg.Type,
 		arg.ParentID,
+		arg.Description,
+		arg.Icon,
+		arg.Color,
+		arg.SortOrder,
 	)
 	var i Category
 	err := row.Scan(
@@ -48,6 +56,53 @@ func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.IsSystem,
+		&i.Icon,
+		&i.Color,
+		&i.SortOrder,
+	)
+	return i, err
+}
+
+const createSystemCategory = `-- name: CreateSystemCategory :one
+INSERT INTO categories (
+    id, family_id, name, type, is_system
+) VALUES (
+             $1, $2, $3, $4, true
+         )
+ON CONFLICT (family_id, name, type) DO NOTHING
+RETURNING id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order
+`
+
+type CreateSystemCategoryParams struct {
+	ID       uuid.UUID `json:"id"`
+	FamilyID uuid.UUID `json:"family_id"`
+	Name     string    `json:"name"`
+	Type     string    `json:"type"`
+}
+
+func (q *Queries) CreateSystemCategory(ctx context.Context, arg CreateSystemCategoryParams) (Category, error) {
+	row := q.db.QueryRow(ctx, createSystemCategory,
+		arg.ID,
+		arg.FamilyID,
+		arg.Name,
+		arg.Type,
+	)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.FamilyID,
+		&i.Name,
+		&i.Type,
+		&i.ParentID,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+		&i.IsSystem,
+		&i.Icon,
+		&i.Color,
+		&i.SortOrder,
 	)
 	return i, err
 }
@@ -64,7 +119,7 @@ func (q *Queries) DeleteCategory(ctx context.Context, id uuid.UUID) error {
 }
 
 const getCategory = `-- name: GetCategory :one
-SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active FROM categories
+SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order FROM categories
 WHERE id = $1 AND is_active = true
 `
 
@@ -81,12 +136,48 @@ func (q *Queries) GetCategory(ctx context.Context, id uuid.UUID) (Category, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.IsSystem,
+		&i.Icon,
+		&i.Color,
+		&i.SortOrder,
+	)
+	return i, err
+}
+
+const getCategoryByName = `-- name: GetCategoryByName :one
+SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order FROM categories
+WHERE family_id = $1 AND name = $2 AND type = $3
+`
+
+type GetCategoryByNameParams struct {
+	FamilyID uuid.UUID `json:"family_id"`
+	Name     string    `json:"name"`
+	Type     string    `json:"type"`
+}
+
+func (q *Queries) GetCategoryByName(ctx context.Context, arg GetCategoryByNameParams) (Category, error) {
+	row := q.db.QueryRow(ctx, getCategoryByName, arg.FamilyID, arg.Name, arg.Type)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.FamilyID,
+		&i.Name,
+		&i.Type,
+		&i.ParentID,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+		&i.IsSystem,
+		&i.Icon,
+		&i.Color,
+		&i.SortOrder,
 	)
 	return i, err
 }
 
 const getCategoryIncludingInactive = `-- name: GetCategoryIncludingInactive :one
-SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active FROM categories
+SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order FROM categories
 WHERE id = $1
 `
 
@@ -103,14 +194,96 @@ func (q *Queries) GetCategoryIncludingInactive(ctx context.Context, id uuid.UUID
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.IsSystem,
+		&i.Icon,
+		&i.Color,
+		&i.SortOrder,
+	)
+	return i, err
+}
+
+const getCategorySubtreeDepth = `-- name: GetCategorySubtreeDepth :one
+WITH RECURSIVE subtree AS (
+    SELECT id, 1 AS depth
+    FROM categories
+    WHERE id = $1
+    UNION ALL
+    SELECT c.id, s.depth + 1
+    FROM categories c
+             JOIN subtree s ON c.parent_id = s.id
+    WHERE s.depth < 100
+)
+SELECT MAX(depth)::int AS depth FROM subtree
+`
+
+func (q *Queries) GetCategorySubtreeDepth(ctx context.Context, id uuid.UUID) (int32, error) {
+	row := q.db.QueryRow(ctx, getCategorySubtreeDepth, id)
+	var depth int32
+	err := row.Scan(&depth)
+	return depth, err
+}
+
+const getNextCategorySortOrder = `-- name: GetNextCategorySortOrder :one
+SELECT COALESCE(MAX(sort_order) + 1, 0)::int AS next_sort_order
+FROM categories
+WHERE family_id = $1
+  AND type = $2
+  AND parent_id IS NOT DISTINCT FROM $3
+  AND is_active = true
+`
+
+type GetNextCategorySortOrderParams struct {
+	FamilyID uuid.UUID   `json:"family_id"`
+	Type     string      `json:"type"`
+	ParentID pgtype.UUID `json:"parent_id"`
+}
+
+func (q *Queries) GetNextCategorySortOrder(ctx context.Context, arg GetNextCategorySortOrderParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getNextCategorySortOrder, arg.FamilyID, arg.Type, arg.ParentID)
+	var next_sort_order int32
+	err := row.Scan(&next_sort_order)
+	return next_sort_order, err
+}
+
+const getSystemCategory = `-- name: GetSystemCategory :one
+SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order FROM categories
+WHERE family_id = $1
+  AND type = $2
+  AND is_system = true
+ORDER BY created_at
+LIMIT 1
+`
+
+type GetSystemCategoryParams struct {
+	FamilyID uuid.UUID `json:"family_id"`
+	Type     string    `json:"type"`
+}
+
+func (q *Queries) GetSystemCategory(ctx context.Context, arg GetSystemCategoryParams) (Category, error) {
+	row := q.db.QueryRow(ctx, getSystemCategory, arg.FamilyID, arg.Type)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.FamilyID,
+		&i.Name,
+		&i.Type,
+		&i.ParentID,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+		&i.IsSystem,
+		&i.Icon,
+		&i.Color,
+		&i.SortOrder,
 	)
 	return i, err
 }
 
 const listAllCategoriesByFamily = `-- name: ListAllCategoriesByFamily :many
-SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active FROM categories
+SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order FROM categories
 WHERE family_id = $1
-ORDER BY type, name
+ORDER BY type, sort_order, name
 `
 
 func (q *Queries) ListAllCategoriesByFamily(ctx context.Context, familyID uuid.UUID) ([]Category, error) {
@@ -132,6 +305,13 @@ func (q *Queries) ListAllCategoriesByFamily(ctx context.Context, familyID uuid.U
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsActive,
+			&i.IsSystem,
+			&i.Icon,
+			&i.Color,
+			&i.SortOrder,
+			&i.Icon,
+			&i.Color,
+			&i.SortOrder,
 		); err != nil {
 			return nil, err
 		}
@@ -144,9 +324,9 @@ func (q *Queries) ListAllCategoriesByFamily(ctx context.Context, familyID uuid.U
 }
 
 const listCategoriesByFamily = `-- name: ListCategoriesByFamily :many
-SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active FROM categories
+SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order FROM categories
 WHERE family_id = $1 AND is_active = true
-ORDER BY type, name
+ORDER BY type, sort_order, name
 `
 
 func (q *Queries) ListCategoriesByFamily(ctx context.Context, familyID uuid.UUID) ([]Category, error) {
@@ -168,6 +348,13 @@ func (q *Queries) ListCategoriesByFamily(ctx context.Context, familyID uuid.UUID
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsActive,
+			&i.IsSystem,
+			&i.Icon,
+			&i.Color,
+			&i.SortOrder,
+			&i.Icon,
+			&i.Color,
+			&i.SortOrder,
 		); err != nil {
 			return nil, err
 		}
@@ -180,9 +367,9 @@ func (q *Queries) ListCategoriesByFamily(ctx context.Context, familyID uuid.UUID
 }
 
 const listCategoriesByType = `-- name: ListCategoriesByType :many
-SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active FROM categories
+SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order FROM categories
 WHERE family_id = $1 AND type = $2 AND is_active = true
-ORDER BY name
+ORDER BY sort_order, name
 `
 
 type ListCategoriesByTypeParams struct {
@@ -209,6 +396,13 @@ func (q *Queries) ListCategoriesByType(ctx context.Context, arg ListCategoriesBy
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsActive,
+			&i.IsSystem,
+			&i.Icon,
+			&i.Color,
+			&i.SortOrder,
+			&i.Icon,
+			&i.Color,
+			&i.SortOrder,
 		); err != nil {
 			return nil, err
 		}
@@ -220,10 +414,45 @@ func (q *Queries) ListCategoriesByType(ctx context.Context, arg ListCategoriesBy
 	return items, nil
 }
 
+const listCategoryAncestorIDs = `-- name: ListCategoryAncestorIDs :many
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id, 1 AS depth
+    FROM categories
+    WHERE id = $1
+    UNION ALL
+    SELECT c.id, c.parent_id, a.depth + 1
+    FROM categories c
+             JOIN ancestors a ON c.id = a.parent_id
+    WHERE a.depth < 100
+)
+SELECT id FROM ancestors
+ORDER BY depth
+`
+
+func (q *Queries) ListCategoryAncestorIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error) {
+	rows, err := q.db.Query(ctx, listCategoryAncestorIDs, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listChildCategories = `-- name: ListChildCategories :many
-SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active FROM categories
+SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order FROM categories
 WHERE parent_id = $1 AND is_active = true
-ORDER BY name
+ORDER BY sort_order, name
 `
 
 func (q *Queries) ListChildCategories(ctx context.Context, parentID pgtype.UUID) ([]Category, error) {
@@ -245,6 +474,13 @@ func (q *Queries) ListChildCategories(ctx context.Context, parentID pgtype.UUID)
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsActive,
+			&i.IsSystem,
+			&i.Icon,
+			&i.Color,
+			&i.SortOrder,
+			&i.Icon,
+			&i.Color,
+			&i.SortOrder,
 		); err != nil {
 			return nil, err
 		}
@@ -257,9 +493,9 @@ func (q *Queries) ListChildCategories(ctx context.Context, parentID pgtype.UUID)
 }
 
 const listRootCategories = `-- name: ListRootCategories :many
-SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active FROM categories
+SELECT id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order FROM categories
 WHERE family_id = $1 AND parent_id IS NULL AND is_active = true
-ORDER BY type, name
+ORDER BY type, sort_order, name
 `
 
 func (q *Queries) ListRootCategories(ctx context.Context, familyID uuid.UUID) ([]Category, error) {
@@ -281,6 +517,13 @@ func (q *Queries) ListRootCategories(ctx context.Context, familyID uuid.UUID) ([
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsActive,
+			&i.IsSystem,
+			&i.Icon,
+			&i.Color,
+			&i.SortOrder,
+			&i.Icon,
+			&i.Color,
+			&i.SortOrder,
 		); err != nil {
 			return nil, err
 		}
@@ -292,22 +535,82 @@ func (q *Queries) ListRootCategories(ctx context.Context, familyID uuid.UUID) ([
 	return items, nil
 }
 
+const moveChildCategories = `-- name: MoveChildCategories :execrows
+UPDATE categories
+SET parent_id = $1, updated_at = NOW()
+WHERE parent_id = $2
+`
+
+type MoveChildCategoriesParams struct {
+	NewParentID pgtype.UUID `json:"new_parent_id"`
+	OldParentID pgtype.UUID `json:"old_parent_id"`
+}
+
+func (q *Queries) MoveChildCategories(ctx context.Context, arg MoveChildCategoriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveChildCategories, arg.NewParentID, arg.OldParentID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setCategoryParent = `-- name: SetCategoryParent :one
+UPDATE categories
+SET parent_id = $2, updated_at = NOW()
+WHERE id = $1
+RETURNING id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order
+`
+
+type SetCategoryParentParams struct {
+	ID       uuid.UUID   `json:"id"`
+	ParentID pgtype.UUID `json:"parent_id"`
+}
+
+func (q *Queries) SetCategoryParent(ctx context.Context, arg SetCategoryParentParams) (Category, error) {
+	row := q.db.QueryRow(ctx, setCategoryParent, arg.ID, arg.ParentID)
+	var i Category
+	err := row.Scan(
+		&i.ID,
+		&i.FamilyID,
+		&i.Name,
+		&i.Type,
+		&i.ParentID,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+		&i.IsSystem,
+		&i.Icon,
+		&i.Color,
+		&i.SortOrder,
+	)
+	return i, err
+}
+
 const updateCategory = `-- name: UpdateCategory :one
 UPDATE categories
 SET
     name = $2,
     parent_id = $3,
     is_active = $4,
+    description = $5,
+    icon = $6,
+    color = $7,
+    sort_order = $8,
     updated_at = NOW()
 WHERE id = $1
-RETURNING id, family_id, name, type, parent_id, description, created_at, updated_at, is_active
+RETURNING id, family_id, name, type, parent_id, description, created_at, updated_at, is_active, is_system, icon, color, sort_order
 `
 
 type UpdateCategoryParams struct {
-	ID       uuid.UUID   `json:"id"`
-	Name     string      `json:"name"`
-	ParentID pgtype.UUID `json:"parent_id"`
-	IsActive bool        `json:"is_active"`
+	ID          uuid.UUID   `json:"id"`
+	Name        string      `json:"name"`
+	ParentID    pgtype.UUID `json:"parent_id"`
+	IsActive    bool        `json:"is_active"`
+	Description pgtype.Text `json:"description"`
+	Icon        pgtype.Text `json:"icon"`
+	Color       pgtype.Text `json:"color"`
+	SortOrder   int32       `json:"sort_order"`
 }
 
 func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error) {
@@ -316,6 +619,10 @@ func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams)
 		arg.Name,
 		arg.ParentID,
 		arg.IsActive,
+		arg.Description,
+		arg.Icon,
+		arg.Color,
+		arg.SortOrder,
 	)
 	var i Category
 	err := row.Scan(
@@ -328,6 +635,25 @@ func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.IsActive,
+		&i.IsSystem,
+		&i.Icon,
+		&i.Color,
+		&i.SortOrder,
 	)
 	return i, err
 }
+
+const updateCategorySortOrders = `-- name: UpdateCategorySortOrders :execrows
+UPDATE categories c
+SET sort_order = o.position - 1, updated_at = NOW()
+FROM unnest($1::uuid[]) WITH ORDINALITY AS o(id, position)
+WHERE c.id = o.id
+`
+
+func (q *Queries) UpdateCategorySortOrders(ctx context.Context, ids []uuid.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, updateCategorySortOrders, ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
@@ -15,9 +15,9 @@ import (
 
 const createExchangeRate = `-- name: CreateExchangeRate :one
 INSERT INTO exchange_rates (
-    id, from_currency, to_currency, rate, date
+    id, from_currency, to_currency, rate, date, source
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6
          )
 RETURNING id, from_currency, to_currency, rate, date, source, created_at
 `
@@ -28,6 +28,7 @@ type CreateExchangeRateParams struct {
 	ToCurrency   string          `json:"to_currency"`
 	Rate         decimal.Decimal `json:"rate"`
 	Date         pgtype.Date     `json:"date"`
+	Source       string          `json:"source"`
 }
 
 func (q *Queries) CreateExchangeRate(ctx context.Context, arg CreateExchangeRateParams) (ExchangeRate, error) {
@@ -37,6 +38,7 @@ func (q *Queries) CreateExchangeRate(ctx context.Context, arg CreateExchangeRate
 		arg.ToCurrency,
 		arg.Rate,
 		arg.Date,
+		arg.Source,
 	)
 	var i ExchangeRate
 	err := row.Scan(
@@ -194,12 +196,12 @@ func (q *Queries) ListExchangeRatesHistory(ctx context.Context, arg ListExchange
 
 const upsertExchangeRate = `-- name: UpsertExchangeRate :one
 INSERT INTO exchange_rates (
-    id, from_currency, to_currency, rate, date
+    id, from_currency, to_currency, rate, date, source
 ) VALUES (
-             $1, $2, $3, $4, $5
+             $1, $2, $3, $4, $5, $6
          )
 ON CONFLICT (from_currency, to_currency, date)
-    DO UPDATE SET rate = EXCLUDED.rate
+    DO UPDATE SET rate = EXCLUDED.rate, source = EXCLUDED.source
 RETURNING id, from_currency, to_currency, rate, date, source, created_at
 `
 
@@ -209,6 +211,7 @@ type UpsertExchangeRateParams struct {
 	ToCurrency   string          `json:"to_currency"`
 	Rate         decimal.Decimal `json:"rate"`
 	Date         pgtype.Date     `json:"date"`
+	Source       string          `json:"source"`
 }
 
 func (q *Queries) UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error) {
@@ -218,6 +221,7 @@ func (q *Queries) UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRate
 		arg.ToCurrency,
 		arg.Rate,
 		arg.Date,
+		arg.Source,
 	)
 	var i ExchangeRate
 	err := row.Scan(
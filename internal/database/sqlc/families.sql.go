@@ -11,6 +11,23 @@ import (
 	"github.com/google/uuid"
 )
 
+const countFamilyBaseCurrencyAmounts = `-- name: CountFamilyBaseCurrencyAmounts :one
+SELECT (
+    (SELECT COUNT(*) FROM budgets b WHERE b.family_id = $1)
+    + (SELECT COUNT(*) FROM budget_assignments ba WHERE ba.family_id = $1)
+    + (SELECT COUNT(*) FROM alert_thresholds at WHERE at.family_id = $1 AND at.limit_amount IS NOT NULL)
+    + (SELECT COUNT(*) FROM alerts al WHERE al.family_id = $1)
+)::bigint AS total
+`
+
+// Budgets, assignments, threshold limits and alerts stored in the family base currency
+func (q *Queries) CountFamilyBaseCurrencyAmounts(ctx context.Context, familyID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countFamilyBaseCurrencyAmounts, familyID)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
 const createFamily = `-- name: CreateFamily :one
 INSERT INTO families (
     id, name, base_currency
@@ -70,6 +87,18 @@ func (q *Queries) GetFamily(ctx context.Context, id uuid.UUID) (Family, error) {
 	return i, err
 }
 
+const getFamilyBaseCurrency = `-- name: GetFamilyBaseCurrency :one
+SELECT base_currency FROM families
+WHERE id = $1 AND is_active = true
+`
+
+func (q *Queries) GetFamilyBaseCurrency(ctx context.Context, id uuid.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, getFamilyBaseCurrency, id)
+	var base_currency string
+	err := row.Scan(&base_currency)
+	return base_currency, err
+}
+
 const getFamilyByName = `-- name: GetFamilyByName :one
 SELECT id, name, base_currency, created_at, updated_at, is_active FROM families
 WHERE name = $1 AND is_active = true
@@ -122,6 +151,20 @@ func (q *Queries) ListFamilies(ctx context.Context) ([]Family, error) {
 	return items, nil
 }
 
+const lockFamilyBaseCurrency = `-- name: LockFamilyBaseCurrency :one
+SELECT base_currency FROM families
+WHERE id = $1 AND is_active = true
+FOR SHARE
+`
+
+// Base currency for computing amount_base; blocks while the base currency is being changed
+func (q *Queries) LockFamilyBaseCurrency(ctx context.Context, id uuid.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, lockFamilyBaseCurrency, id)
+	var base_currency string
+	err := row.Scan(&base_currency)
+	return base_currency, err
+}
+
 const updateFamily = `-- name: UpdateFamily :one
 UPDATE families
 SET
@@ -22,9 +22,9 @@ type Account struct {
 	Name string `json:"name"`
 	// Account type: cash (physical cash), checking (current/debit account), savings (savings account/deposit)
 	Type string `json:"type"`
-	// Account currency. MVP: RSD or EUR only. Post-MVP: any currency.
+	// Account currency (ISO 4217, see currencies).
 	Currency string `json:"currency"`
-	// Starting balance when account was created. Does NOT change after creation. Used for balance calculations.
+	// Starting balance when account was created. Can be corrected later; current_balance is recalculated and the change is recorded in audit_log.
 	InitialBalance decimal.Decimal `json:"initial_balance"`
 	// Current account balance. AUTOMATICALLY calculated by trigger based on transactions. Formula: initial_balance + SUM(income) - SUM(expense). DO NOT update manually!
 	CurrentBalance decimal.Decimal `json:"current_balance"`
@@ -38,6 +38,76 @@ type Account struct {
 	IsActive bool `json:"is_active"`
 }
 
+// Interest configuration for savings accounts. Interest income transactions are posted by a scheduled job.
+type AccountInterestSetting struct {
+	// UUID primary key. Generated automatically.
+	ID uuid.UUID `json:"id"`
+	// Foreign key to accounts. One configuration per account. ON DELETE CASCADE.
+	AccountID uuid.UUID `json:"account_id"`
+	// Foreign key to families. ON DELETE CASCADE.
+	FamilyID uuid.UUID `json:"family_id"`
+	// Nominal annual interest rate in percent. Example: 3.5000 = 3.5% p.a.
+	AnnualRate decimal.Decimal `json:"annual_rate"`
+	// How often interest is paid out and added to the balance: monthly, quarterly, annually
+	Compounding string `json:"compounding"`
+	// Day of month when interest is paid out (1-28)
+	PayoutDay int16 `json:"payout_day"`
+	// Tax withheld from gross interest in percent. Only net interest is posted.
+	WithholdingTaxRate decimal.Decimal `json:"withholding_tax_rate"`
+	// Income category used for posted interest transactions. ON DELETE RESTRICT.
+	IncomeCategoryID uuid.UUID `json:"income_category_id"`
+	// User who configured interest. Posted transactions are created on behalf of this user.
+	CreatedBy uuid.UUID `json:"created_by"`
+	// Payout date of the last posted interest. NULL = nothing posted yet.
+	LastPostedDate pgtype.Date `json:"last_posted_date"`
+	// Timestamp when configuration was created. First payout is one compounding period later.
+	CreatedAt time.Time `json:"created_at"`
+	// Timestamp of last update. Updated automatically by trigger.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Raised when spending of a month reaches a percentage of a threshold limit. Never duplicated within a month.
+type Alert struct {
+	ID          uuid.UUID   `json:"id"`
+	FamilyID    uuid.UUID   `json:"family_id"`
+	ThresholdID uuid.UUID   `json:"threshold_id"`
+	CategoryID  pgtype.UUID `json:"category_id"`
+	// First day of the month the alert belongs to.
+	Period     pgtype.Date `json:"period"`
+	Percentage int32       `json:"percentage"`
+	// Limit at the moment the alert was raised, in the family base currency.
+	LimitAmount decimal.Decimal `json:"limit_amount"`
+	// Spending of the month at the moment the alert was raised, in the family base currency.
+	SpentAmount decimal.Decimal `json:"spent_amount"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// Read and acknowledge state of alerts per user. No row = unread.
+type AlertRead struct {
+	AlertID uuid.UUID `json:"alert_id"`
+	UserID  uuid.UUID `json:"user_id"`
+	ReadAt  time.Time `json:"read_at"`
+	// When the user acknowledged the alert. NULL = read but not acknowledged.
+	AcknowledgedAt pgtype.Timestamp `json:"acknowledged_at"`
+}
+
+// Spending thresholds per category or for overall monthly spending. Checked when transactions are created or updated.
+type AlertThreshold struct {
+	ID       uuid.UUID `json:"id"`
+	FamilyID uuid.UUID `json:"family_id"`
+	// Watched category including subcategories. NULL = overall monthly spending of the family.
+	CategoryID pgtype.UUID `json:"category_id"`
+	// Monthly limit in the family base currency. NULL = monthly budget of the category.
+	LimitAmount pgtype.Numeric `json:"limit_amount"`
+	// Percentages of the limit that raise an alert. Default: 80 and 100.
+	Percentages []int32     `json:"percentages"`
+	CreatedBy   pgtype.UUID `json:"created_by"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	// Soft delete flag. true = active threshold, false = deleted threshold
+	IsActive bool `json:"is_active"`
+}
+
 // Complete audit trail of all data changes. Automatically populated by triggers.
 type AuditLog struct {
 	ID       uuid.UUID `json:"id"`
@@ -57,6 +127,50 @@ type AuditLog struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// Monthly spending limits per category. A budget without month is the default for every month without its own budget.
+type Budget struct {
+	// UUID primary key. Generated automatically.
+	ID uuid.UUID `json:"id"`
+	// Foreign key to families. ON DELETE CASCADE.
+	FamilyID uuid.UUID `json:"family_id"`
+	// Foreign key to categories. Spending in child categories counts towards the budget of the parent.
+	CategoryID uuid.UUID `json:"category_id"`
+	// First day of the budgeted month. NULL = default budget, same every month.
+	Month pgtype.Date `json:"month"`
+	// Planned spending for the month in the family base currency. Must be positive.
+	Amount decimal.Decimal `json:"amount"`
+	// User who created the budget. NULL if user was deleted.
+	CreatedBy pgtype.UUID `json:"created_by"`
+	// Timestamp when budget was created. Set automatically.
+	CreatedAt time.Time `json:"created_at"`
+	// Timestamp of last update. Updated automatically by trigger.
+	UpdatedAt time.Time `json:"updated_at"`
+	// Soft delete flag. true = active budget, false = deleted budget (data preserved for history)
+	IsActive bool `json:"is_active"`
+	// What happens with the remainder at month end: none = dropped, carry_surplus = unspent amount added to next month, carry_all = surplus and overspending carried to next month
+	RolloverMode string `json:"rollover_mode"`
+}
+
+// Envelope budgeting: income explicitly assigned to expense categories per month.
+type BudgetAssignment struct {
+	// UUID primary key. Generated automatically.
+	ID uuid.UUID `json:"id"`
+	// Foreign key to families. ON DELETE CASCADE.
+	FamilyID uuid.UUID `json:"family_id"`
+	// Foreign key to categories. One assignment per category and month.
+	CategoryID uuid.UUID `json:"category_id"`
+	// First day of the month the money is assigned for.
+	Month pgtype.Date `json:"month"`
+	// Assigned amount in the family base currency. 0 = assignment cleared.
+	Amount decimal.Decimal `json:"amount"`
+	// User who made the assignment. NULL if user was deleted.
+	CreatedBy pgtype.UUID `json:"created_by"`
+	// Timestamp when assignment was created. Set automatically.
+	CreatedAt time.Time `json:"created_at"`
+	// Timestamp of last update. Updated automatically by trigger.
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 // Income and expense categories for transaction classification. Supports hierarchical structure (parent-child relationships).
 type Category struct {
 	// UUID primary key. Generated automatically.
@@ -77,14 +191,36 @@ type Category struct {
 	UpdatedAt time.Time `json:"updated_at"`
 	// Soft delete flag. true = active category, false = deleted category (transactions preserved)
 	IsActive bool `json:"is_active"`
+	// System category managed by the application (e.g. "Balance adjustment"). Cannot be edited or deleted by users. Transactions in system categories are excluded from spending reports.
+	IsSystem bool `json:"is_system"`
+	// Optional icon identifier used by the frontend. Examples: "cart", "car", "home"
+	Icon pgtype.Text `json:"icon"`
+	// Optional display color in hex format #RRGGBB
+	Color pgtype.Text `json:"color"`
+	// Display position among sibling categories (same parent and type). 0 = first.
+	SortOrder int32 `json:"sort_order"`
+}
+
+// ISO 4217 currencies available to accounts, transactions, goals, exchange rates and family base currency.
+type Currency struct {
+	// ISO 4217 alphabetic code, e.g. RSD, EUR, USD
+	Code string `json:"code"`
+	Name string `json:"name"`
+	// Number of decimal places of the currency (JPY = 0, EUR = 2, KWD = 3). Amounts are rounded to it. At most 4 - amount columns are DECIMAL(18, 4).
+	MinorUnits int16  `json:"minor_units"`
+	Symbol     string `json:"symbol"`
+	// Enabled currencies can be used for new accounts, transactions and goals. Disabling keeps existing data valid.
+	IsEnabled bool      `json:"is_enabled"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // Historical currency exchange rates for multi-currency support. Updated daily via external API.
 type ExchangeRate struct {
 	ID uuid.UUID `json:"id"`
-	// Source currency code. MVP: RSD or EUR only.
+	// Source currency code (ISO 4217, see currencies).
 	FromCurrency string `json:"from_currency"`
-	// Target currency code. MVP: RSD or EUR only.
+	// Target currency code (ISO 4217, see currencies).
 	ToCurrency string `json:"to_currency"`
 	// Exchange rate: 1 from_currency = rate * to_currency. Example: 1 EUR = 117.50 RSD. DECIMAL(15,6) for high precision.
 	Rate decimal.Decimal `json:"rate"`
@@ -102,7 +238,7 @@ type Family struct {
 	ID uuid.UUID `json:"id"`
 	// Family display name. Example: "Kudinov Family", "Test Family"
 	Name string `json:"name"`
-	// Base currency for financial reporting. MVP: Only RSD supported. Post-MVP: User-configurable (EUR, USD, etc.)
+	// Base currency for financial reporting, any enabled currency. transactions.amount_base is stored in this currency; changing it recomputes amount_base of all family transactions.
 	BaseCurrency string `json:"base_currency"`
 	// Timestamp when family was created. Set automatically.
 	CreatedAt time.Time `json:"created_at"`
@@ -112,6 +248,59 @@ type Family struct {
 	IsActive bool `json:"is_active"`
 }
 
+// Custom report definitions (measures, dimensions, filters, sort) saved by family members. Run on demand.
+type SavedReport struct {
+	ID          uuid.UUID   `json:"id"`
+	FamilyID    uuid.UUID   `json:"family_id"`
+	Name        string      `json:"name"`
+	Description pgtype.Text `json:"description"`
+	// Report definition as JSON: measures, dimensions, filters, sort and limit. Validated by the application.
+	Definition []byte      `json:"definition"`
+	CreatedBy  pgtype.UUID `json:"created_by"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+	// Soft delete flag. true = active report, false = deleted report
+	IsActive bool `json:"is_active"`
+}
+
+// Savings goals of a family. Progress is calculated from balances of linked accounts.
+type SavingsGoal struct {
+	// UUID primary key. Generated automatically.
+	ID uuid.UUID `json:"id"`
+	// Foreign key to families. ON DELETE CASCADE.
+	FamilyID uuid.UUID `json:"family_id"`
+	// User-defined goal name. Examples: "Vacation", "New Car", "Emergency Fund"
+	Name string `json:"name"`
+	// Amount to be saved, in goal currency. Must be positive.
+	TargetAmount decimal.Decimal `json:"target_amount"`
+	// Goal currency. Balances of linked accounts are converted to it.
+	Currency string `json:"currency"`
+	// Optional date by which the target should be reached. Used to calculate required monthly contribution.
+	Deadline pgtype.Date `json:"deadline"`
+	// Optional description/notes about the goal
+	Description pgtype.Text `json:"description"`
+	// User who created the goal. NULL if user was deleted.
+	CreatedBy pgtype.UUID `json:"created_by"`
+	// Timestamp when goal was created. Set automatically.
+	CreatedAt time.Time `json:"created_at"`
+	// Timestamp of last update. Updated automatically by trigger.
+	UpdatedAt time.Time `json:"updated_at"`
+	// Soft delete flag. true = active goal, false = deleted goal (data preserved for history)
+	IsActive bool `json:"is_active"`
+}
+
+// Accounts linked to a savings goal. Either the whole account balance or an earmarked part of it counts towards the goal.
+type SavingsGoalAccount struct {
+	// Foreign key to savings_goals. ON DELETE CASCADE.
+	GoalID uuid.UUID `json:"goal_id"`
+	// Foreign key to accounts. ON DELETE CASCADE.
+	AccountID uuid.UUID `json:"account_id"`
+	// Part of the account balance reserved for the goal, in account currency. NULL = whole balance counts towards the goal.
+	EarmarkedAmount pgtype.Numeric `json:"earmarked_amount"`
+	// Timestamp when account was linked. Set automatically.
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // Core financial transactions (income and expenses). Automatic balance calculation via trigger.
 type Transaction struct {
 	ID         uuid.UUID `json:"id"`
@@ -121,9 +310,9 @@ type Transaction struct {
 	Type       string    `json:"type"`
 	// Transaction amount in original currency. Always positive (type determines income/expense).
 	Amount decimal.Decimal `json:"amount"`
-	// Original transaction currency (RSD or EUR in MVP)
+	// Original transaction currency (ISO 4217, see currencies)
 	Currency string `json:"currency"`
-	// Amount converted to base currency (RSD) using exchange rate at transaction date. Used for reports and balance calculations.
+	// Amount converted to the family base currency using exchange rate at transaction date. Used for reports.
 	AmountBase  decimal.Decimal `json:"amount_base"`
 	Description pgtype.Text     `json:"description"`
 	// Date when transaction occurred (not when it was recorded). Cannot be in future.
@@ -135,6 +324,23 @@ type Transaction struct {
 	IsActive  bool      `json:"is_active"`
 }
 
+// Totals of active transactions per family, month, category, type and currency. Maintained by trigger on transactions; rebuild with rebuild_transaction_monthly_aggregates().
+type TransactionMonthlyAggregate struct {
+	FamilyID uuid.UUID `json:"family_id"`
+	// First day of the month of transaction_date
+	Month      pgtype.Date `json:"month"`
+	CategoryID uuid.UUID   `json:"category_id"`
+	Type       string      `json:"type"`
+	Currency   string      `json:"currency"`
+	// Sum of amount in the original currency
+	TotalAmount decimal.Decimal `json:"total_amount"`
+	// Sum of amount_base in the family base currency. Used for reports.
+	TotalBase decimal.Decimal `json:"total_base"`
+	// Number of active transactions. Rows are removed when the count drops to zero.
+	TransactionCount int32     `json:"transaction_count"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
 // User accounts for family members with authentication credentials
 type User struct {
 	// UUID primary key. Generated automatically.
@@ -9,62 +9,186 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/shopspring/decimal"
 )
 
 type Querier interface {
+	AcknowledgeAlert(ctx context.Context, arg AcknowledgeAlertParams) error
+	AddMergedBudgetAmounts(ctx context.Context, arg AddMergedBudgetAmountsParams) error
+	AddSavingsGoalAccount(ctx context.Context, arg AddSavingsGoalAccountParams) error
+	// Multiplies limit and spent amounts of raised alerts by rate when the family base currency changes
+	ConvertAlertAmounts(ctx context.Context, arg ConvertAlertAmountsParams) (int64, error)
+	// Multiplies fixed threshold limits by rate when the family base currency changes
+	ConvertAlertThresholdLimits(ctx context.Context, arg ConvertAlertThresholdLimitsParams) (int64, error)
+	// Multiplies budget amounts by rate when the family base currency changes
+	ConvertBudgetAmounts(ctx context.Context, arg ConvertBudgetAmountsParams) (int64, error)
+	// Multiplies assigned amounts by rate when the family base currency changes
+	ConvertBudgetAssignmentAmounts(ctx context.Context, arg ConvertBudgetAssignmentAmountsParams) (int64, error)
+	// Budgets, assignments, threshold limits and alerts stored in the family base currency
+	CountFamilyBaseCurrencyAmounts(ctx context.Context, familyID uuid.UUID) (int64, error)
+	CountTransactionsByCategory(ctx context.Context, categoryID uuid.UUID) (int64, error)
 	CountTransactionsByFamily(ctx context.Context, familyID uuid.UUID) (int64, error)
 	CountTransactionsFiltered(ctx context.Context, arg CountTransactionsFilteredParams) (int64, error)
+	// Transactions that cannot be converted to the base currency (no direct, inverse or cross rate up to their date)
+	CountTransactionsWithoutExchangeRate(ctx context.Context, arg CountTransactionsWithoutExchangeRateParams) (int64, error)
+	CountUnreadAlerts(ctx context.Context, arg CountUnreadAlertsParams) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (Account, error)
+	CreateAlert(ctx context.Context, arg CreateAlertParams) (int64, error)
+	CreateAlertThreshold(ctx context.Context, arg CreateAlertThresholdParams) (AlertThreshold, error)
+	CreateBudget(ctx context.Context, arg CreateBudgetParams) (Budget, error)
 	CreateCategory(ctx context.Context, arg CreateCategoryParams) (Category, error)
 	CreateExchangeRate(ctx context.Context, arg CreateExchangeRateParams) (ExchangeRate, error)
 	CreateFamily(ctx context.Context, arg CreateFamilyParams) (Family, error)
+	CreateSavedReport(ctx context.Context, arg CreateSavedReportParams) (SavedReport, error)
+	CreateSavingsGoal(ctx context.Context, arg CreateSavingsGoalParams) (SavingsGoal, error)
+	CreateSystemCategory(ctx context.Context, arg CreateSystemCategoryParams) (Category, error)
 	CreateTransaction(ctx context.Context, arg CreateTransactionParams) (Transaction, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	DeactivateMergedAlertThreshold(ctx context.Context, arg DeactivateMergedAlertThresholdParams) error
+	DeactivateMergedBudgets(ctx context.Context, arg DeactivateMergedBudgetsParams) error
 	DeleteAccount(ctx context.Context, id uuid.UUID) error
+	DeleteAccountInterestSettings(ctx context.Context, accountID uuid.UUID) error
+	DeleteAlertThreshold(ctx context.Context, id uuid.UUID) error
+	DeleteBudget(ctx context.Context, id uuid.UUID) error
+	DeleteBudgetAssignmentsByCategory(ctx context.Context, categoryID uuid.UUID) error
 	DeleteCategory(ctx context.Context, id uuid.UUID) error
 	DeleteFamily(ctx context.Context, id uuid.UUID) error
+	DeleteSavedReport(ctx context.Context, id uuid.UUID) error
+	DeleteSavingsGoal(ctx context.Context, id uuid.UUID) error
+	DeleteSavingsGoalAccounts(ctx context.Context, goalID uuid.UUID) error
 	DeleteTransaction(ctx context.Context, id uuid.UUID) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
 	GetAccount(ctx context.Context, id uuid.UUID) (Account, error)
 	GetAccountBalance(ctx context.Context, id uuid.UUID) (GetAccountBalanceRow, error)
+	GetAccountBalanceAsOf(ctx context.Context, arg GetAccountBalanceAsOfParams) (decimal.Decimal, error)
 	GetAccountIncludingInactive(ctx context.Context, id uuid.UUID) (Account, error)
+	GetAccountInterestSettings(ctx context.Context, accountID uuid.UUID) (AccountInterestSetting, error)
+	GetAccountsNetFlow(ctx context.Context, arg GetAccountsNetFlowParams) (decimal.Decimal, error)
+	GetAlert(ctx context.Context, id uuid.UUID) (Alert, error)
+	GetAlertThreshold(ctx context.Context, id uuid.UUID) (AlertThreshold, error)
+	GetBudget(ctx context.Context, id uuid.UUID) (Budget, error)
+	// Assigned in the month and in all previous months
+	GetBudgetAssignmentTotals(ctx context.Context, arg GetBudgetAssignmentTotalsParams) (GetBudgetAssignmentTotalsRow, error)
+	GetBudgetByCategoryMonth(ctx context.Context, arg GetBudgetByCategoryMonthParams) (Budget, error)
 	GetCategory(ctx context.Context, id uuid.UUID) (Category, error)
+	GetCategoryByName(ctx context.Context, arg GetCategoryByNameParams) (Category, error)
 	GetCategoryIncludingInactive(ctx context.Context, id uuid.UUID) (Category, error)
+	GetCategorySubtreeDepth(ctx context.Context, id uuid.UUID) (int32, error)
+	// Expenses of the category and all its subcategories in a period
+	GetCategorySubtreeExpenseTotal(ctx context.Context, arg GetCategorySubtreeExpenseTotalParams) (decimal.Decimal, error)
+	GetCurrency(ctx context.Context, code string) (Currency, error)
+	// Budget of the month, otherwise the default budget
+	GetEffectiveBudgetAmount(ctx context.Context, arg GetEffectiveBudgetAmountParams) (decimal.Decimal, error)
 	GetExchangeRate(ctx context.Context, arg GetExchangeRateParams) (ExchangeRate, error)
 	GetFamily(ctx context.Context, id uuid.UUID) (Family, error)
+	GetFamilyBaseCurrency(ctx context.Context, id uuid.UUID) (string, error)
 	GetFamilyByName(ctx context.Context, name string) (Family, error)
+	GetFamilyExpenseTotal(ctx context.Context, arg GetFamilyExpenseTotalParams) (decimal.Decimal, error)
+	// Income in the month and in all previous months
+	GetIncomeTotalsForMonth(ctx context.Context, arg GetIncomeTotalsForMonthParams) (GetIncomeTotalsForMonthRow, error)
 	GetLatestExchangeRate(ctx context.Context, arg GetLatestExchangeRateParams) (ExchangeRate, error)
+	// Same as GetTransactionsSummaryByCategory for whole months, read from monthly aggregates
+	GetMonthlyAggregatesSummaryByCategory(ctx context.Context, arg GetMonthlyAggregatesSummaryByCategoryParams) ([]GetMonthlyAggregatesSummaryByCategoryRow, error)
+	// Same as GetTransactionsSummaryByType for whole months, read from monthly aggregates
+	GetMonthlyAggregatesSummaryByType(ctx context.Context, arg GetMonthlyAggregatesSummaryByTypeParams) ([]GetMonthlyAggregatesSummaryByTypeRow, error)
+	GetNextCategorySortOrder(ctx context.Context, arg GetNextCategorySortOrderParams) (int32, error)
+	GetSavedReport(ctx context.Context, id uuid.UUID) (SavedReport, error)
+	GetSavingsGoal(ctx context.Context, id uuid.UUID) (SavingsGoal, error)
+	GetSystemCategory(ctx context.Context, arg GetSystemCategoryParams) (Category, error)
 	GetTotalBalanceByFamily(ctx context.Context, familyID uuid.UUID) (GetTotalBalanceByFamilyRow, error)
 	GetTransaction(ctx context.Context, id uuid.UUID) (Transaction, error)
 	GetTransactionIncludingInactive(ctx context.Context, id uuid.UUID) (Transaction, error)
+	// Transaction with names of its category, account and creator
+	GetTransactionWithDetails(ctx context.Context, id uuid.UUID) (GetTransactionWithDetailsRow, error)
+	// Totals per day of one type, filtered in index order of idx_transactions_family_date_type
+	GetTransactionsDailyTotals(ctx context.Context, arg GetTransactionsDailyTotalsParams) ([]GetTransactionsDailyTotalsRow, error)
+	GetTransactionsMonthlySummaryByCategory(ctx context.Context, arg GetTransactionsMonthlySummaryByCategoryParams) ([]GetTransactionsMonthlySummaryByCategoryRow, error)
+	// Monthly totals grouped by category, type or account in one pass
+	GetTransactionsMonthlyTrend(ctx context.Context, arg GetTransactionsMonthlyTrendParams) ([]GetTransactionsMonthlyTrendRow, error)
 	GetTransactionsSummaryByCategory(ctx context.Context, arg GetTransactionsSummaryByCategoryParams) ([]GetTransactionsSummaryByCategoryRow, error)
+	// Category summary including category names
+	GetTransactionsSummaryByCategoryWithNames(ctx context.Context, arg GetTransactionsSummaryByCategoryWithNamesParams) ([]GetTransactionsSummaryByCategoryWithNamesRow, error)
+	// Totals per family member (created_by), type and category
+	GetTransactionsSummaryByMember(ctx context.Context, arg GetTransactionsSummaryByMemberParams) ([]GetTransactionsSummaryByMemberRow, error)
 	GetTransactionsSummaryByType(ctx context.Context, arg GetTransactionsSummaryByTypeParams) ([]GetTransactionsSummaryByTypeRow, error)
+	// Totals per description (payee), case and surrounding spaces ignored
+	GetTransactionsTopDescriptions(ctx context.Context, arg GetTransactionsTopDescriptionsParams) ([]GetTransactionsTopDescriptionsRow, error)
 	GetUser(ctx context.Context, id uuid.UUID) (User, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
+	ListAccountBalancesBetween(ctx context.Context, arg ListAccountBalancesBetweenParams) ([]ListAccountBalancesBetweenRow, error)
 	ListAccountsByFamily(ctx context.Context, familyID uuid.UUID) ([]Account, error)
 	ListAccountsByType(ctx context.Context, arg ListAccountsByTypeParams) ([]Account, error)
+	ListAlertThresholdsByFamily(ctx context.Context, familyID uuid.UUID) ([]AlertThreshold, error)
+	ListAlertsForUser(ctx context.Context, arg ListAlertsForUserParams) ([]ListAlertsForUserRow, error)
 	ListAllAccountsByFamily(ctx context.Context, familyID uuid.UUID) ([]Account, error)
 	ListAllCategoriesByFamily(ctx context.Context, familyID uuid.UUID) ([]Category, error)
+	// Thresholds of the category, its ancestors and overall family spending
+	ListApplicableAlertThresholds(ctx context.Context, arg ListApplicableAlertThresholdsParams) ([]AlertThreshold, error)
+	ListBudgetAssignmentsForMonth(ctx context.Context, arg ListBudgetAssignmentsForMonthParams) ([]BudgetAssignment, error)
+	ListBudgetAssignmentsUntil(ctx context.Context, arg ListBudgetAssignmentsUntilParams) ([]BudgetAssignment, error)
+	ListBudgetsByFamily(ctx context.Context, familyID uuid.UUID) ([]Budget, error)
+	// Effective budgets: budget of the month, otherwise the default budget
+	ListBudgetsForMonth(ctx context.Context, arg ListBudgetsForMonthParams) ([]Budget, error)
 	ListCategoriesByFamily(ctx context.Context, familyID uuid.UUID) ([]Category, error)
 	ListCategoriesByType(ctx context.Context, arg ListCategoriesByTypeParams) ([]Category, error)
+	ListCategoryAncestorIDs(ctx context.Context, id uuid.UUID) ([]uuid.UUID, error)
 	ListChildCategories(ctx context.Context, parentID pgtype.UUID) ([]Category, error)
+	ListCurrencies(ctx context.Context) ([]Currency, error)
+	ListEnabledCurrencies(ctx context.Context) ([]Currency, error)
 	ListExchangeRatesByDate(ctx context.Context, date pgtype.Date) ([]ExchangeRate, error)
 	ListExchangeRatesHistory(ctx context.Context, arg ListExchangeRatesHistoryParams) ([]ExchangeRate, error)
 	ListFamilies(ctx context.Context) ([]Family, error)
+	ListInterestSettingsForPosting(ctx context.Context) ([]AccountInterestSetting, error)
+	// Largest transactions of a type in a period with category, account and creator names
+	ListLargestTransactionsWithDetails(ctx context.Context, arg ListLargestTransactionsWithDetailsParams) ([]ListLargestTransactionsWithDetailsRow, error)
+	// Aggregates differing from raw transactions (missing, extra or wrong totals); nil UUID = all families
+	ListMonthlyAggregateMismatches(ctx context.Context, familyID uuid.UUID) ([]ListMonthlyAggregateMismatchesRow, error)
 	ListRootCategories(ctx context.Context, familyID uuid.UUID) ([]Category, error)
+	ListSavedReportsByFamily(ctx context.Context, familyID uuid.UUID) ([]SavedReport, error)
+	ListSavingsGoalAccounts(ctx context.Context, goalID uuid.UUID) ([]SavingsGoalAccount, error)
+	ListSavingsGoalAccountsByFamily(ctx context.Context, familyID uuid.UUID) ([]SavingsGoalAccount, error)
+	ListSavingsGoalsByFamily(ctx context.Context, familyID uuid.UUID) ([]SavingsGoal, error)
 	ListTransactionsByAccount(ctx context.Context, accountID uuid.UUID) ([]Transaction, error)
 	ListTransactionsByCategory(ctx context.Context, categoryID uuid.UUID) ([]Transaction, error)
 	ListTransactionsByDateRange(ctx context.Context, arg ListTransactionsByDateRangeParams) ([]Transaction, error)
 	ListTransactionsByFamily(ctx context.Context, familyID uuid.UUID) ([]Transaction, error)
-	ListTransactionsFiltered(ctx context.Context, arg ListTransactionsFilteredParams) ([]Transaction, error)
+	// Next batch of filtered transactions after the given row (keyset pagination), same columns as ListTransactionsFilteredWithDetails
+	ListTransactionsFilteredAfter(ctx context.Context, arg ListTransactionsFilteredAfterParams) ([]ListTransactionsFilteredAfterRow, error)
+	// Filtered page of transactions with names of category, account and creator in one query
+	ListTransactionsFilteredWithDetails(ctx context.Context, arg ListTransactionsFilteredWithDetailsParams) ([]ListTransactionsFilteredWithDetailsRow, error)
 	ListTransactionsPaginated(ctx context.Context, arg ListTransactionsPaginatedParams) ([]Transaction, error)
 	ListUsersByFamily(ctx context.Context, familyID uuid.UUID) ([]User, error)
+	// Base currency for computing amount_base; blocks while the base currency is being changed
+	LockFamilyBaseCurrency(ctx context.Context, id uuid.UUID) (string, error)
+	MarkAlertRead(ctx context.Context, arg MarkAlertReadParams) error
+	MarkAllAlertsRead(ctx context.Context, arg MarkAllAlertsReadParams) (int64, error)
+	MergeBudgetAssignments(ctx context.Context, arg MergeBudgetAssignmentsParams) error
+	MoveChildCategories(ctx context.Context, arg MoveChildCategoriesParams) (int64, error)
+	ReassignAlertThresholdsCategory(ctx context.Context, arg ReassignAlertThresholdsCategoryParams) error
+	ReassignAlertsCategory(ctx context.Context, arg ReassignAlertsCategoryParams) error
+	ReassignBudgetsCategory(ctx context.Context, arg ReassignBudgetsCategoryParams) error
+	ReassignInterestIncomeCategory(ctx context.Context, arg ReassignInterestIncomeCategoryParams) error
+	ReassignTransactionsCategory(ctx context.Context, arg ReassignTransactionsCategoryParams) (int64, error)
+	// Recalculates aggregates from raw transactions; nil UUID = all families
+	RebuildMonthlyAggregates(ctx context.Context, familyID uuid.UUID) (int32, error)
+	// Converts amount to the base currency with the latest rate up to the transaction date (direct, inverse or cross rate through EUR or RSD), rounded to its minor units
+	RecomputeTransactionsAmountBase(ctx context.Context, arg RecomputeTransactionsAmountBaseParams) (int64, error)
+	SetCategoryParent(ctx context.Context, arg SetCategoryParentParams) (Category, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (Account, error)
+	UpdateAccountInitialBalance(ctx context.Context, arg UpdateAccountInitialBalanceParams) (Account, error)
+	UpdateAlertThreshold(ctx context.Context, arg UpdateAlertThresholdParams) (AlertThreshold, error)
+	UpdateBudget(ctx context.Context, arg UpdateBudgetParams) (Budget, error)
 	UpdateCategory(ctx context.Context, arg UpdateCategoryParams) (Category, error)
+	UpdateCategorySortOrders(ctx context.Context, ids []uuid.UUID) (int64, error)
 	UpdateFamily(ctx context.Context, arg UpdateFamilyParams) (Family, error)
+	UpdateInterestLastPostedDate(ctx context.Context, arg UpdateInterestLastPostedDateParams) (int64, error)
+	UpdateSavedReport(ctx context.Context, arg UpdateSavedReportParams) (SavedReport, error)
+	UpdateSavingsGoal(ctx context.Context, arg UpdateSavingsGoalParams) (SavingsGoal, error)
 	UpdateTransaction(ctx context.Context, arg UpdateTransactionParams) (Transaction, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	UpsertAccountInterestSettings(ctx context.Context, arg UpsertAccountInterestSettingsParams) (AccountInterestSetting, error)
+	UpsertBudgetAssignment(ctx context.Context, arg UpsertBudgetAssignmentParams) (BudgetAssignment, error)
 	UpsertExchangeRate(ctx context.Context, arg UpsertExchangeRateParams) (ExchangeRate, error)
 }
 
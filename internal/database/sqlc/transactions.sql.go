@@ -7,12 +7,26 @@ package sqlc
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 )
 
+const countTransactionsByCategory = `-- name: CountTransactionsByCategory :one
+SELECT COUNT(*) as total
+FROM transactions
+WHERE category_id = $1 AND is_active = true
+`
+
+func (q *Queries) CountTransactionsByCategory(ctx context.Context, categoryID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countTransactionsByCategory, categoryID)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
 const countTransactionsByFamily = `-- name: CountTransactionsByFamily :one
 SELECT COUNT(*) as total
 FROM transactions
@@ -58,6 +72,26 @@ func (q *Queries) CountTransactionsFiltered(ctx context.Context, arg CountTransa
 	return total, err
 }
 
+const countTransactionsWithoutExchangeRate = `-- name: CountTransactionsWithoutExchangeRate :one
+SELECT COUNT(*) AS total
+FROM transactions t
+WHERE t.family_id = $1
+  AND find_exchange_rate(t.currency, $2::text, t.transaction_date) IS NULL
+`
+
+type CountTransactionsWithoutExchangeRateParams struct {
+	FamilyID     uuid.UUID `json:"family_id"`
+	BaseCurrency string    `json:"base_currency"`
+}
+
+// Transactions that cannot be converted to the base currency (no direct, inverse or cross rate up to their date)
+func (q *Queries) CountTransactionsWithoutExchangeRate(ctx context.Context, arg CountTransactionsWithoutExchangeRateParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countTransactionsWithoutExchangeRate, arg.FamilyID, arg.BaseCurrency)
+	var total int64
+	err := row.Scan(&total)
+	return total, err
+}
+
 const createTransaction = `-- name: CreateTransaction :one
 INSERT INTO transactions (
     id, family_id, account_id, category_id, type,
@@ -127,6 +161,37 @@ func (q *Queries) DeleteTransaction(ctx context.Context, id uuid.UUID) error {
 	return err
 }
 
+const getIncomeTotalsForMonth = `-- name: GetIncomeTotalsForMonth :one
+SELECT
+    COALESCE(SUM(amount_base) FILTER (WHERE transaction_date >= $1), 0)::numeric AS month_total,
+    COALESCE(SUM(amount_base) FILTER (WHERE transaction_date < $1), 0)::numeric AS previous_total
+FROM transactions
+WHERE family_id = $2
+  AND type = 'income'
+  AND transaction_date <= $3
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+`
+
+type GetIncomeTotalsForMonthParams struct {
+	MonthStart pgtype.Date `json:"month_start"`
+	FamilyID   uuid.UUID   `json:"family_id"`
+	MonthEnd   pgtype.Date `json:"month_end"`
+}
+
+type GetIncomeTotalsForMonthRow struct {
+	MonthTotal    decimal.Decimal `json:"month_total"`
+	PreviousTotal decimal.Decimal `json:"previous_total"`
+}
+
+// Income in the month and in all previous months
+func (q *Queries) GetIncomeTotalsForMonth(ctx context.Context, arg GetIncomeTotalsForMonthParams) (GetIncomeTotalsForMonthRow, error) {
+	row := q.db.QueryRow(ctx, getIncomeTotalsForMonth, arg.MonthStart, arg.FamilyID, arg.MonthEnd)
+	var i GetIncomeTotalsForMonthRow
+	err := row.Scan(&i.MonthTotal, &i.PreviousTotal)
+	return i, err
+}
+
 const getTransaction = `-- name: GetTransaction :one
 SELECT id, family_id, account_id, category_id, type, amount, currency, amount_base, description, transaction_date, created_by, created_at, updated_at, is_active FROM transactions
 WHERE id = $1 AND is_active = true
@@ -181,6 +246,255 @@ func (q *Queries) GetTransactionIncludingInactive(ctx context.Context, id uuid.U
 	return i, err
 }
 
+const getTransactionWithDetails = `-- name: GetTransactionWithDetails :one
+SELECT
+    t.id, t.family_id, t.account_id, t.category_id, t.type, t.amount, t.currency, t.amount_base, t.description, t.transaction_date, t.created_by, t.created_at, t.updated_at, t.is_active,
+    c.name AS category_name,
+    c.type AS category_type,
+    a.name AS account_name,
+    a.type AS account_type,
+    u.name AS created_by_name
+FROM transactions t
+JOIN categories c ON c.id = t.category_id
+JOIN accounts a ON a.id = t.account_id
+JOIN users u ON u.id = t.created_by
+WHERE t.id = $1 AND t.is_active = true
+`
+
+type GetTransactionWithDetailsRow struct {
+	ID              uuid.UUID       `json:"id"`
+	FamilyID        uuid.UUID       `json:"family_id"`
+	AccountID       uuid.UUID       `json:"account_id"`
+	CategoryID      uuid.UUID       `json:"category_id"`
+	Type            string          `json:"type"`
+	Amount          decimal.Decimal `json:"amount"`
+	Currency        string          `json:"currency"`
+	AmountBase      decimal.Decimal `json:"amount_base"`
+	Description     pgtype.Text     `json:"description"`
+	TransactionDate pgtype.Date     `json:"transaction_date"`
+	CreatedBy       uuid.UUID       `json:"created_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	IsActive        bool            `json:"is_active"`
+	CategoryName    string          `json:"category_name"`
+	CategoryType    string          `json:"category_type"`
+	AccountName     string          `json:"account_name"`
+	AccountType     string          `json:"account_type"`
+	CreatedByName   string          `json:"created_by_name"`
+}
+
+// Transaction with names of its category, account and creator
+func (q *Queries) GetTransactionWithDetails(ctx context.Context, id uuid.UUID) (GetTransactionWithDetailsRow, error) {
+	row := q.db.QueryRow(ctx, getTransactionWithDetails, id)
+	var i GetTransactionWithDetailsRow
+	err := row.Scan(
+		&i.ID,
+		&i.FamilyID,
+		&i.AccountID,
+		&i.CategoryID,
+		&i.Type,
+		&i.Amount,
+		&i.Currency,
+		&i.AmountBase,
+		&i.Description,
+		&i.TransactionDate,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.IsActive,
+		&i.CategoryName,
+		&i.CategoryType,
+		&i.AccountName,
+		&i.AccountType,
+		&i.CreatedByName,
+	)
+	return i, err
+}
+
+const getTransactionsDailyTotals = `-- name: GetTransactionsDailyTotals :many
+SELECT
+    transaction_date AS day,
+    COUNT(*) AS count,
+    COALESCE(SUM(amount_base), 0)::numeric AS total
+FROM transactions
+WHERE family_id = $1
+  AND transaction_date >= $2
+  AND transaction_date <= $3
+  AND type = $4
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+GROUP BY transaction_date
+ORDER BY transaction_date
+`
+
+type GetTransactionsDailyTotalsParams struct {
+	FamilyID  uuid.UUID   `json:"family_id"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+	Type      string      `json:"type"`
+}
+
+type GetTransactionsDailyTotalsRow struct {
+	Day   pgtype.Date     `json:"day"`
+	Count int64           `json:"count"`
+	Total decimal.Decimal `json:"total"`
+}
+
+// Totals per day of one type, filtered in index order of idx_transactions_family_date_type
+func (q *Queries) GetTransactionsDailyTotals(ctx context.Context, arg GetTransactionsDailyTotalsParams) ([]GetTransactionsDailyTotalsRow, error) {
+	rows, err := q.db.Query(ctx, getTransactionsDailyTotals,
+		arg.FamilyID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Type,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTransactionsDailyTotalsRow{}
+	for rows.Next() {
+		var i GetTransactionsDailyTotalsRow
+		if err := rows.Scan(&i.Day, &i.Count, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransactionsMonthlySummaryByCategory = `-- name: GetTransactionsMonthlySummaryByCategory :many
+SELECT
+    date_trunc('month', transaction_date)::date as month,
+    category_id,
+    COUNT(*) as count,
+    COALESCE(SUM(amount_base), 0)::numeric as total
+FROM transactions
+WHERE family_id = $1
+  AND type = $2
+  AND transaction_date >= $3
+  AND transaction_date <= $4
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+GROUP BY date_trunc('month', transaction_date), category_id
+ORDER BY month
+`
+
+type GetTransactionsMonthlySummaryByCategoryParams struct {
+	FamilyID          uuid.UUID   `json:"family_id"`
+	Type              string      `json:"type"`
+	TransactionDate   pgtype.Date `json:"transaction_date"`
+	TransactionDate_2 pgtype.Date `json:"transaction_date_2"`
+}
+
+type GetTransactionsMonthlySummaryByCategoryRow struct {
+	Month      pgtype.Date     `json:"month"`
+	CategoryID uuid.UUID       `json:"category_id"`
+	Count      int64           `json:"count"`
+	Total      decimal.Decimal `json:"total"`
+}
+
+func (q *Queries) GetTransactionsMonthlySummaryByCategory(ctx context.Context, arg GetTransactionsMonthlySummaryByCategoryParams) ([]GetTransactionsMonthlySummaryByCategoryRow, error) {
+	rows, err := q.db.Query(ctx, getTransactionsMonthlySummaryByCategory,
+		arg.FamilyID,
+		arg.Type,
+		arg.TransactionDate,
+		arg.TransactionDate_2,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTransactionsMonthlySummaryByCategoryRow{}
+	for rows.Next() {
+		var i GetTransactionsMonthlySummaryByCategoryRow
+		if err := rows.Scan(
+			&i.Month,
+			&i.CategoryID,
+			&i.Count,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransactionsMonthlyTrend = `-- name: GetTransactionsMonthlyTrend :many
+SELECT
+    date_trunc('month', transaction_date)::date AS month,
+    (CASE $1::text
+        WHEN 'category' THEN category_id::text
+        WHEN 'account' THEN account_id::text
+        ELSE type
+    END)::text AS group_key,
+    COUNT(*) AS count,
+    COALESCE(SUM(amount_base), 0)::numeric AS total
+FROM transactions
+WHERE family_id = $2
+  AND transaction_date >= $3
+  AND transaction_date <= $4
+  AND ($5::text IS NULL OR type = $5)
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+GROUP BY 1, 2
+ORDER BY 1, 2
+`
+
+type GetTransactionsMonthlyTrendParams struct {
+	GroupBy   string      `json:"group_by"`
+	FamilyID  uuid.UUID   `json:"family_id"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+	Type      pgtype.Text `json:"type"`
+}
+
+type GetTransactionsMonthlyTrendRow struct {
+	Month    pgtype.Date     `json:"month"`
+	GroupKey string          `json:"group_key"`
+	Count    int64           `json:"count"`
+	Total    decimal.Decimal `json:"total"`
+}
+
+// Monthly totals grouped by category, type or account in one pass
+func (q *Queries) GetTransactionsMonthlyTrend(ctx context.Context, arg GetTransactionsMonthlyTrendParams) ([]GetTransactionsMonthlyTrendRow, error) {
+	rows, err := q.db.Query(ctx, getTransactionsMonthlyTrend,
+		arg.GroupBy,
+		arg.FamilyID,
+		arg.StartDate,
+		arg.EndDate,
+		arg.Type,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTransactionsMonthlyTrendRow{}
+	for rows.Next() {
+		var i GetTransactionsMonthlyTrendRow
+		if err := rows.Scan(
+			&i.Month,
+			&i.GroupKey,
+			&i.Count,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTransactionsSummaryByCategory = `-- name: GetTransactionsSummaryByCategory :many
 SELECT
     category_id,
@@ -192,6 +506,7 @@ WHERE family_id = $1
   AND transaction_date >= $3
   AND transaction_date <= $4
   AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
 GROUP BY category_id
 ORDER BY total DESC
 `
@@ -234,6 +549,127 @@ func (q *Queries) GetTransactionsSummaryByCategory(ctx context.Context, arg GetT
 	return items, nil
 }
 
+const getTransactionsSummaryByCategoryWithNames = `-- name: GetTransactionsSummaryByCategoryWithNames :many
+SELECT
+    t.category_id,
+    c.name AS category_name,
+    COUNT(*) AS count,
+    COALESCE(SUM(t.amount_base), 0)::numeric AS total
+FROM transactions t
+JOIN categories c ON c.id = t.category_id
+WHERE t.family_id = $1
+  AND t.type = $2
+  AND t.transaction_date >= $3
+  AND t.transaction_date <= $4
+  AND t.is_active = true
+  AND c.is_system = false
+GROUP BY t.category_id, c.name
+ORDER BY total DESC
+`
+
+type GetTransactionsSummaryByCategoryWithNamesParams struct {
+	FamilyID  uuid.UUID   `json:"family_id"`
+	Type      string      `json:"type"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+}
+
+type GetTransactionsSummaryByCategoryWithNamesRow struct {
+	CategoryID   uuid.UUID       `json:"category_id"`
+	CategoryName string          `json:"category_name"`
+	Count        int64           `json:"count"`
+	Total        decimal.Decimal `json:"total"`
+}
+
+// Category summary including category names
+func (q *Queries) GetTransactionsSummaryByCategoryWithNames(ctx context.Context, arg GetTransactionsSummaryByCategoryWithNamesParams) ([]GetTransactionsSummaryByCategoryWithNamesRow, error) {
+	rows, err := q.db.Query(ctx, getTransactionsSummaryByCategoryWithNames,
+		arg.FamilyID,
+		arg.Type,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTransactionsSummaryByCategoryWithNamesRow{}
+	for rows.Next() {
+		var i GetTransactionsSummaryByCategoryWithNamesRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.Count,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTransactionsSummaryByMember = `-- name: GetTransactionsSummaryByMember :many
+SELECT
+    created_by,
+    type,
+    category_id,
+    COUNT(*) AS count,
+    COALESCE(SUM(amount_base), 0)::numeric AS total
+FROM transactions
+WHERE family_id = $1
+  AND transaction_date >= $2
+  AND transaction_date <= $3
+  AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+GROUP BY created_by, type, category_id
+ORDER BY created_by, type, total DESC
+`
+
+type GetTransactionsSummaryByMemberParams struct {
+	FamilyID  uuid.UUID   `json:"family_id"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+}
+
+type GetTransactionsSummaryByMemberRow struct {
+	CreatedBy  uuid.UUID       `json:"created_by"`
+	Type       string          `json:"type"`
+	CategoryID uuid.UUID       `json:"category_id"`
+	Count      int64           `json:"count"`
+	Total      decimal.Decimal `json:"total"`
+}
+
+// Totals per family member (created_by), type and category
+func (q *Queries) GetTransactionsSummaryByMember(ctx context.Context, arg GetTransactionsSummaryByMemberParams) ([]GetTransactionsSummaryByMemberRow, error) {
+	rows, err := q.db.Query(ctx, getTransactionsSummaryByMember, arg.FamilyID, arg.StartDate, arg.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTransactionsSummaryByMemberRow{}
+	for rows.Next() {
+		var i GetTransactionsSummaryByMemberRow
+		if err := rows.Scan(
+			&i.CreatedBy,
+			&i.Type,
+			&i.CategoryID,
+			&i.Count,
+			&i.Total,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTransactionsSummaryByType = `-- name: GetTransactionsSummaryByType :many
 SELECT
     type,
@@ -244,6 +680,7 @@ WHERE family_id = $1
   AND transaction_date >= $2
   AND transaction_date <= $3
   AND is_active = true
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
 GROUP BY type
 `
 
@@ -279,6 +716,164 @@ func (q *Queries) GetTransactionsSummaryByType(ctx context.Context, arg GetTrans
 	return items, nil
 }
 
+const getTransactionsTopDescriptions = `-- name: GetTransactionsTopDescriptions :many
+SELECT
+    (array_agg(trim(description) ORDER BY transaction_date DESC))[1]::text AS description,
+    COUNT(*) AS count,
+    COALESCE(SUM(amount_base), 0)::numeric AS total
+FROM transactions
+WHERE family_id = $1
+  AND type = $2
+  AND transaction_date >= $3
+  AND transaction_date <= $4
+  AND is_active = true
+  AND trim(COALESCE(description, '')) <> ''
+  AND category_id NOT IN (SELECT id FROM categories WHERE is_system = true)
+GROUP BY lower(trim(description))
+ORDER BY total DESC
+LIMIT $5
+`
+
+type GetTransactionsTopDescriptionsParams struct {
+	FamilyID  uuid.UUID   `json:"family_id"`
+	Type      string      `json:"type"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+	RowLimit  int32       `json:"row_limit"`
+}
+
+type GetTransactionsTopDescriptionsRow struct {
+	Description string          `json:"description"`
+	Count       int64           `json:"count"`
+	Total       decimal.Decimal `json:"total"`
+}
+
+// Totals per description (payee), case and surrounding spaces ignored
+func (q *Queries) GetTransactionsTopDescriptions(ctx context.Context, arg GetTransactionsTopDescriptionsParams) ([]GetTransactionsTopDescriptionsRow, error) {
+	rows, err := q.db.Query(ctx, getTransactionsTopDescriptions,
+		arg.FamilyID,
+		arg.Type,
+		arg.StartDate,
+		arg.EndDate,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTransactionsTopDescriptionsRow{}
+	for rows.Next() {
+		var i GetTransactionsTopDescriptionsRow
+		if err := rows.Scan(&i.Description, &i.Count, &i.Total); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLargestTransactionsWithDetails = `-- name: ListLargestTransactionsWithDetails :many
+SELECT
+    t.id, t.family_id, t.account_id, t.category_id, t.type, t.amount, t.currency, t.amount_base, t.description, t.transaction_date, t.created_by, t.created_at, t.updated_at, t.is_active,
+    c.name AS category_name,
+    c.type AS category_type,
+    a.name AS account_name,
+    a.type AS account_type,
+    u.name AS created_by_name
+FROM transactions t
+JOIN categories c ON c.id = t.category_id
+JOIN accounts a ON a.id = t.account_id
+JOIN users u ON u.id = t.created_by
+WHERE t.family_id = $1
+  AND t.type = $2
+  AND t.transaction_date >= $3
+  AND t.transaction_date <= $4
+  AND t.is_active = true
+  AND c.is_system = false
+ORDER BY t.amount_base DESC, t.transaction_date DESC
+LIMIT $5
+`
+
+type ListLargestTransactionsWithDetailsParams struct {
+	FamilyID  uuid.UUID   `json:"family_id"`
+	Type      string      `json:"type"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+	RowLimit  int32       `json:"row_limit"`
+}
+
+type ListLargestTransactionsWithDetailsRow struct {
+	ID              uuid.UUID       `json:"id"`
+	FamilyID        uuid.UUID       `json:"family_id"`
+	AccountID       uuid.UUID       `json:"account_id"`
+	CategoryID      uuid.UUID       `json:"category_id"`
+	Type            string          `json:"type"`
+	Amount          decimal.Decimal `json:"amount"`
+	Currency        string          `json:"currency"`
+	AmountBase      decimal.Decimal `json:"amount_base"`
+	Description     pgtype.Text     `json:"description"`
+	TransactionDate pgtype.Date     `json:"transaction_date"`
+	CreatedBy       uuid.UUID       `json:"created_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	IsActive        bool            `json:"is_active"`
+	CategoryName    string          `json:"category_name"`
+	CategoryType    string          `json:"category_type"`
+	AccountName     string          `json:"account_name"`
+	AccountType     string          `json:"account_type"`
+	CreatedByName   string          `json:"created_by_name"`
+}
+
+// Largest transactions of a type in a period with category, account and creator names
+func (q *Queries) ListLargestTransactionsWithDetails(ctx context.Context, arg ListLargestTransactionsWithDetailsParams) ([]ListLargestTransactionsWithDetailsRow, error) {
+	rows, err := q.db.Query(ctx, listLargestTransactionsWithDetails,
+		arg.FamilyID,
+		arg.Type,
+		arg.StartDate,
+		arg.EndDate,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListLargestTransactionsWithDetailsRow{}
+	for rows.Next() {
+		var i ListLargestTransactionsWithDetailsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FamilyID,
+			&i.AccountID,
+			&i.CategoryID,
+			&i.Type,
+			&i.Amount,
+			&i.Currency,
+			&i.AmountBase,
+			&i.Description,
+			&i.TransactionDate,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsActive,
+			&i.CategoryName,
+			&i.CategoryType,
+			&i.AccountName,
+			&i.AccountType,
+			&i.CreatedByName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTransactionsByAccount = `-- name: ListTransactionsByAccount :many
 SELECT id, family_id, account_id, category_id, type, amount, currency, amount_base, description, transaction_date, created_by, created_at, updated_at, is_active FROM transactions
 WHERE account_id = $1 AND is_active = true
@@ -452,45 +1047,187 @@ func (q *Queries) ListTransactionsByFamily(ctx context.Context, familyID uuid.UU
 	return items, nil
 }
 
-const listTransactionsFiltered = `-- name: ListTransactionsFiltered :many
-SELECT id, family_id, account_id, category_id, type, amount, currency, amount_base, description, transaction_date, created_by, created_at, updated_at, is_active FROM transactions
-WHERE family_id = $1
-  AND is_active = true
-  AND ($2 = '' OR type = $2)
-  AND ($3 = '00000000-0000-0000-0000-000000000000'::uuid OR account_id = $3)
-  AND ($4::date IS NULL OR transaction_date >= $4)
-  AND ($5::date IS NULL OR transaction_date <= $5)
-ORDER BY transaction_date DESC, created_at DESC
+const listTransactionsFilteredAfter = `-- name: ListTransactionsFilteredAfter :many
+SELECT
+    t.id, t.family_id, t.account_id, t.category_id, t.type, t.amount, t.currency, t.amount_base, t.description, t.transaction_date, t.created_by, t.created_at, t.updated_at, t.is_active,
+    c.name AS category_name,
+    c.type AS category_type,
+    a.name AS account_name,
+    a.type AS account_type,
+    u.name AS created_by_name
+FROM transactions t
+JOIN categories c ON c.id = t.category_id
+JOIN accounts a ON a.id = t.account_id
+JOIN users u ON u.id = t.created_by
+WHERE t.family_id = $1
+  AND t.is_active = true
+  AND ($2::text = '' OR t.type = $2)
+  AND ($3::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR t.account_id = $3)
+  AND ($4::date IS NULL OR t.transaction_date >= $4)
+  AND ($5::date IS NULL OR t.transaction_date <= $5)
+  AND ($6::uuid IS NULL
+    OR (t.transaction_date, t.created_at, t.id) < ($7::date, $8::timestamp, $6::uuid))
+ORDER BY t.transaction_date DESC, t.created_at DESC, t.id DESC
+LIMIT $9
+`
+
+type ListTransactionsFilteredAfterParams struct {
+	FamilyID       uuid.UUID        `json:"family_id"`
+	TypeFilter     string           `json:"type_filter"`
+	AccountFilter  uuid.UUID        `json:"account_filter"`
+	StartDate      pgtype.Date      `json:"start_date"`
+	EndDate        pgtype.Date      `json:"end_date"`
+	AfterID        pgtype.UUID      `json:"after_id"`
+	AfterDate      pgtype.Date      `json:"after_date"`
+	AfterCreatedAt pgtype.Timestamp `json:"after_created_at"`
+	RowLimit       int32            `json:"row_limit"`
+}
+
+type ListTransactionsFilteredAfterRow struct {
+	ID              uuid.UUID       `json:"id"`
+	FamilyID        uuid.UUID       `json:"family_id"`
+	AccountID       uuid.UUID       `json:"account_id"`
+	CategoryID      uuid.UUID       `json:"category_id"`
+	Type            string          `json:"type"`
+	Amount          decimal.Decimal `json:"amount"`
+	Currency        string          `json:"currency"`
+	AmountBase      decimal.Decimal `json:"amount_base"`
+	Description     pgtype.Text     `json:"description"`
+	TransactionDate pgtype.Date     `json:"transaction_date"`
+	CreatedBy       uuid.UUID       `json:"created_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	IsActive        bool            `json:"is_active"`
+	CategoryName    string          `json:"category_name"`
+	CategoryType    string          `json:"category_type"`
+	AccountName     string          `json:"account_name"`
+	AccountType     string          `json:"account_type"`
+	CreatedByName   string          `json:"created_by_name"`
+}
+
+// Next batch of filtered transactions after the given row (keyset pagination), same columns as ListTransactionsFilteredWithDetails
+func (q *Queries) ListTransactionsFilteredAfter(ctx context.Context, arg ListTransactionsFilteredAfterParams) ([]ListTransactionsFilteredAfterRow, error) {
+	rows, err := q.db.Query(ctx, listTransactionsFilteredAfter,
+		arg.FamilyID,
+		arg.TypeFilter,
+		arg.AccountFilter,
+		arg.StartDate,
+		arg.EndDate,
+		arg.AfterID,
+		arg.AfterDate,
+		arg.AfterCreatedAt,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTransactionsFilteredAfterRow{}
+	for rows.Next() {
+		var i ListTransactionsFilteredAfterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FamilyID,
+			&i.AccountID,
+			&i.CategoryID,
+			&i.Type,
+			&i.Amount,
+			&i.Currency,
+			&i.AmountBase,
+			&i.Description,
+			&i.TransactionDate,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.IsActive,
+			&i.CategoryName,
+			&i.CategoryType,
+			&i.AccountName,
+			&i.AccountType,
+			&i.CreatedByName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTransactionsFilteredWithDetails = `-- name: ListTransactionsFilteredWithDetails :many
+SELECT
+    t.id, t.family_id, t.account_id, t.category_id, t.type, t.amount, t.currency, t.amount_base, t.description, t.transaction_date, t.created_by, t.created_at, t.updated_at, t.is_active,
+    c.name AS category_name,
+    c.type AS category_type,
+    a.name AS account_name,
+    a.type AS account_type,
+    u.name AS created_by_name
+FROM transactions t
+JOIN categories c ON c.id = t.category_id
+JOIN accounts a ON a.id = t.account_id
+JOIN users u ON u.id = t.created_by
+WHERE t.family_id = $1
+  AND t.is_active = true
+  AND ($2::text = '' OR t.type = $2)
+  AND ($3::uuid = '00000000-0000-0000-0000-000000000000'::uuid OR t.account_id = $3)
+  AND ($4::date IS NULL OR t.transaction_date >= $4)
+  AND ($5::date IS NULL OR t.transaction_date <= $5)
+ORDER BY t.transaction_date DESC, t.created_at DESC, t.id DESC
 LIMIT $6 OFFSET $7
 `
 
-type ListTransactionsFilteredParams struct {
-	FamilyID uuid.UUID   `json:"family_id"`
-	Column2  interface{} `json:"column_2"`
-	Column3  interface{} `json:"column_3"`
-	Column4  pgtype.Date `json:"column_4"`
-	Column5  pgtype.Date `json:"column_5"`
-	Limit    int32       `json:"limit"`
-	Offset   int32       `json:"offset"`
+type ListTransactionsFilteredWithDetailsParams struct {
+	FamilyID      uuid.UUID   `json:"family_id"`
+	TypeFilter    string      `json:"type_filter"`
+	AccountFilter uuid.UUID   `json:"account_filter"`
+	StartDate     pgtype.Date `json:"start_date"`
+	EndDate       pgtype.Date `json:"end_date"`
+	RowLimit      int32       `json:"row_limit"`
+	RowOffset     int32       `json:"row_offset"`
 }
 
-func (q *Queries) ListTransactionsFiltered(ctx context.Context, arg ListTransactionsFilteredParams) ([]Transaction, error) {
-	rows, err := q.db.Query(ctx, listTransactionsFiltered,
+type ListTransactionsFilteredWithDetailsRow struct {
+	ID              uuid.UUID       `json:"id"`
+	FamilyID        uuid.UUID       `json:"family_id"`
+	AccountID       uuid.UUID       `json:"account_id"`
+	CategoryID      uuid.UUID       `json:"category_id"`
+	Type            string          `json:"type"`
+	Amount          decimal.Decimal `json:"amount"`
+	Currency        string          `json:"currency"`
+	AmountBase      decimal.Decimal `json:"amount_base"`
+	Description     pgtype.Text     `json:"description"`
+	TransactionDate pgtype.Date     `json:"transaction_date"`
+	CreatedBy       uuid.UUID       `json:"created_by"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+	IsActive        bool            `json:"is_active"`
+	CategoryName    string          `json:"category_name"`
+	CategoryType    string          `json:"category_type"`
+	AccountName     string          `json:"account_name"`
+	AccountType     string          `json:"account_type"`
+	CreatedByName   string          `json:"created_by_name"`
+}
+
+// Filtered page of transactions with names of category, account and creator in one query
+func (q *Queries) ListTransactionsFilteredWithDetails(ctx context.Context, arg ListTransactionsFilteredWithDetailsParams) ([]ListTransactionsFilteredWithDetailsRow, error) {
+	rows, err := q.db.Query(ctx, listTransactionsFilteredWithDetails,
 		arg.FamilyID,
-		arg.Column2,
-		arg.Column3,
-		arg.Column4,
-		arg.Column5,
-		arg.Limit,
-		arg.Offset,
+		arg.TypeFilter,
+		arg.AccountFilter,
+		arg.StartDate,
+		arg.EndDate,
+		arg.RowLimit,
+		arg.RowOffset,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	items := []Transaction{}
+	items := []ListTransactionsFilteredWithDetailsRow{}
 	for rows.Next() {
-		var i Transaction
+		var i ListTransactionsFilteredWithDetailsRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.FamilyID,
@@ -506,6 +1243,11 @@ func (q *Queries) ListTransactionsFiltered(ctx context.Context, arg ListTransact
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.IsActive,
+			&i.CategoryName,
+			&i.CategoryType,
+			&i.AccountName,
+			&i.AccountType,
+			&i.CreatedByName,
 		); err != nil {
 			return nil, err
 		}
@@ -565,6 +1307,52 @@ func (q *Queries) ListTransactionsPaginated(ctx context.Context, arg ListTransac
 	return items, nil
 }
 
+const reassignTransactionsCategory = `-- name: ReassignTransactionsCategory :execrows
+UPDATE transactions
+SET category_id = $1, updated_at = NOW()
+WHERE category_id = $2
+`
+
+type ReassignTransactionsCategoryParams struct {
+	TargetCategoryID uuid.UUID `json:"target_category_id"`
+	SourceCategoryID uuid.UUID `json:"source_category_id"`
+}
+
+func (q *Queries) ReassignTransactionsCategory(ctx context.Context, arg ReassignTransactionsCategoryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reassignTransactionsCategory, arg.TargetCategoryID, arg.SourceCategoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const recomputeTransactionsAmountBase = `-- name: RecomputeTransactionsAmountBase :execrows
+UPDATE transactions t
+SET amount_base = CASE
+        WHEN t.currency = $1::text THEN t.amount
+        ELSE GREATEST(ROUND(t.amount * find_exchange_rate(t.currency, $1::text, t.transaction_date),
+            c.minor_units), power(10::numeric, -c.minor_units))
+    END,
+    updated_at = NOW()
+FROM currencies c
+WHERE c.code = $1::text
+  AND t.family_id = $2
+`
+
+type RecomputeTransactionsAmountBaseParams struct {
+	BaseCurrency string    `json:"base_currency"`
+	FamilyID     uuid.UUID `json:"family_id"`
+}
+
+// Converts amount to the base currency with the latest rate up to the transaction date (direct, inverse or cross rate through EUR or RSD), rounded to its minor units
+func (q *Queries) RecomputeTransactionsAmountBase(ctx context.Context, arg RecomputeTransactionsAmountBaseParams) (int64, error) {
+	result, err := q.db.Exec(ctx, recomputeTransactionsAmountBase, arg.BaseCurrency, arg.FamilyID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateTransaction = `-- name: UpdateTransaction :one
 UPDATE transactions
 SET
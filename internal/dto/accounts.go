@@ -12,14 +12,44 @@ import (
 type CreateAccountRequest struct {
 	Name           string          `json:"name" validate:"required,min=1,max=100"`
 	Type           string          `json:"type" validate:"required,oneof=cash checking savings"`
-	Currency       string          `json:"currency" validate:"required,oneof=RSD EUR"`
+	Currency       string          `json:"currency" validate:"required,currency"`
 	InitialBalance decimal.Decimal `json:"initial_balance"`
 }
 
 // UpdateAccountRequest - запрос на обновление счёта (partial)
 type UpdateAccountRequest struct {
-	Name     *string `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
-	IsActive *bool   `json:"is_active,omitempty"`
+	Name           *string          `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	IsActive       *bool            `json:"is_active,omitempty"`
+	InitialBalance *decimal.Decimal `json:"initial_balance,omitempty"`
+}
+
+// AdjustBalanceRequest - запрос на корректировку баланса счёта на дату
+type AdjustBalanceRequest struct {
+	TargetBalance *decimal.Decimal `json:"target_balance" validate:"required"`
+	Date          string           `json:"date,omitempty"` // YYYY-MM-DD, default: today
+}
+
+// ParseDate returns the adjustment date, today if not set
+func (r *AdjustBalanceRequest) ParseDate() (time.Time, []ValidationError) {
+	if r.Date == "" {
+		return time.Now(), nil
+	}
+
+	date, err := time.Parse("2006-01-02", r.Date)
+	if err != nil {
+		return time.Time{}, []ValidationError{{
+			Field:   "date",
+			Message: "Invalid date format, use YYYY-MM-DD",
+		}}
+	}
+	if date.After(time.Now()) {
+		return time.Time{}, []ValidationError{{
+			Field:   "date",
+			Message: "Adjustment date cannot be in the future",
+		}}
+	}
+
+	return date, nil
 }
 
 // --- Responses ---
@@ -51,3 +81,13 @@ type AccountBalanceResponse struct {
 	BalanceDate         time.Time       `json:"balance_date"`
 	LastTransactionDate *time.Time      `json:"last_transaction_date,omitempty"`
 }
+
+// BalanceAdjustmentResponse - результат корректировки баланса
+type BalanceAdjustmentResponse struct {
+	Account         AccountResponse `json:"account"`
+	TransactionID   uuid.UUID       `json:"transaction_id"`
+	Date            string          `json:"date"`
+	PreviousBalance decimal.Decimal `json:"previous_balance"`
+	TargetBalance   decimal.Decimal `json:"target_balance"`
+	Difference      decimal.Decimal `json:"difference"`
+}
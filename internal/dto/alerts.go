@@ -0,0 +1,130 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// --- Requests ---
+
+// CreateAlertThresholdRequest - запрос на создание порога уведомлений
+type CreateAlertThresholdRequest struct {
+	CategoryID  *uuid.UUID       `json:"category_id,omitempty"`                                                 // nil = общие расходы за месяц
+	LimitAmount *decimal.Decimal `json:"limit_amount,omitempty"`                                                // nil = бюджет категории на месяц
+	Percentages []int32          `json:"percentages,omitempty" validate:"omitempty,max=10,dive,min=1,max=1000"` // по умолчанию 80 и 100
+}
+
+// ValidateBusiness performs business logic validation
+func (r *CreateAlertThresholdRequest) ValidateBusiness() []ValidationError {
+	var errors []ValidationError
+
+	if r.CategoryID == nil && r.LimitAmount == nil {
+		errors = append(errors, ValidationError{
+			Field:   "limit_amount",
+			Message: "Limit amount is required for overall spending threshold",
+		})
+	}
+
+	if r.LimitAmount != nil && r.LimitAmount.LessThanOrEqual(decimal.Zero) {
+		errors = append(errors, ValidationError{
+			Field:   "limit_amount",
+			Message: "Limit amount must be positive",
+		})
+	}
+
+	errors = append(errors, validateAlertPercentages(r.Percentages)...)
+
+	return errors
+}
+
+// UpdateAlertThresholdRequest - запрос на обновление порога (partial)
+type UpdateAlertThresholdRequest struct {
+	LimitAmount *decimal.Decimal `json:"limit_amount,omitempty"`
+	UseBudget   bool             `json:"use_budget,omitempty"` // true = убрать лимит и сравнивать с бюджетом категории
+	Percentages []int32          `json:"percentages,omitempty" validate:"omitempty,max=10,dive,min=1,max=1000"`
+}
+
+// ValidateBusiness performs business logic validation
+func (r *UpdateAlertThresholdRequest) ValidateBusiness() []ValidationError {
+	var errors []ValidationError
+
+	if r.LimitAmount != nil && r.UseBudget {
+		errors = append(errors, ValidationError{
+			Field:   "use_budget",
+			Message: "Cannot set limit amount and use budget at the same time",
+		})
+	}
+
+	if r.LimitAmount != nil && r.LimitAmount.LessThanOrEqual(decimal.Zero) {
+		errors = append(errors, ValidationError{
+			Field:   "limit_amount",
+			Message: "Limit amount must be positive",
+		})
+	}
+
+	errors = append(errors, validateAlertPercentages(r.Percentages)...)
+
+	return errors
+}
+
+func validateAlertPercentages(percentages []int32) []ValidationError {
+	var errors []ValidationError
+
+	seen := make(map[int32]bool, len(percentages))
+	for _, p := range percentages {
+		if seen[p] {
+			errors = append(errors, ValidationError{
+				Field:   "percentages",
+				Message: "Percentage is listed more than once",
+			})
+		}
+		seen[p] = true
+	}
+
+	return errors
+}
+
+// --- Responses ---
+
+// AlertThresholdResponse - порог уведомлений в ответе API
+type AlertThresholdResponse struct {
+	ID           uuid.UUID        `json:"id"`
+	CategoryID   *uuid.UUID       `json:"category_id,omitempty"` // nil = общие расходы за месяц
+	CategoryName *string          `json:"category_name,omitempty"`
+	LimitAmount  *decimal.Decimal `json:"limit_amount,omitempty"`
+	UsesBudget   bool             `json:"uses_budget"` // лимит = бюджет категории на месяц
+	Percentages  []int32          `json:"percentages"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+}
+
+// AlertThresholdListResponse - список порогов
+type AlertThresholdListResponse struct {
+	Thresholds []AlertThresholdResponse `json:"thresholds"`
+}
+
+// AlertResponse - уведомление о превышении порога
+type AlertResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	ThresholdID    uuid.UUID       `json:"threshold_id"`
+	CategoryID     *uuid.UUID      `json:"category_id,omitempty"`
+	CategoryName   *string         `json:"category_name,omitempty"`
+	Month          string          `json:"month"` // YYYY-MM
+	Percentage     int32           `json:"percentage"`
+	LimitAmount    decimal.Decimal `json:"limit_amount"`
+	SpentAmount    decimal.Decimal `json:"spent_amount"`
+	Message        string          `json:"message"`
+	IsRead         bool            `json:"is_read"`
+	ReadAt         *time.Time      `json:"read_at,omitempty"`
+	IsAcknowledged bool            `json:"is_acknowledged"`
+	AcknowledgedAt *time.Time      `json:"acknowledged_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// AlertListResponse - список уведомлений пользователя
+type AlertListResponse struct {
+	Alerts      []AlertResponse `json:"alerts"`
+	UnreadCount int64           `json:"unread_count"`
+}
@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// --- Requests ---
+
+// CreateBudgetRequest - запрос на создание бюджета категории
+type CreateBudgetRequest struct {
+	CategoryID   uuid.UUID       `json:"category_id" validate:"required"`
+	Month        string          `json:"month,omitempty"` // YYYY-MM, пусто = бюджет по умолчанию на каждый месяц
+	Amount       decimal.Decimal `json:"amount" validate:"required"`
+	RolloverMode string          `json:"rollover_mode,omitempty" validate:"omitempty,oneof=none carry_surplus carry_all"`
+}
+
+// ValidateBusiness performs business logic validation
+func (r *CreateBudgetRequest) ValidateBusiness() []ValidationError {
+	var errors []ValidationError
+
+	if r.Amount.LessThanOrEqual(decimal.Zero) {
+		errors = append(errors, ValidationError{
+			Field:   "amount",
+			Message: "Amount must be positive",
+		})
+	}
+
+	if r.Month != "" {
+		if _, err := time.Parse("2006-01", r.Month); err != nil {
+			errors = append(errors, ValidationError{
+				Field:   "month",
+				Message: "Invalid month format, use YYYY-MM",
+			})
+		}
+	}
+
+	return errors
+}
+
+// UpdateBudgetRequest - запрос на обновление бюджета (partial)
+type UpdateBudgetRequest struct {
+	Amount       *decimal.Decimal `json:"amount,omitempty"`
+	RolloverMode *string          `json:"rollover_mode,omitempty" validate:"omitempty,oneof=none carry_surplus carry_all"`
+}
+
+// ValidateBusiness performs business logic validation
+func (r *UpdateBudgetRequest) ValidateBusiness() []ValidationError {
+	var errors []ValidationError
+
+	if r.Amount != nil && r.Amount.LessThanOrEqual(decimal.Zero) {
+		errors = append(errors, ValidationError{
+			Field:   "amount",
+			Message: "Amount must be positive",
+		})
+	}
+
+	return errors
+}
+
+// AssignBudgetRequest - запрос на распределение дохода в категорию (envelope)
+type AssignBudgetRequest struct {
+	CategoryID uuid.UUID       `json:"category_id" validate:"required"`
+	Month      string          `json:"month" validate:"required"` // YYYY-MM
+	Amount     decimal.Decimal `json:"amount"`                    // заменяет прежнее значение, 0 = сбросить
+}
+
+// ValidateBusiness performs business logic validation
+func (r *AssignBudgetRequest) ValidateBusiness() []ValidationError {
+	var errors []ValidationError
+
+	if r.Amount.IsNegative() {
+		errors = append(errors, ValidationError{
+			Field:   "amount",
+			Message: "Amount cannot be negative",
+		})
+	}
+
+	if _, err := time.Parse("2006-01", r.Month); err != nil {
+		errors = append(errors, ValidationError{
+			Field:   "month",
+			Message: "Invalid month format, use YYYY-MM",
+		})
+	}
+
+	return errors
+}
+
+// --- Responses ---
+
+// BudgetResponse - бюджет в ответе API
+type BudgetResponse struct {
+	ID           uuid.UUID       `json:"id"`
+	CategoryID   uuid.UUID       `json:"category_id"`
+	CategoryName string          `json:"category_name"`
+	Month        *string         `json:"month,omitempty"` // YYYY-MM, nil = бюджет по умолчанию
+	IsDefault    bool            `json:"is_default"`
+	Amount       decimal.Decimal `json:"amount"`
+	RolloverMode string          `json:"rollover_mode"`
+	CreatedAt    time.Time       `json:"created_at"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// BudgetListResponse - список бюджетов
+type BudgetListResponse struct {
+	Budgets []BudgetResponse `json:"budgets"`
+}
+
+// BudgetAssignmentResponse - распределённая в категорию сумма
+type BudgetAssignmentResponse struct {
+	CategoryID   uuid.UUID       `json:"category_id"`
+	CategoryName string          `json:"category_name"`
+	Month        string          `json:"month"` // YYYY-MM
+	Amount       decimal.Decimal `json:"amount"`
+	UpdatedAt    time.Time       `json:"updated_at"`
+}
+
+// BudgetAssignmentListResponse - распределение дохода за месяц
+type BudgetAssignmentListResponse struct {
+	Month             string                     `json:"month"`
+	Assignments       []BudgetAssignmentResponse `json:"assignments"`
+	Income            decimal.Decimal            `json:"income"`       // доход за месяц
+	Assigned          decimal.Decimal            `json:"assigned"`     // распределено за месяц
+	CarriedOver       decimal.Decimal            `json:"carried_over"` // нераспределённый остаток прошлых месяцев
+	AvailableToAssign decimal.Decimal            `json:"available_to_assign"`
+}
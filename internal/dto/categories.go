@@ -1,41 +1,119 @@
 package dto
 
 import (
+	"regexp"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 // --- Requests ---
 
 // CreateCategoryRequest - запрос на создание категории
 type CreateCategoryRequest struct {
-	Name     string     `json:"name" validate:"required,min=1,max=100"`
-	Type     string     `json:"type" validate:"required,oneof=income expense"`
-	ParentID *uuid.UUID `json:"parent_id,omitempty"`
+	Name        string     `json:"name" validate:"required,min=1,max=100"`
+	Type        string     `json:"type" validate:"required,oneof=income expense"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	Description *string    `json:"description,omitempty" validate:"omitempty,max=500"`
+	Icon        *string    `json:"icon,omitempty" validate:"omitempty,max=50"`
+	Color       *string    `json:"color,omitempty" validate:"omitempty,hexcolor,len=7"` // #RRGGBB
+	SortOrder   *int       `json:"sort_order,omitempty" validate:"omitempty,min=0"`     // по умолчанию - в конец списка
 }
 
 // UpdateCategoryRequest - запрос на обновление категории (partial)
 type UpdateCategoryRequest struct {
-	Name     *string    `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
-	ParentID *uuid.UUID `json:"parent_id,omitempty"`
-	IsActive *bool      `json:"is_active,omitempty"`
+	Name        *string    `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	IsActive    *bool      `json:"is_active,omitempty"`
+	Description *string    `json:"description,omitempty" validate:"omitempty,max=500"` // "" = удалить
+	Icon        *string    `json:"icon,omitempty" validate:"omitempty,max=50"`         // "" = удалить
+	Color       *string    `json:"color,omitempty"`                                    // #RRGGBB, "" = удалить
+	SortOrder   *int       `json:"sort_order,omitempty" validate:"omitempty,min=0"`
+}
+
+// categoryColorPattern matches #RRGGBB colors
+var categoryColorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// ValidateBusiness performs business logic validation
+func (r *UpdateCategoryRequest) ValidateBusiness() []ValidationError {
+	var errors []ValidationError
+
+	if r.Color != nil && *r.Color != "" && !categoryColorPattern.MatchString(*r.Color) {
+		errors = append(errors, ValidationError{
+			Field:   "color",
+			Message: "Color must be in #RRGGBB format",
+		})
+	}
+
+	return errors
+}
+
+// MergeCategoryRequest - запрос на слияние категории с другой категорией
+type MergeCategoryRequest struct {
+	TargetID uuid.UUID `json:"target_id" validate:"required"`
+}
+
+// MoveCategoryRequest - запрос на перемещение категории вместе с подкатегориями
+type MoveCategoryRequest struct {
+	ParentID *uuid.UUID `json:"parent_id"` // nil = категория верхнего уровня
+}
+
+// ReorderCategoriesRequest - новый порядок категорий одного уровня
+type ReorderCategoriesRequest struct {
+	IDs []uuid.UUID `json:"ids" validate:"required,min=1,dive,required"` // все активные категории с общим родителем и типом
 }
 
 // --- Responses ---
 
 // CategoryResponse - категория в ответе API
 type CategoryResponse struct {
-	ID        uuid.UUID  `json:"id"`
-	Name      string     `json:"name"`
-	Type      string     `json:"type"`
-	ParentID  *uuid.UUID `json:"parent_id,omitempty"`
-	IsActive  bool       `json:"is_active"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID          uuid.UUID  `json:"id"`
+	Name        string     `json:"name"`
+	Type        string     `json:"type"`
+	ParentID    *uuid.UUID `json:"parent_id,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Icon        *string    `json:"icon,omitempty"`
+	Color       *string    `json:"color,omitempty"`
+	SortOrder   int        `json:"sort_order"`
+	IsActive    bool       `json:"is_active"`
+	IsSystem    bool       `json:"is_system"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
 }
 
 // CategoryListResponse - список категорий
 type CategoryListResponse struct {
 	Categories []CategoryResponse `json:"categories"`
 }
+
+// MergeCategoryResponse - результат слияния категорий
+type MergeCategoryResponse struct {
+	Category          CategoryResponse `json:"category"` // целевая категория
+	TransactionsMoved int64            `json:"transactions_moved"`
+	ChildrenMoved     int64            `json:"children_moved"`
+}
+
+// CategoryTreeNode - узел дерева категорий (итоги включают всех потомков)
+type CategoryTreeNode struct {
+	ID                  uuid.UUID          `json:"id"`
+	Name                string             `json:"name"`
+	Type                string             `json:"type"`
+	ParentID            *uuid.UUID         `json:"parent_id,omitempty"`
+	Icon                *string            `json:"icon,omitempty"`
+	Color               *string            `json:"color,omitempty"`
+	SortOrder           int                `json:"sort_order"`
+	IsSystem            bool               `json:"is_system"`
+	OwnTotal            *decimal.Decimal   `json:"own_total,omitempty"`
+	Total               *decimal.Decimal   `json:"total,omitempty"`
+	OwnTransactionCount *int               `json:"own_transaction_count,omitempty"`
+	TransactionCount    *int               `json:"transaction_count,omitempty"`
+	Children            []CategoryTreeNode `json:"children"`
+}
+
+// CategoryTreeResponse - дерево категорий
+type CategoryTreeResponse struct {
+	Categories []CategoryTreeNode `json:"categories"`
+	Period     *ReportPeriod      `json:"period,omitempty"`
+	Currency   string             `json:"currency,omitempty"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+// --- Responses ---
+
+// CategoryTemplateItem - категория в шаблоне
+type CategoryTemplateItem struct {
+	Name        string                 `json:"name"`
+	Type        string                 `json:"type"`
+	Description string                 `json:"description,omitempty"`
+	Children    []CategoryTemplateItem `json:"children,omitempty"`
+}
+
+// CategoryTemplateResponse - шаблон категорий
+type CategoryTemplateResponse struct {
+	Key           string                 `json:"key"`
+	Name          string                 `json:"name"`
+	Description   string                 `json:"description"`
+	Language      string                 `json:"language"`
+	IsDefault     bool                   `json:"is_default"`
+	CategoryCount int                    `json:"category_count"`
+	Categories    []CategoryTemplateItem `json:"categories"`
+}
+
+// CategoryTemplateListResponse - список шаблонов категорий
+type CategoryTemplateListResponse struct {
+	Templates []CategoryTemplateResponse `json:"templates"`
+}
+
+// ApplyCategoryTemplateResponse - результат применения шаблона
+type ApplyCategoryTemplateResponse struct {
+	Template   string             `json:"template"`
+	Created    int                `json:"created"`
+	Skipped    int                `json:"skipped"` // категории, которые уже существовали
+	Categories []CategoryResponse `json:"categories"`
+}
@@ -6,6 +6,19 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// Currencies
+
+type CurrencyResponse struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	MinorUnits int    `json:"minor_units"`
+	Symbol     string `json:"symbol"`
+}
+
+type CurrencyListResponse struct {
+	Currencies []CurrencyResponse `json:"currencies"`
+}
+
 // Exchange Rates
 
 type ExchangeRatesResponse struct {
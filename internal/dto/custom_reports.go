@@ -0,0 +1,104 @@
+package dto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/DigitLock/expense-tracker/internal/reportbuilder"
+)
+
+// --- Requests ---
+
+// CustomReportRequest - запрос произвольного отчёта (определение в корне тела)
+type CustomReportRequest struct {
+	reportbuilder.Definition
+}
+
+// ValidateBusiness performs business logic validation
+func (r *CustomReportRequest) ValidateBusiness() []ValidationError {
+	return validateReportDefinition(r.Definition)
+}
+
+// CreateSavedReportRequest - запрос на сохранение отчёта
+type CreateSavedReportRequest struct {
+	Name        string                   `json:"name" validate:"required,min=1,max=100"`
+	Description *string                  `json:"description,omitempty" validate:"omitempty,max=500"`
+	Definition  reportbuilder.Definition `json:"definition"`
+}
+
+// ValidateBusiness performs business logic validation
+func (r *CreateSavedReportRequest) ValidateBusiness() []ValidationError {
+	return validateReportDefinition(r.Definition)
+}
+
+// UpdateSavedReportRequest - запрос на обновление сохранённого отчёта (partial)
+type UpdateSavedReportRequest struct {
+	Name        *string                   `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Description *string                   `json:"description,omitempty" validate:"omitempty,max=500"` // "" = убрать описание
+	Definition  *reportbuilder.Definition `json:"definition,omitempty"`
+}
+
+// ValidateBusiness performs business logic validation
+func (r *UpdateSavedReportRequest) ValidateBusiness() []ValidationError {
+	if r.Definition == nil {
+		return nil
+	}
+	return validateReportDefinition(*r.Definition)
+}
+
+// validateReportDefinition checks measures, dimensions, filters and sort of a definition
+func validateReportDefinition(d reportbuilder.Definition) []ValidationError {
+	err := d.Validate()
+	if err == nil {
+		return nil
+	}
+
+	var invalid *reportbuilder.ValidationError
+	if errors.As(err, &invalid) {
+		return []ValidationError{{Field: "definition." + invalid.Field, Message: invalid.Message}}
+	}
+	return []ValidationError{{Field: "definition", Message: err.Error()}}
+}
+
+// --- Responses ---
+
+// SavedReportResponse - сохранённый отчёт в ответе API
+type SavedReportResponse struct {
+	ID          uuid.UUID                `json:"id"`
+	Name        string                   `json:"name"`
+	Description *string                  `json:"description,omitempty"`
+	Definition  reportbuilder.Definition `json:"definition"`
+	CreatedBy   *uuid.UUID               `json:"created_by,omitempty"`
+	CreatedAt   time.Time                `json:"created_at"`
+	UpdatedAt   time.Time                `json:"updated_at"`
+}
+
+// SavedReportListResponse - список сохранённых отчётов
+type SavedReportListResponse struct {
+	Reports []SavedReportResponse `json:"reports"`
+}
+
+// CustomReportResponse - результат произвольного отчёта
+type CustomReportResponse struct {
+	ReportType    string                   `json:"report_type"`
+	SavedReportID *uuid.UUID               `json:"saved_report_id,omitempty"`
+	Name          *string                  `json:"name,omitempty"`
+	Period        *ReportPeriod            `json:"period,omitempty"` // nil = без ограничения по датам
+	Currency      string                   `json:"currency"`
+	Definition    reportbuilder.Definition `json:"definition"`
+	Columns       []reportbuilder.Column   `json:"columns"`
+	Rows          []map[string]any         `json:"rows"` // ключи - имена колонок
+	RowCount      int                      `json:"row_count"`
+	Truncated     bool                     `json:"truncated"` // строк столько же, сколько limit
+	GeneratedAt   time.Time                `json:"generated_at"`
+}
+
+// CustomReportOptionsResponse - поддерживаемые меры, измерения и периоды
+type CustomReportOptionsResponse struct {
+	Measures   []string `json:"measures"`
+	Dimensions []string `json:"dimensions"`
+	Periods    []string `json:"periods"`
+	MaxLimit   int      `json:"max_limit"`
+}
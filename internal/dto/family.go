@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// --- Requests ---
+
+// UpdateFamilyRequest - запрос на обновление семьи (partial)
+type UpdateFamilyRequest struct {
+	Name         *string `json:"name,omitempty" validate:"omitempty,min=1,max=255"`
+	BaseCurrency *string `json:"base_currency,omitempty" validate:"omitempty,currency"` // смена пересчитывает amount_base всех транзакций
+}
+
+// --- Responses ---
+
+// FamilyResponse - семья в ответе API
+type FamilyResponse struct {
+	ID           uuid.UUID `json:"id"`
+	Name         string    `json:"name"`
+	BaseCurrency string    `json:"base_currency"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// UpdateFamilyResponse - семья после обновления
+type UpdateFamilyResponse struct {
+	FamilyResponse
+	RecomputedTransactions int64 `json:"recomputed_transactions"` // транзакции, пересчитанные в новую базовую валюту
+	ConvertedAmounts       int64 `json:"converted_amounts"`       // бюджеты, назначения, лимиты и алерты, пересчитанные по текущему курсу
+}
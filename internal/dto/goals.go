@@ -0,0 +1,159 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// --- Requests ---
+
+// GoalAccountRequest - привязка счёта к цели
+type GoalAccountRequest struct {
+	AccountID       uuid.UUID        `json:"account_id" validate:"required"`
+	EarmarkedAmount *decimal.Decimal `json:"earmarked_amount,omitempty"` // nil = весь баланс счёта
+}
+
+// CreateGoalRequest - запрос на создание цели накоплений
+type CreateGoalRequest struct {
+	Name         string               `json:"name" validate:"required,min=1,max=100"`
+	TargetAmount decimal.Decimal      `json:"target_amount" validate:"required"`
+	Currency     string               `json:"currency" validate:"required,currency"`
+	Deadline     string               `json:"deadline,omitempty"` // YYYY-MM-DD
+	Description  string               `json:"description,omitempty" validate:"max=500"`
+	Accounts     []GoalAccountRequest `json:"accounts" validate:"required,min=1,dive"`
+}
+
+// ValidateBusiness performs business logic validation
+func (r *CreateGoalRequest) ValidateBusiness() []ValidationError {
+	var errors []ValidationError
+
+	if r.TargetAmount.LessThanOrEqual(decimal.Zero) {
+		errors = append(errors, ValidationError{
+			Field:   "target_amount",
+			Message: "Target amount must be positive",
+		})
+	}
+
+	if r.Deadline != "" {
+		errors = append(errors, validateGoalDeadline(r.Deadline)...)
+	}
+
+	errors = append(errors, validateGoalAccounts(r.Accounts)...)
+
+	return errors
+}
+
+// UpdateGoalRequest - запрос на обновление цели (partial)
+type UpdateGoalRequest struct {
+	Name         *string               `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	TargetAmount *decimal.Decimal      `json:"target_amount,omitempty"`
+	Currency     *string               `json:"currency,omitempty" validate:"omitempty,currency"`
+	Deadline     *string               `json:"deadline,omitempty"` // YYYY-MM-DD, "" = убрать срок
+	Description  *string               `json:"description,omitempty" validate:"omitempty,max=500"`
+	Accounts     *[]GoalAccountRequest `json:"accounts,omitempty" validate:"omitempty,min=1,dive"`
+}
+
+// ValidateBusiness performs business logic validation
+func (r *UpdateGoalRequest) ValidateBusiness() []ValidationError {
+	var errors []ValidationError
+
+	if r.TargetAmount != nil && r.TargetAmount.LessThanOrEqual(decimal.Zero) {
+		errors = append(errors, ValidationError{
+			Field:   "target_amount",
+			Message: "Target amount must be positive",
+		})
+	}
+
+	if r.Deadline != nil && *r.Deadline != "" {
+		errors = append(errors, validateGoalDeadline(*r.Deadline)...)
+	}
+
+	if r.Accounts != nil {
+		errors = append(errors, validateGoalAccounts(*r.Accounts)...)
+	}
+
+	return errors
+}
+
+func validateGoalDeadline(deadline string) []ValidationError {
+	if _, err := time.Parse("2006-01-02", deadline); err != nil {
+		return []ValidationError{{
+			Field:   "deadline",
+			Message: "Invalid date format, use YYYY-MM-DD",
+		}}
+	}
+	return nil
+}
+
+func validateGoalAccounts(accounts []GoalAccountRequest) []ValidationError {
+	var errors []ValidationError
+
+	seen := make(map[uuid.UUID]bool, len(accounts))
+	for _, a := range accounts {
+		if seen[a.AccountID] {
+			errors = append(errors, ValidationError{
+				Field:   "accounts",
+				Message: "Account is linked more than once",
+			})
+		}
+		seen[a.AccountID] = true
+
+		if a.EarmarkedAmount != nil && a.EarmarkedAmount.LessThanOrEqual(decimal.Zero) {
+			errors = append(errors, ValidationError{
+				Field:   "earmarked_amount",
+				Message: "Earmarked amount must be positive",
+			})
+		}
+	}
+
+	return errors
+}
+
+// --- Responses ---
+
+// GoalAccountResponse - счёт, привязанный к цели
+type GoalAccountResponse struct {
+	AccountID       uuid.UUID        `json:"account_id"`
+	AccountName     string           `json:"account_name"`
+	AccountCurrency string           `json:"account_currency"`
+	CurrentBalance  decimal.Decimal  `json:"current_balance"`
+	EarmarkedAmount *decimal.Decimal `json:"earmarked_amount,omitempty"`
+	Contribution    decimal.Decimal  `json:"contribution"` // в валюте цели
+	IsConverted     bool             `json:"is_converted"` // false - нет курса к валюте цели, вклад не учтён
+	IsActive        bool             `json:"is_active"`
+}
+
+// GoalProgress - прогресс накоплений по цели
+type GoalProgress struct {
+	CurrentAmount               decimal.Decimal  `json:"current_amount"`
+	RemainingAmount             decimal.Decimal  `json:"remaining_amount"`
+	ProgressPercent             decimal.Decimal  `json:"progress_percent"`
+	IsCompleted                 bool             `json:"is_completed"`
+	HasUnconverted              bool             `json:"has_unconverted"` // есть суммы без курса к валюте цели
+	MonthsRemaining             *int             `json:"months_remaining,omitempty"`
+	RequiredMonthlyContribution *decimal.Decimal `json:"required_monthly_contribution,omitempty"`
+	AverageMonthlyContribution  decimal.Decimal  `json:"average_monthly_contribution"`
+	ProjectedCompletionDate     *string          `json:"projected_completion_date,omitempty"`
+	IsOnTrack                   *bool            `json:"is_on_track,omitempty"`
+}
+
+// GoalResponse - цель накоплений в ответе API
+type GoalResponse struct {
+	ID           uuid.UUID             `json:"id"`
+	Name         string                `json:"name"`
+	TargetAmount decimal.Decimal       `json:"target_amount"`
+	Currency     string                `json:"currency"`
+	Deadline     *string               `json:"deadline,omitempty"`
+	Description  *string               `json:"description,omitempty"`
+	Accounts     []GoalAccountResponse `json:"accounts"`
+	Progress     GoalProgress          `json:"progress"`
+	CreatedAt    time.Time             `json:"created_at"`
+	UpdatedAt    time.Time             `json:"updated_at"`
+}
+
+// GoalListResponse - список целей
+type GoalListResponse struct {
+	Goals []GoalResponse `json:"goals"`
+}
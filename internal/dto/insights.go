@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// InsightTransaction - транзакция, вызвавшая находку
+type InsightTransaction struct {
+	ID          uuid.UUID       `json:"id"`
+	Date        string          `json:"date"`
+	Amount      decimal.Decimal `json:"amount"` // в базовой валюте
+	Description *string         `json:"description,omitempty"`
+	Link        string          `json:"link"` // /api/v1/transactions/{id}
+}
+
+// InsightFinding - необычная трата
+type InsightFinding struct {
+	Kind         string               `json:"kind"`     // category_spike, large_transaction, new_payee
+	Severity     string               `json:"severity"` // medium, high
+	Title        string               `json:"title"`
+	Explanation  string               `json:"explanation"`
+	CategoryID   uuid.UUID            `json:"category_id"`
+	CategoryName string               `json:"category_name"`
+	Amount       decimal.Decimal      `json:"amount"`   // сумма категории за период или сумма транзакции
+	Expected     decimal.Decimal      `json:"expected"` // медиана истории
+	Score        decimal.Decimal      `json:"score"`    // робастный z-score
+	Transactions []InsightTransaction `json:"transactions"`
+}
+
+// InsightsResponse - найденные аномалии расходов за период
+type InsightsResponse struct {
+	Period        ReportPeriod     `json:"period"`
+	History       ReportPeriod     `json:"history"`
+	HistoryMonths int              `json:"history_months"`
+	Currency      string           `json:"currency"`
+	Findings      []InsightFinding `json:"findings"` // от самых необычных
+	GeneratedAt   time.Time        `json:"generated_at"`
+}
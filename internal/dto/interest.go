@@ -0,0 +1,82 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// --- Requests ---
+
+// InterestSettingsRequest - запрос на настройку начисления процентов по счёту
+type InterestSettingsRequest struct {
+	AnnualRate         decimal.Decimal `json:"annual_rate" validate:"required"`
+	Compounding        string          `json:"compounding" validate:"required,oneof=monthly quarterly annually"`
+	PayoutDay          int             `json:"payout_day" validate:"required,min=1,max=28"`
+	WithholdingTaxRate decimal.Decimal `json:"withholding_tax_rate"`
+	IncomeCategoryID   uuid.UUID       `json:"income_category_id" validate:"required"`
+}
+
+// ValidateBusiness performs business logic validation
+func (r *InterestSettingsRequest) ValidateBusiness() []ValidationError {
+	var errors []ValidationError
+	hundred := decimal.NewFromInt(100)
+
+	if r.AnnualRate.LessThanOrEqual(decimal.Zero) || r.AnnualRate.GreaterThan(hundred) {
+		errors = append(errors, ValidationError{
+			Field:   "annual_rate",
+			Message: "Annual rate must be greater than 0 and at most 100",
+		})
+	}
+
+	if r.WithholdingTaxRate.IsNegative() || r.WithholdingTaxRate.GreaterThan(hundred) {
+		errors = append(errors, ValidationError{
+			Field:   "withholding_tax_rate",
+			Message: "Withholding tax rate must be between 0 and 100",
+		})
+	}
+
+	return errors
+}
+
+// --- Responses ---
+
+// InterestSettingsResponse - настройки начисления процентов
+type InterestSettingsResponse struct {
+	AccountID          uuid.UUID       `json:"account_id"`
+	AnnualRate         decimal.Decimal `json:"annual_rate"`
+	Compounding        string          `json:"compounding"`
+	PayoutDay          int             `json:"payout_day"`
+	WithholdingTaxRate decimal.Decimal `json:"withholding_tax_rate"`
+	IncomeCategoryID   uuid.UUID       `json:"income_category_id"`
+	LastPostedDate     *string         `json:"last_posted_date,omitempty"`
+	NextPayoutDate     string          `json:"next_payout_date"`
+	CreatedAt          time.Time       `json:"created_at"`
+	UpdatedAt          time.Time       `json:"updated_at"`
+}
+
+// InterestPreviewItem - прогноз одной выплаты процентов
+type InterestPreviewItem struct {
+	Date           string          `json:"date"`
+	OpeningBalance decimal.Decimal `json:"opening_balance"`
+	GrossInterest  decimal.Decimal `json:"gross_interest"`
+	Tax            decimal.Decimal `json:"tax"`
+	NetInterest    decimal.Decimal `json:"net_interest"`
+	ClosingBalance decimal.Decimal `json:"closing_balance"`
+}
+
+// InterestPreviewResponse - прогноз процентов на 12 месяцев
+type InterestPreviewResponse struct {
+	AccountID        uuid.UUID             `json:"account_id"`
+	Currency         string                `json:"currency"`
+	AnnualRate       decimal.Decimal       `json:"annual_rate"`
+	Compounding      string                `json:"compounding"`
+	Period           ReportPeriod          `json:"period"`
+	CurrentBalance   decimal.Decimal       `json:"current_balance"`
+	Payouts          []InterestPreviewItem `json:"payouts"`
+	TotalGross       decimal.Decimal       `json:"total_gross"`
+	TotalTax         decimal.Decimal       `json:"total_tax"`
+	TotalNet         decimal.Decimal       `json:"total_net"`
+	ProjectedBalance decimal.Decimal       `json:"projected_balance"`
+}
@@ -15,6 +15,7 @@ type SpendingByCategoryResponse struct {
 	Period             ReportPeriod       `json:"period"`
 	Currency           string             `json:"currency"`
 	TransactionType    string             `json:"transaction_type"`
+	GroupBy            string             `json:"group_by"`
 	SpendingByCategory []CategorySpending `json:"spending_by_category"`
 	TotalAmount        decimal.Decimal    `json:"total_amount"`
 	TotalTransactions  int                `json:"total_transactions"`
@@ -72,3 +73,293 @@ type TransactionCounts struct {
 	ExpenseTransactions int `json:"expense_transactions"`
 	TotalTransactions   int `json:"total_transactions"`
 }
+
+// --- Budget vs Actual Report ---
+
+// BudgetVsActualItem - план и факт по категории с бюджетом или распределением
+type BudgetVsActualItem struct {
+	CategoryID       uuid.UUID        `json:"category_id"`
+	CategoryName     string           `json:"category_name"`
+	ParentID         *uuid.UUID       `json:"parent_id,omitempty"`
+	BudgetID         *uuid.UUID       `json:"budget_id,omitempty"` // nil = только распределение (envelope)
+	IsDefaultBudget  bool             `json:"is_default_budget"`
+	RolloverMode     string           `json:"rollover_mode"`
+	Assigned         *decimal.Decimal `json:"assigned,omitempty"` // распределено в envelope за месяц
+	Planned          decimal.Decimal  `json:"planned"`            // распределение, иначе сумма бюджета
+	CarriedOver      decimal.Decimal  `json:"carried_over"`       // перенос с прошлых месяцев
+	Available        decimal.Decimal  `json:"available"`          // planned + carried_over
+	Spent            decimal.Decimal  `json:"spent"`              // включая подкатегории
+	Remaining        decimal.Decimal  `json:"remaining"`
+	PercentUsed      decimal.Decimal  `json:"percent_used"`
+	IsOverBudget     bool             `json:"is_over_budget"`
+	TransactionCount int              `json:"transaction_count"`
+}
+
+// BudgetVsActualResponse - отчёт план/факт по бюджетам за месяц
+type BudgetVsActualResponse struct {
+	ReportType        string               `json:"report_type"`
+	Month             string               `json:"month"`
+	Currency          string               `json:"currency"`
+	Categories        []BudgetVsActualItem `json:"categories"`
+	TotalPlanned      decimal.Decimal      `json:"total_planned"` // без двойного учёта вложенных бюджетов
+	TotalCarriedOver  decimal.Decimal      `json:"total_carried_over"`
+	TotalSpent        decimal.Decimal      `json:"total_spent"`
+	TotalRemaining    decimal.Decimal      `json:"total_remaining"`
+	PercentUsed       decimal.Decimal      `json:"percent_used"`
+	UnbudgetedSpent   decimal.Decimal      `json:"unbudgeted_spent"`    // расходы в категориях без бюджета
+	AvailableToAssign decimal.Decimal      `json:"available_to_assign"` // нераспределённый доход (envelope)
+	GeneratedAt       time.Time            `json:"generated_at"`
+}
+
+// --- Trends Report ---
+
+// TrendPoint - значение ряда за месяц
+type TrendPoint struct {
+	Month            string           `json:"month"` // YYYY-MM
+	Total            decimal.Decimal  `json:"total"`
+	TransactionCount int              `json:"transaction_count"`
+	Change           decimal.Decimal  `json:"change"`                   // к предыдущему месяцу
+	ChangePercent    *decimal.Decimal `json:"change_percent,omitempty"` // nil, если в предыдущем месяце 0
+	RollingAvg3      decimal.Decimal  `json:"rolling_avg_3"`
+	RollingAvg6      decimal.Decimal  `json:"rolling_avg_6"`
+	RollingAvg12     decimal.Decimal  `json:"rolling_avg_12"`
+}
+
+// TrendSeries - помесячный ряд одной группы (категория, тип или счёт)
+type TrendSeries struct {
+	Key     string          `json:"key"` // ID категории/счёта или тип
+	Name    string          `json:"name"`
+	Points  []TrendPoint    `json:"points"`
+	Total   decimal.Decimal `json:"total"`   // за период
+	Average decimal.Decimal `json:"average"` // среднее за месяц периода
+}
+
+// TrendsResponse - отчёт о динамике по месяцам
+type TrendsResponse struct {
+	ReportType      string        `json:"report_type"`
+	From            string        `json:"from"` // YYYY-MM
+	To              string        `json:"to"`   // YYYY-MM
+	Currency        string        `json:"currency"`
+	GroupBy         string        `json:"group_by"`
+	TransactionType string        `json:"transaction_type,omitempty"` // пусто = доходы и расходы
+	Series          []TrendSeries `json:"series"`
+	Totals          []TrendPoint  `json:"totals,omitempty"` // сумма всех групп, только для одного типа
+	GeneratedAt     time.Time     `json:"generated_at"`
+}
+
+// --- Period Comparison Report ---
+
+// CategoryComparison - суммы категории в двух периодах
+type CategoryComparison struct {
+	CategoryID     uuid.UUID        `json:"category_id"`
+	CategoryName   string           `json:"category_name"`
+	CurrentAmount  decimal.Decimal  `json:"current_amount"`
+	PreviousAmount decimal.Decimal  `json:"previous_amount"`
+	CurrentCount   int              `json:"current_count"`
+	PreviousCount  int              `json:"previous_count"`
+	Change         decimal.Decimal  `json:"change"`
+	ChangePercent  *decimal.Decimal `json:"change_percent,omitempty"` // nil, если в прошлом периоде 0
+	Status         string           `json:"status"`                   // new, disappeared, continued
+}
+
+// PeriodComparisonResponse - сравнение двух периодов по категориям
+type PeriodComparisonResponse struct {
+	ReportType            string               `json:"report_type"`
+	Current               ReportPeriod         `json:"current"`
+	Previous              ReportPeriod         `json:"previous"`
+	Currency              string               `json:"currency"`
+	TransactionType       string               `json:"transaction_type"`
+	GroupBy               string               `json:"group_by"`
+	Categories            []CategoryComparison `json:"categories"`
+	CurrentTotal          decimal.Decimal      `json:"current_total"`
+	PreviousTotal         decimal.Decimal      `json:"previous_total"`
+	Change                decimal.Decimal      `json:"change"`
+	ChangePercent         *decimal.Decimal     `json:"change_percent,omitempty"`
+	AppearedCategories    []uuid.UUID          `json:"appeared_categories"`    // только в текущем периоде
+	DisappearedCategories []uuid.UUID          `json:"disappeared_categories"` // только в прошлом периоде
+	GeneratedAt           time.Time            `json:"generated_at"`
+}
+
+// --- Cash-Flow Forecast Report ---
+
+// ForecastEvent - ожидаемая транзакция в периоде прогноза
+type ForecastEvent struct {
+	Date         string          `json:"date"`
+	Type         string          `json:"type"`
+	Amount       decimal.Decimal `json:"amount"`
+	CategoryID   uuid.UUID       `json:"category_id"`
+	CategoryName string          `json:"category_name"`
+	Description  *string         `json:"description,omitempty"`
+	Source       string          `json:"source"`             // recurring, interest
+	Interval     string          `json:"interval,omitempty"` // weekly, biweekly, monthly, quarterly, yearly
+}
+
+// ForecastDay - прогнозный баланс на конец дня
+type ForecastDay struct {
+	Date    string          `json:"date"`
+	Balance decimal.Decimal `json:"balance"`
+	Income  decimal.Decimal `json:"income"`
+	Expense decimal.Decimal `json:"expense"`
+}
+
+// AccountForecast - прогноз по счёту
+type AccountForecast struct {
+	AccountID         uuid.UUID       `json:"account_id"`
+	AccountName       string          `json:"account_name"`
+	AccountType       string          `json:"account_type"`
+	Currency          string          `json:"currency"`
+	CurrentBalance    decimal.Decimal `json:"current_balance"`
+	LowestBalance     decimal.Decimal `json:"lowest_balance"`
+	LowestBalanceDate *string         `json:"lowest_balance_date,omitempty"` // nil, если баланс не опускается ниже текущего
+	FirstNegativeDate *string         `json:"first_negative_date,omitempty"`
+	LowPoints         []ForecastDay   `json:"low_points"` // локальные минимумы, от самого низкого
+	Events            []ForecastEvent `json:"events"`
+	Days              []ForecastDay   `json:"days"`
+}
+
+// CashFlowForecastResponse - прогноз движения денег по счетам
+type CashFlowForecastResponse struct {
+	ReportType   string            `json:"report_type"`
+	Period       ReportPeriod      `json:"period"`
+	Days         int               `json:"days"`
+	LookbackDays int               `json:"lookback_days"` // глубина истории для поиска повторяющихся транзакций
+	Accounts     []AccountForecast `json:"accounts"`
+	GeneratedAt  time.Time         `json:"generated_at"`
+}
+
+// --- Member Spending Report ---
+
+// MemberSpending - доходы и расходы члена семьи
+type MemberSpending struct {
+	UserID            uuid.UUID          `json:"user_id"`
+	Name              string             `json:"name"`
+	IsActive          bool               `json:"is_active"` // false = пользователь удалён, транзакции остались
+	TotalIncome       decimal.Decimal    `json:"total_income"`
+	TotalExpense      decimal.Decimal    `json:"total_expense"`
+	Net               decimal.Decimal    `json:"net"`
+	IncomeCount       int                `json:"income_count"`
+	ExpenseCount      int                `json:"expense_count"`
+	IncomeShare       decimal.Decimal    `json:"income_share"`  // % от доходов семьи
+	ExpenseShare      decimal.Decimal    `json:"expense_share"` // % от расходов семьи
+	IncomeCategories  []CategorySpending `json:"income_categories"`
+	ExpenseCategories []CategorySpending `json:"expense_categories"`
+}
+
+// MemberSpendingResponse - отчёт по членам семьи
+type MemberSpendingResponse struct {
+	ReportType   string           `json:"report_type"`
+	Period       ReportPeriod     `json:"period"`
+	Currency     string           `json:"currency"`
+	GroupBy      string           `json:"group_by"`
+	Members      []MemberSpending `json:"members"`
+	TotalIncome  decimal.Decimal  `json:"total_income"`
+	TotalExpense decimal.Decimal  `json:"total_expense"`
+	GeneratedAt  time.Time        `json:"generated_at"`
+}
+
+// --- Daily Spending Report ---
+
+// DailyTotal - сумма за день
+type DailyTotal struct {
+	Date             string          `json:"date"`
+	Weekday          string          `json:"weekday"` // monday ... sunday
+	TotalAmount      decimal.Decimal `json:"total_amount"`
+	TransactionCount int             `json:"transaction_count"`
+}
+
+// WeekdayAverage - средние значения по дню недели
+type WeekdayAverage struct {
+	Weekday          string          `json:"weekday"`
+	Days             int             `json:"days"` // сколько таких дней в периоде
+	TotalAmount      decimal.Decimal `json:"total_amount"`
+	AverageAmount    decimal.Decimal `json:"average_amount"` // на день, включая дни без транзакций
+	AverageCount     decimal.Decimal `json:"average_count"`
+	TransactionCount int             `json:"transaction_count"`
+}
+
+// DailySpendingResponse - отчёт по дням (календарь-тепловая карта)
+type DailySpendingResponse struct {
+	ReportType      string           `json:"report_type"`
+	Period          ReportPeriod     `json:"period"`
+	Currency        string           `json:"currency"`
+	TransactionType string           `json:"transaction_type"`
+	Days            []DailyTotal     `json:"days"`     // каждый день периода, включая нулевые
+	Weekdays        []WeekdayAverage `json:"weekdays"` // с понедельника
+	TopDays         []DailyTotal     `json:"top_days"`
+	TotalAmount     decimal.Decimal  `json:"total_amount"`
+	AveragePerDay   decimal.Decimal  `json:"average_per_day"`
+	MaxDayAmount    decimal.Decimal  `json:"max_day_amount"` // для шкалы тепловой карты
+	GeneratedAt     time.Time        `json:"generated_at"`
+}
+
+// --- Annual Report ---
+
+// AnnualTotals - итоги года
+type AnnualTotals struct {
+	TotalIncome   decimal.Decimal `json:"total_income"`
+	TotalExpenses decimal.Decimal `json:"total_expenses"`
+	NetSavings    decimal.Decimal `json:"net_savings"`
+	SavingsRate   decimal.Decimal `json:"savings_rate"`
+	IncomeCount   int             `json:"income_count"`
+	ExpenseCount  int             `json:"expense_count"`
+}
+
+// AnnualMonth - итоги месяца в годовом отчёте
+type AnnualMonth struct {
+	Month           string          `json:"month"` // YYYY-MM
+	TotalIncome     decimal.Decimal `json:"total_income"`
+	TotalExpenses   decimal.Decimal `json:"total_expenses"`
+	NetSavings      decimal.Decimal `json:"net_savings"`
+	SavingsRate     decimal.Decimal `json:"savings_rate"`
+	IncomeCount     int             `json:"income_count"`
+	ExpenseCount    int             `json:"expense_count"`
+	PreviousIncome  decimal.Decimal `json:"previous_income"`  // тот же месяц прошлого года
+	PreviousExpense decimal.Decimal `json:"previous_expense"` // тот же месяц прошлого года
+}
+
+// AnnualPayee - сумма по описанию (получателю) за год
+type AnnualPayee struct {
+	Description      string          `json:"description"`
+	TotalAmount      decimal.Decimal `json:"total_amount"`
+	TransactionCount int             `json:"transaction_count"`
+	Percentage       decimal.Decimal `json:"percentage"` // % от расходов года
+}
+
+// AnnualAccountChange - изменение баланса счёта за год
+type AnnualAccountChange struct {
+	AccountID      uuid.UUID       `json:"account_id"`
+	AccountName    string          `json:"account_name"`
+	AccountType    string          `json:"account_type"`
+	Currency       string          `json:"currency"`
+	OpeningBalance decimal.Decimal `json:"opening_balance"` // на конец прошлого года
+	ClosingBalance decimal.Decimal `json:"closing_balance"` // на конец года (или на сегодня)
+	NetChange      decimal.Decimal `json:"net_change"`
+}
+
+// AnnualComparison - сравнение с прошлым годом
+type AnnualComparison struct {
+	Year                 int                  `json:"year"`
+	Totals               AnnualTotals         `json:"totals"`
+	IncomeChange         decimal.Decimal      `json:"income_change"`
+	IncomeChangePercent  *decimal.Decimal     `json:"income_change_percent,omitempty"` // nil, если в прошлом году 0
+	ExpenseChange        decimal.Decimal      `json:"expense_change"`
+	ExpenseChangePercent *decimal.Decimal     `json:"expense_change_percent,omitempty"`
+	ExpenseCategories    []CategoryComparison `json:"expense_categories"`
+}
+
+// AnnualReportResponse - годовой отчёт
+type AnnualReportResponse struct {
+	ReportType          string                `json:"report_type"`
+	Year                int                   `json:"year"`
+	Period              ReportPeriod          `json:"period"`
+	Currency            string                `json:"currency"`
+	Totals              AnnualTotals          `json:"totals"`
+	Months              []AnnualMonth         `json:"months"`
+	IncomeCategories    []CategorySpending    `json:"income_categories"`
+	ExpenseCategories   []CategorySpending    `json:"expense_categories"`
+	TopPayees           []AnnualPayee         `json:"top_payees"` // по сумме расходов
+	LargestTransactions []TransactionResponse `json:"largest_transactions"`
+	Accounts            []AnnualAccountChange `json:"accounts"`
+	PreviousYear        AnnualComparison      `json:"previous_year"`
+	GeneratedAt         time.Time             `json:"generated_at"`
+}
@@ -13,7 +13,7 @@ import (
 type CreateTransactionRequest struct {
 	Type        string          `json:"type" validate:"required,oneof=income expense"`
 	Amount      decimal.Decimal `json:"amount" validate:"required"`
-	Currency    string          `json:"currency" validate:"required,oneof=RSD EUR"`
+	Currency    string          `json:"currency" validate:"required,currency"`
 	CategoryID  uuid.UUID       `json:"category_id" validate:"required"`
 	AccountID   uuid.UUID       `json:"account_id" validate:"required"`
 	Description string          `json:"description,omitempty" validate:"max=500"`
@@ -53,7 +53,7 @@ func (r *CreateTransactionRequest) ValidateBusiness() []ValidationError {
 type UpdateTransactionRequest struct {
 	Type        *string          `json:"type,omitempty" validate:"omitempty,oneof=income expense"`
 	Amount      *decimal.Decimal `json:"amount,omitempty"`
-	Currency    *string          `json:"currency,omitempty" validate:"omitempty,oneof=RSD EUR"`
+	Currency    *string          `json:"currency,omitempty" validate:"omitempty,currency"`
 	CategoryID  *uuid.UUID       `json:"category_id,omitempty"`
 	AccountID   *uuid.UUID       `json:"account_id,omitempty"`
 	Description *string          `json:"description,omitempty" validate:"omitempty,max=500"`
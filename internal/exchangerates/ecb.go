@@ -0,0 +1,82 @@
+package exchangerates
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SourceECB is the source value of rates published by the European Central Bank
+const SourceECB = "ecb"
+
+// DefaultECBURL is the euro foreign exchange reference rates feed of the ECB
+const DefaultECBURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+// ECBProvider reads the ECB daily euro reference rates (1 EUR = rate * currency)
+type ECBProvider struct {
+	url    string
+	client *http.Client
+}
+
+// NewECBProvider creates a new ECBProvider. Empty url uses DefaultECBURL.
+func NewECBProvider(url string, client *http.Client) *ECBProvider {
+	if url == "" {
+		url = DefaultECBURL
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &ECBProvider{url: url, client: client}
+}
+
+// ecbEnvelope is the eurofxref XML document:
+// <Cube><Cube time="2024-01-05"><Cube currency="USD" rate="1.0921"/>...</Cube></Cube>
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string `xml:"currency,attr"`
+			Rate     string `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// Source returns the source value of ECB rates
+func (p *ECBProvider) Source() string {
+	return SourceECB
+}
+
+// Fetch returns the latest published euro reference rates
+func (p *ECBProvider) Fetch(ctx context.Context) ([]Rate, error) {
+	var envelope ecbEnvelope
+	if err := fetchXML(ctx, p.client, p.url, &envelope); err != nil {
+		return nil, err
+	}
+
+	var rates []Rate
+	for _, day := range envelope.Days {
+		date, err := time.Parse("2006-01-02", day.Time)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate date %q", day.Time)
+		}
+
+		for _, r := range day.Rates {
+			rate, err := decimal.NewFromString(r.Rate)
+			if err != nil || !rate.IsPositive() {
+				return nil, fmt.Errorf("invalid rate %q for %s", r.Rate, r.Currency)
+			}
+			rates = append(rates, Rate{
+				From: "EUR",
+				To:   strings.ToUpper(r.Currency),
+				Rate: rate,
+				Date: date,
+			})
+		}
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,41 @@
+package exchangerates
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestECBProviderFetch(t *testing.T) {
+	server := serveFile(t, "eurofxref-daily.xml")
+
+	rates, err := NewECBProvider(server.URL, server.Client()).Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rates) != 5 {
+		t.Fatalf("%d rates, want 5", len(rates))
+	}
+	for _, r := range rates {
+		if r.From != "EUR" {
+			t.Errorf("%s/%s: rates must be against EUR", r.From, r.To)
+		}
+	}
+
+	byCurrency := ratesByCurrency(rates, "EUR")
+	assertRate(t, byCurrency, "USD", "1.0921")
+	assertRate(t, byCurrency, "JPY", "158.41")
+	assertRate(t, byCurrency, "GBP", "0.86053")
+}
+
+func TestECBProviderUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "maintenance", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	if _, err := NewECBProvider(server.URL, server.Client()).Fetch(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
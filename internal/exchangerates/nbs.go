@@ -0,0 +1,136 @@
+package exchangerates
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// SourceNBS is the source value of rates published by the National Bank of Serbia
+const SourceNBS = "nbs"
+
+// NBSProvider reads the official middle exchange rate list of the National Bank
+// of Serbia (1 currency = rate * RSD). The URL must return the ExchangeRateDataSet
+// XML of the NBS exchange rate service, either as is or inside a SOAP envelope.
+type NBSProvider struct {
+	url    string
+	client *http.Client
+}
+
+// NewNBSProvider creates a new NBSProvider
+func NewNBSProvider(url string, client *http.Client) *NBSProvider {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &NBSProvider{url: url, client: client}
+}
+
+// nbsRate is one <ExchangeRate> element of the NBS exchange rate list
+type nbsRate struct {
+	Date       string `xml:"Date"`
+	Currency   string `xml:"CurrencyCodeAlfaChar"`
+	Unit       string `xml:"Unit"`
+	MiddleRate string `xml:"MiddleRate"`
+}
+
+// nbsDataSet collects <ExchangeRate> elements at any depth of the document
+type nbsDataSet struct {
+	Rates []nbsRate
+}
+
+func (d *nbsDataSet) UnmarshalXML(dec *xml.Decoder, start xml.StartElement) error {
+	for {
+		token, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if el, ok := token.(xml.StartElement); ok && el.Name.Local == "ExchangeRate" {
+			var rate nbsRate
+			if err := dec.DecodeElement(&rate, &el); err != nil {
+				return err
+			}
+			d.Rates = append(d.Rates, rate)
+		}
+		if el, ok := token.(xml.EndElement); ok && el.Name == start.Name {
+			return nil
+		}
+	}
+}
+
+// Source returns the source value of NBS rates
+func (p *NBSProvider) Source() string {
+	return SourceNBS
+}
+
+// Fetch returns the latest middle exchange rates against the dinar
+func (p *NBSProvider) Fetch(ctx context.Context) ([]Rate, error) {
+	if p.url == "" {
+		return nil, errors.New("NBS rates URL is not configured")
+	}
+
+	var dataSet nbsDataSet
+	if err := fetchXML(ctx, p.client, p.url, &dataSet); err != nil {
+		return nil, err
+	}
+
+	rates := make([]Rate, 0, len(dataSet.Rates))
+	for _, r := range dataSet.Rates {
+		date, err := parseNBSDate(r.Date)
+		if err != nil {
+			return nil, err
+		}
+
+		middle, err := parseNBSDecimal(r.MiddleRate)
+		if err != nil || !middle.IsPositive() {
+			return nil, fmt.Errorf("invalid middle rate %q for %s", r.MiddleRate, r.Currency)
+		}
+
+		// Rates of some currencies are quoted per 100 units (e.g. JPY)
+		unit := decimal.NewFromInt(1)
+		if strings.TrimSpace(r.Unit) != "" {
+			unit, err = parseNBSDecimal(r.Unit)
+			if err != nil || !unit.IsPositive() {
+				return nil, fmt.Errorf("invalid unit %q for %s", r.Unit, r.Currency)
+			}
+		}
+
+		rates = append(rates, Rate{
+			From: strings.ToUpper(strings.TrimSpace(r.Currency)),
+			To:   "RSD",
+			Rate: middle.Div(unit),
+			Date: date,
+		})
+	}
+
+	return rates, nil
+}
+
+// parseNBSDate parses dates as ISO (2006-01-02, optionally with time) or 02.01.2006
+func parseNBSDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if len(value) >= 10 {
+		if date, err := time.Parse("2006-01-02", value[:10]); err == nil {
+			return date, nil
+		}
+	}
+	if date, err := time.Parse("02.01.2006", strings.TrimSuffix(value, ".")); err == nil {
+		return date, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid rate date %q", value)
+}
+
+// parseNBSDecimal parses a number with either decimal point or comma
+func parseNBSDecimal(value string) (decimal.Decimal, error) {
+	return decimal.NewFromString(strings.ReplaceAll(strings.TrimSpace(value), ",", "."))
+}
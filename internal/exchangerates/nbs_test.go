@@ -0,0 +1,73 @@
+package exchangerates
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestNBSProviderFetch(t *testing.T) {
+	for _, file := range []string{"nbs-exchange-rates.xml", "nbs-exchange-rates-soap.xml"} {
+		t.Run(file, func(t *testing.T) {
+			server := serveFile(t, file)
+
+			rates, err := NewNBSProvider(server.URL, server.Client()).Fetch(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(rates) != 4 {
+				t.Fatalf("%d rates, want 4", len(rates))
+			}
+			for _, r := range rates {
+				if r.To != "RSD" {
+					t.Errorf("%s/%s: rates must be against RSD", r.From, r.To)
+				}
+			}
+
+			byCurrency := ratesByCurrency(rates, "RSD")
+			assertRate(t, byCurrency, "EUR", "117.1727")
+			assertRate(t, byCurrency, "USD", "107.2849")
+			// Quoted per 100 yen
+			assertRate(t, byCurrency, "JPY", "0.739695")
+		})
+	}
+}
+
+func TestNBSProviderNotConfigured(t *testing.T) {
+	if _, err := NewNBSProvider("", nil).Fetch(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestParseNBSDate(t *testing.T) {
+	want := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)
+	for _, value := range []string{"2024-01-05", "2024-01-05T00:00:00+01:00", "05.01.2024", "05.01.2024."} {
+		date, err := parseNBSDate(value)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", value, err)
+			continue
+		}
+		if !date.Equal(want) {
+			t.Errorf("%q: date %s, want %s", value, date.Format(time.DateOnly), want.Format(time.DateOnly))
+		}
+	}
+
+	if _, err := parseNBSDate("5 Jan 2024"); err == nil {
+		t.Error("expected error for unsupported date format")
+	}
+}
+
+func TestParseNBSDecimal(t *testing.T) {
+	for _, value := range []string{"117.1727", "117,1727", " 117.1727 "} {
+		d, err := parseNBSDecimal(value)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", value, err)
+			continue
+		}
+		if !d.Equal(decimal.RequireFromString("117.1727")) {
+			t.Errorf("%q: %s, want 117.1727", value, d)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package exchangerates
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Rate is a published exchange rate: 1 From = Rate * To on Date
+type Rate struct {
+	From string
+	To   string
+	Rate decimal.Decimal
+	Date time.Time
+}
+
+// Provider fetches the latest reference rates published by an external source
+type Provider interface {
+	// Source identifies the provider; stored in exchange_rates.source
+	Source() string
+	// Fetch returns the latest published rates
+	Fetch(ctx context.Context) ([]Rate, error)
+}
+
+// Result contains the rates fetched by a chain and the provider they came from
+type Result struct {
+	Source string
+	Rates  []Rate
+}
+
+// Chain fetches rates from the first provider that succeeds,
+// falling back to the next one on errors or empty responses
+type Chain struct {
+	providers []Provider
+}
+
+// NewChain creates a chain trying providers in the given order
+func NewChain(providers ...Provider) *Chain {
+	return &Chain{providers: providers}
+}
+
+// Len returns the number of providers in the chain
+func (c *Chain) Len() int {
+	return len(c.providers)
+}
+
+// Fetch returns rates of the first provider that succeeds. If all providers
+// fail, the errors of all of them are returned.
+func (c *Chain) Fetch(ctx context.Context) (Result, error) {
+	if len(c.providers) == 0 {
+		return Result{}, errors.New("no exchange rate providers configured")
+	}
+
+	var errs []error
+	for _, p := range c.providers {
+		rates, err := p.Fetch(ctx)
+		if err == nil && len(rates) == 0 {
+			err = errors.New("no rates returned")
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Source(), err))
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		return Result{Source: p.Source(), Rates: rates}, nil
+	}
+
+	return Result{}, errors.Join(errs...)
+}
+
+// fetchXML downloads url and decodes the XML response into v
+func fetchXML(ctx context.Context, client *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/xml, text/xml")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	if err := xml.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode rates: %w", err)
+	}
+	return nil
+}
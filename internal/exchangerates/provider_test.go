@@ -0,0 +1,152 @@
+package exchangerates
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// serveFile starts a server answering every request with a recorded response from testdata
+func serveFile(t *testing.T, name string) *httptest.Server {
+	t.Helper()
+
+	body, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+		w.Write(body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// ratesByCurrency indexes rates by their non-base currency
+func ratesByCurrency(rates []Rate, base string) map[string]Rate {
+	byCurrency := make(map[string]Rate, len(rates))
+	for _, r := range rates {
+		if r.From == base {
+			byCurrency[r.To] = r
+		} else {
+			byCurrency[r.From] = r
+		}
+	}
+	return byCurrency
+}
+
+func assertRate(t *testing.T, rates map[string]Rate, currency, want string) {
+	t.Helper()
+
+	r, ok := rates[currency]
+	if !ok {
+		t.Errorf("%s: rate missing", currency)
+		return
+	}
+	if !r.Rate.Equal(decimal.RequireFromString(want)) {
+		t.Errorf("%s: rate %s, want %s", currency, r.Rate, want)
+	}
+	if wantDate := time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC); !r.Date.Equal(wantDate) {
+		t.Errorf("%s: date %s, want %s", currency, r.Date.Format(time.DateOnly), wantDate.Format(time.DateOnly))
+	}
+}
+
+// stubProvider returns fixed rates or an error
+type stubProvider struct {
+	source string
+	rates  []Rate
+	err    error
+	calls  int
+}
+
+func (p *stubProvider) Source() string {
+	return p.source
+}
+
+func (p *stubProvider) Fetch(ctx context.Context) ([]Rate, error) {
+	p.calls++
+	return p.rates, p.err
+}
+
+var stubRates = []Rate{{From: "EUR", To: "USD", Rate: decimal.RequireFromString("1.0921"), Date: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)}}
+
+func TestChainFallsBackOnError(t *testing.T) {
+	failing := &stubProvider{source: SourceNBS, err: errors.New("connection refused")}
+	working := &stubProvider{source: SourceECB, rates: stubRates}
+
+	result, err := NewChain(failing, working).Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Source != SourceECB {
+		t.Errorf("source %q, want %q", result.Source, SourceECB)
+	}
+	if len(result.Rates) != len(stubRates) {
+		t.Errorf("%d rates, want %d", len(result.Rates), len(stubRates))
+	}
+}
+
+func TestChainFallsBackOnEmptyResponse(t *testing.T) {
+	empty := &stubProvider{source: SourceNBS}
+	working := &stubProvider{source: SourceECB, rates: stubRates}
+
+	result, err := NewChain(empty, working).Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Source != SourceECB {
+		t.Errorf("source %q, want %q", result.Source, SourceECB)
+	}
+}
+
+func TestChainStopsAtFirstProviderWithRates(t *testing.T) {
+	first := &stubProvider{source: SourceNBS, rates: stubRates}
+	second := &stubProvider{source: SourceECB, rates: stubRates}
+
+	result, err := NewChain(first, second).Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Source != SourceNBS {
+		t.Errorf("source %q, want %q", result.Source, SourceNBS)
+	}
+	if second.calls != 0 {
+		t.Errorf("second provider called %d times, want 0", second.calls)
+	}
+}
+
+func TestChainJoinsErrorsWhenAllProvidersFail(t *testing.T) {
+	nbsErr := errors.New("connection refused")
+	ecbErr := errors.New("unexpected status 503 Service Unavailable")
+
+	_, err := NewChain(
+		&stubProvider{source: SourceNBS, err: nbsErr},
+		&stubProvider{source: SourceECB, err: ecbErr},
+	).Fetch(context.Background())
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if !errors.Is(err, nbsErr) || !errors.Is(err, ecbErr) {
+		t.Errorf("error %q does not wrap errors of all providers", err)
+	}
+	for _, prefix := range []string{"nbs: ", "ecb: "} {
+		if !strings.Contains(err.Error(), prefix) {
+			t.Errorf("error %q has no %q prefix", err, prefix)
+		}
+	}
+}
+
+func TestChainWithoutProviders(t *testing.T) {
+	if _, err := NewChain().Fetch(context.Background()); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,90 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// csvWriter writes sections one after another separated by an empty line.
+// Locales with a decimal comma use semicolon as delimiter, as spreadsheet
+// applications in those locales expect.
+type csvWriter struct {
+	w       *csv.Writer
+	locale  Locale
+	columns []Column
+}
+
+func newCSVWriter(w io.Writer, doc Document, locale Locale) (*csvWriter, error) {
+	// UTF-8 byte order mark, so spreadsheet applications detect the encoding
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return nil, err
+	}
+
+	cw := csv.NewWriter(w)
+	if locale.DecimalSeparator == "," {
+		cw.Comma = ';'
+	}
+
+	records := [][]string{{doc.Title}}
+	for _, line := range doc.headerLines() {
+		records = append(records, []string{line})
+	}
+	if err := cw.WriteAll(records); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{w: cw, locale: locale}, nil
+}
+
+func (c *csvWriter) Section(title string, columns []Column) error {
+	c.columns = columns
+
+	records := [][]string{{}}
+	if title != "" {
+		records = append(records, []string{title})
+	}
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Title
+	}
+	records = append(records, header)
+
+	for _, record := range records {
+		if err := c.w.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *csvWriter) Row(values ...any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		kind := KindText
+		if i < len(c.columns) {
+			kind = c.columns[i].Kind
+		}
+		record[i] = c.locale.formatValue(kind, v)
+		if kind == KindText {
+			record[i] = escapeFormula(record[i])
+		}
+	}
+
+	return c.w.Write(record)
+}
+
+// escapeFormula prefixes text that spreadsheet applications would run as a
+// formula (e.g. a description starting with "=") with an apostrophe
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,213 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/dto"
+)
+
+// Format is an export file format
+type Format string
+
+// Supported export formats
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatPDF  Format = "pdf"
+)
+
+var contentTypes = map[Format]string{
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatPDF:  "application/pdf",
+}
+
+// ParseFormat parses a format name (csv, xlsx, pdf)
+func ParseFormat(name string) (Format, bool) {
+	f := Format(strings.ToLower(strings.TrimSpace(name)))
+	_, ok := contentTypes[f]
+	return f, ok
+}
+
+// FormatFromAccept returns the first supported format listed in an Accept header
+func FormatFromAccept(accept string) (Format, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		for f, contentType := range contentTypes {
+			if mediaType == strings.SplitN(contentType, ";", 2)[0] {
+				return f, true
+			}
+		}
+	}
+	return "", false
+}
+
+// ContentType returns the MIME type of the format
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// Kind describes how values of a column are formatted
+type Kind int
+
+// Column kinds
+const (
+	KindText Kind = iota
+	KindMoney
+	KindInteger
+	KindPercent
+)
+
+// Column is a column of a table section
+type Column struct {
+	Title string
+	Kind  Kind
+}
+
+// Document describes the exported report
+type Document struct {
+	Title    string
+	Period   *dto.ReportPeriod
+	Currency string
+	Meta     []string // extra header lines, e.g. applied filters
+}
+
+// headerLines returns the lines printed below the title
+func (d Document) headerLines() []string {
+	var lines []string
+	if d.Period != nil {
+		lines = append(lines, fmt.Sprintf("Period: %s - %s", d.Period.StartDate, d.Period.EndDate))
+	}
+	if d.Currency != "" {
+		lines = append(lines, "Currency: "+d.Currency)
+	}
+	return append(lines, d.Meta...)
+}
+
+// Writer writes a document as a sequence of table sections. Rows are written
+// as they come, so large exports are streamed without buffering the whole file.
+type Writer interface {
+	// Section starts a new table with an optional title
+	Section(title string, columns []Column) error
+	// Row writes a row of the current section. Values are string, decimal.Decimal, int or int64.
+	Row(values ...any) error
+	// Close finishes the document
+	Close() error
+}
+
+// NewWriter creates a writer of the format
+func NewWriter(w io.Writer, format Format, doc Document, locale Locale) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, doc, locale)
+	case FormatXLSX:
+		return newXLSXWriter(w, doc)
+	case FormatPDF:
+		return newPDFWriter(w, doc, locale)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// Locale defines number formatting
+type Locale struct {
+	Code             string
+	DecimalSeparator string
+	GroupSeparator   string
+}
+
+var locales = map[string]Locale{
+	"en": {Code: "en", DecimalSeparator: ".", GroupSeparator: ","},
+	"sr": {Code: "sr", DecimalSeparator: ",", GroupSeparator: "."},
+	"ru": {Code: "ru", DecimalSeparator: ",", GroupSeparator: " "},
+}
+
+// LocaleFor returns the locale of a language tag ("sr-Latn-RS", "ru", ...),
+// falling back to English
+func LocaleFor(tag string) Locale {
+	lang := strings.ToLower(strings.SplitN(strings.SplitN(strings.TrimSpace(tag), "-", 2)[0], "_", 2)[0])
+	if l, ok := locales[lang]; ok {
+		return l
+	}
+	return locales["en"]
+}
+
+// LocaleFromAcceptLanguage returns the locale of the first supported language in an Accept-Language header
+func LocaleFromAcceptLanguage(header string) Locale {
+	for _, part := range strings.Split(header, ",") {
+		tag := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		lang := strings.ToLower(strings.SplitN(tag, "-", 2)[0])
+		if _, ok := locales[lang]; ok {
+			return locales[lang]
+		}
+	}
+	return locales["en"]
+}
+
+// FormatDecimal formats a number with the given number of decimal places
+func (l Locale) FormatDecimal(d decimal.Decimal, places int32) string {
+	s := d.StringFixed(places)
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+
+	var b strings.Builder
+	b.WriteString(sign)
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteString(l.GroupSeparator)
+		}
+		b.WriteRune(c)
+	}
+	if fracPart != "" {
+		b.WriteString(l.DecimalSeparator)
+		b.WriteString(fracPart)
+	}
+	return b.String()
+}
+
+// moneyPlaces returns the decimal places to show an amount with: 2, or up to 4
+// if the amount has more (currencies with 3 minor units, e.g. KWD)
+func moneyPlaces(d decimal.Decimal) int32 {
+	places := int32(2)
+	for places < 4 && !d.Round(places).Equal(d) {
+		places++
+	}
+	return places
+}
+
+// formatValue formats a value of a column for text-based formats
+func (l Locale) formatValue(kind Kind, value any) string {
+	switch v := value.(type) {
+	case decimal.Decimal:
+		switch kind {
+		case KindPercent:
+			return l.FormatDecimal(v, 1) + "%"
+		case KindInteger:
+			return l.FormatDecimal(v, 0)
+		default:
+			return l.FormatDecimal(v, moneyPlaces(v))
+		}
+	case int:
+		return l.FormatDecimal(decimal.NewFromInt(int64(v)), 0)
+	case int64:
+		return l.FormatDecimal(decimal.NewFromInt(v), 0)
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,312 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// A4 page layout in points
+const (
+	pdfPageWidth  = 595.0
+	pdfPageHeight = 842.0
+	pdfMargin     = 40.0
+	pdfFontSize   = 9.0
+	pdfTitleSize  = 14.0
+	pdfLineHeight = 14.0
+	pdfCellPad    = 3.0
+)
+
+// Object numbers reserved before the pages are written
+const (
+	pdfCatalogObj = 1
+	pdfPagesObj   = 2
+	pdfFontObj    = 3
+	pdfBoldObj    = 4
+	pdfFirstPage  = 5
+)
+
+// pdfWriter writes a paginated table document using the standard Helvetica
+// font. Each page is flushed as soon as it is full, so only one page is kept
+// in memory. Standard fonts cover WinAnsi only: Cyrillic is transliterated
+// and other characters are replaced.
+type pdfWriter struct {
+	w       *countingWriter
+	locale  Locale
+	offsets map[int]int64
+	pages   []int // page object numbers
+	nextObj int
+	page    bytes.Buffer
+	y       float64
+	columns []Column
+	widths  []float64
+	err     error
+}
+
+func newPDFWriter(w io.Writer, doc Document, locale Locale) (*pdfWriter, error) {
+	p := &pdfWriter{
+		w:       &countingWriter{w: w},
+		locale:  locale,
+		offsets: make(map[int]int64),
+		nextObj: pdfFirstPage,
+	}
+
+	p.writeRaw("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	p.writeObject(pdfCatalogObj, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfPagesObj))
+	p.writeObject(pdfFontObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	p.writeObject(pdfBoldObj, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	p.startPage()
+	p.text(pdfMargin, p.y, "F2", pdfTitleSize, winAnsi(doc.Title))
+	p.y -= pdfTitleSize + 6
+	for _, line := range doc.headerLines() {
+		p.text(pdfMargin, p.y, "F1", pdfFontSize, winAnsi(line))
+		p.y -= pdfLineHeight
+	}
+
+	return p, p.err
+}
+
+func (p *pdfWriter) Section(title string, columns []Column) error {
+	p.columns = columns
+	p.widths = columnWidths(columns)
+
+	// Keep section title, header and at least one row together
+	p.y -= pdfLineHeight / 2
+	if p.y-3*pdfLineHeight < pdfMargin {
+		p.newPage()
+	}
+
+	if title != "" {
+		p.text(pdfMargin, p.y, "F2", pdfFontSize+1, winAnsi(title))
+		p.y -= pdfLineHeight
+	}
+	p.headerRow()
+
+	return p.err
+}
+
+func (p *pdfWriter) Row(values ...any) error {
+	if p.y-pdfLineHeight < pdfMargin {
+		p.newPage()
+		p.headerRow()
+	}
+
+	x := pdfMargin
+	for i, v := range values {
+		if i >= len(p.widths) {
+			break
+		}
+		kind := p.columns[i].Kind
+		p.cell(x, p.widths[i], "F1", p.locale.formatValue(kind, v), kind != KindText)
+		x += p.widths[i]
+	}
+	p.y -= pdfLineHeight
+
+	return p.err
+}
+
+func (p *pdfWriter) Close() error {
+	p.finishPage()
+
+	kids := make([]string, len(p.pages))
+	for i, obj := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", obj)
+	}
+	p.writeObject(pdfPagesObj, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+
+	// Cross-reference table
+	xref := p.w.n
+	p.writeRaw(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", p.nextObj))
+	for obj := 1; obj < p.nextObj; obj++ {
+		p.writeRaw(fmt.Sprintf("%010d 00000 n \n", p.offsets[obj]))
+	}
+	p.writeRaw(fmt.Sprintf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", p.nextObj, pdfCatalogObj, xref))
+
+	return p.err
+}
+
+// headerRow writes column titles of the current section
+func (p *pdfWriter) headerRow() {
+	x := pdfMargin
+	for i, col := range p.columns {
+		p.cell(x, p.widths[i], "F2", col.Title, col.Kind != KindText)
+		x += p.widths[i]
+	}
+	p.y -= 4
+	fmt.Fprintf(&p.page, "0.5 w %.2f %.2f m %.2f %.2f l S\n", pdfMargin, p.y+pdfLineHeight-pdfFontSize-2, pdfPageWidth-pdfMargin, p.y+pdfLineHeight-pdfFontSize-2)
+	p.y -= pdfLineHeight - 4
+}
+
+// cell writes text into a column, truncated to its width
+func (p *pdfWriter) cell(x, width float64, font, value string, alignRight bool) {
+	text := fitText(winAnsi(value), width-2*pdfCellPad)
+	if alignRight {
+		x += width - pdfCellPad - textWidth(text)
+	} else {
+		x += pdfCellPad
+	}
+	p.text(x, p.y, font, pdfFontSize, text)
+}
+
+// text writes WinAnsi text at the position
+func (p *pdfWriter) text(x, y float64, font string, size float64, value string) {
+	fmt.Fprintf(&p.page, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfEscape(value))
+}
+
+func (p *pdfWriter) startPage() {
+	p.page.Reset()
+	p.y = pdfPageHeight - pdfMargin - pdfTitleSize
+}
+
+func (p *pdfWriter) newPage() {
+	p.finishPage()
+	p.startPage()
+}
+
+// finishPage writes the page number and flushes content and page objects
+func (p *pdfWriter) finishPage() {
+	number := fmt.Sprintf("%d", len(p.pages)+1)
+	p.text(pdfPageWidth-pdfMargin-textWidth(number), pdfMargin/2, "F1", pdfFontSize-1, number)
+
+	contentObj := p.nextObj
+	pageObj := p.nextObj + 1
+	p.nextObj += 2
+
+	p.writeObject(contentObj, fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.page.Len(), p.page.String()))
+	p.writeObject(pageObj, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.0f %.0f] /Resources << /Font << /F1 %d 0 R /F2 %d 0 R >> >> /Contents %d 0 R >>",
+		pdfPagesObj, pdfPageWidth, pdfPageHeight, pdfFontObj, pdfBoldObj, contentObj,
+	))
+	p.pages = append(p.pages, pageObj)
+}
+
+func (p *pdfWriter) writeObject(obj int, body string) {
+	p.offsets[obj] = p.w.n
+	p.writeRaw(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", obj, body))
+}
+
+func (p *pdfWriter) writeRaw(s string) {
+	if p.err != nil {
+		return
+	}
+	_, p.err = io.WriteString(p.w, s)
+}
+
+// countingWriter tracks the byte offset needed by the cross-reference table
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
+
+// columnWidths splits the page width between columns; text columns get twice the width of numbers
+func columnWidths(columns []Column) []float64 {
+	var weights float64
+	for _, col := range columns {
+		if col.Kind == KindText {
+			weights += 2
+		} else {
+			weights++
+		}
+	}
+
+	available := pdfPageWidth - 2*pdfMargin
+	widths := make([]float64, len(columns))
+	for i, col := range columns {
+		weight := 1.0
+		if col.Kind == KindText {
+			weight = 2
+		}
+		widths[i] = available * weight / weights
+	}
+	return widths
+}
+
+// helveticaWidths are glyph widths of Helvetica for characters 32-126 in 1/1000 em
+var helveticaWidths = []int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// textWidth returns approximate width of WinAnsi text in points
+func textWidth(s string) float64 {
+	var units int
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 32 && c <= 126 {
+			units += helveticaWidths[c-32]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * pdfFontSize / 1000
+}
+
+// fitText truncates text to the width, marking truncation with "..."
+func fitText(s string, width float64) string {
+	if textWidth(s) <= width {
+		return s
+	}
+	for len(s) > 0 && textWidth(s+"...") > width {
+		s = s[:len(s)-1]
+	}
+	return s + "..."
+}
+
+func pdfEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`).Replace(s)
+}
+
+// winAnsiExtras maps characters outside Latin-1 that exist in WinAnsiEncoding
+var winAnsiExtras = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92, '“': 0x93, '”': 0x94,
+	'•': 0x95, '–': 0x96, '—': 0x97, 'Š': 0x8A, 'š': 0x9A, 'Ž': 0x8E, 'ž': 0x9E,
+}
+
+// transliterations cover Cyrillic and Serbian Latin letters missing in WinAnsi
+var transliterations = map[rune]string{
+	'А': "A", 'Б': "B", 'В': "V", 'Г': "G", 'Д': "D", 'Ђ': "Dj", 'Е': "E", 'Ё': "E", 'Ж': "Zh", 'З': "Z",
+	'И': "I", 'Й': "J", 'Ј': "J", 'К': "K", 'Л': "L", 'Љ': "Lj", 'М': "M", 'Н': "N", 'Њ': "Nj", 'О': "O",
+	'П': "P", 'Р': "R", 'С': "S", 'Т': "T", 'Ћ': "C", 'У': "U", 'Ф': "F", 'Х': "H", 'Ц': "C", 'Ч': "Ch",
+	'Џ': "Dz", 'Ш': "Sh", 'Щ': "Shch", 'Ъ': "", 'Ы': "Y", 'Ь': "", 'Э': "E", 'Ю': "Yu", 'Я': "Ya",
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'ђ': "dj", 'е': "e", 'ё': "e", 'ж': "zh", 'з': "z",
+	'и': "i", 'й': "j", 'ј': "j", 'к': "k", 'л': "l", 'љ': "lj", 'м': "m", 'н': "n", 'њ': "nj", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'ћ': "c", 'у': "u", 'ф': "f", 'х': "h", 'ц': "c", 'ч': "ch",
+	'џ': "dz", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu", 'я': "ya",
+	'Č': "C", 'č': "c", 'Ć': "C", 'ć': "c", 'Đ': "Dj", 'đ': "dj",
+}
+
+// winAnsi converts UTF-8 text to WinAnsi bytes, transliterating or replacing unsupported characters
+func winAnsi(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r < 0x80:
+			b.WriteRune(r)
+		case r >= 0xA0 && r <= 0xFF:
+			b.WriteByte(byte(r))
+		default:
+			if c, ok := winAnsiExtras[r]; ok {
+				b.WriteByte(c)
+			} else if t, ok := transliterations[r]; ok {
+				b.WriteString(t)
+			} else if unicode.IsSpace(r) {
+				b.WriteByte(' ')
+			} else {
+				b.WriteByte('?')
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,250 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Cell styles defined in xlsxStyles
+const (
+	xlsxStyleDefault = 0
+	xlsxStyleBold    = 1
+	xlsxStyleMoney   = 2
+	xlsxStyleInteger = 3
+	xlsxStylePercent = 4
+	xlsxStyleTitle   = 5
+)
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+// Numbers are stored as numbers; the spreadsheet application applies the
+// user's locale to the built-in formats
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="0.0&quot;%&quot;"/></numFmts>
+<fonts count="3">
+<font><sz val="11"/><name val="Calibri"/></font>
+<font><b/><sz val="11"/><name val="Calibri"/></font>
+<font><b/><sz val="14"/><name val="Calibri"/></font>
+</fonts>
+<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="6">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+<xf numFmtId="4" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="3" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="0" fontId="2" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+</cellXfs>
+</styleSheet>`
+
+// xlsxWriter writes a single-sheet workbook. Strings are written inline, so
+// the sheet is streamed row by row without a shared strings table.
+type xlsxWriter struct {
+	zip     *zip.Writer
+	sheet   *bufio.Writer
+	columns []Column
+	row     int
+	err     error // first write error, returned by Row and Close
+}
+
+func newXLSXWriter(w io.Writer, doc Document) (*xlsxWriter, error) {
+	zw := zip.NewWriter(w)
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, xmlEscape(sheetName(doc.Title)))},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, p.content); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+
+	x := &xlsxWriter{zip: zw, sheet: bufio.NewWriter(f)}
+	x.write(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	x.write(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	x.write(`<sheetFormatPr defaultRowHeight="15" baseColWidth="18"/><sheetData>`)
+
+	x.writeRow(xlsxStyleTitle, doc.Title)
+	for _, line := range doc.headerLines() {
+		x.writeRow(xlsxStyleDefault, line)
+	}
+
+	return x, x.err
+}
+
+func (x *xlsxWriter) Section(title string, columns []Column) error {
+	x.columns = columns
+	x.row++ // empty row between sections
+
+	if title != "" {
+		x.writeRow(xlsxStyleBold, title)
+	}
+
+	header := make([]any, len(columns))
+	for i, col := range columns {
+		header[i] = col.Title
+	}
+	x.writeRow(xlsxStyleBold, header...)
+
+	return x.err
+}
+
+func (x *xlsxWriter) Row(values ...any) error {
+	x.row++
+	x.write(fmt.Sprintf(`<row r="%d">`, x.row))
+	for i, v := range values {
+		kind := KindText
+		if i < len(x.columns) {
+			kind = x.columns[i].Kind
+		}
+		x.writeCell(i, kind, xlsxStyleDefault, v)
+	}
+	x.write(`</row>`)
+
+	return x.err
+}
+
+func (x *xlsxWriter) Close() error {
+	x.write(`</sheetData></worksheet>`)
+	if x.err != nil {
+		return x.err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.zip.Close()
+}
+
+// writeRow writes a row of text cells with the same style
+func (x *xlsxWriter) writeRow(style int, values ...any) {
+	x.row++
+	x.write(fmt.Sprintf(`<row r="%d">`, x.row))
+	for i, v := range values {
+		x.writeCell(i, KindText, style, v)
+	}
+	x.write(`</row>`)
+}
+
+func (x *xlsxWriter) writeCell(col int, kind Kind, style int, value any) {
+	ref := columnName(col) + fmt.Sprint(x.row)
+
+	var number *decimal.Decimal
+	switch v := value.(type) {
+	case decimal.Decimal:
+		number = &v
+	case int:
+		d := decimal.NewFromInt(int64(v))
+		number = &d
+	case int64:
+		d := decimal.NewFromInt(v)
+		number = &d
+	}
+
+	if number == nil {
+		text := ""
+		if value != nil {
+			text = fmt.Sprint(value)
+		}
+		x.write(fmt.Sprintf(`<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xmlEscape(text)))
+		return
+	}
+
+	if style == xlsxStyleDefault {
+		switch kind {
+		case KindMoney:
+			style = xlsxStyleMoney
+		case KindInteger:
+			style = xlsxStyleInteger
+		case KindPercent:
+			style = xlsxStylePercent
+		}
+	}
+	x.write(fmt.Sprintf(`<c r="%s" s="%d"><v>%s</v></c>`, ref, style, number.String()))
+}
+
+// write appends to the sheet, keeping the first error
+func (x *xlsxWriter) write(s string) {
+	if x.err != nil {
+		return
+	}
+	_, x.err = x.sheet.WriteString(s)
+}
+
+// columnName returns the spreadsheet column name of a zero-based index (A, B, ..., AA)
+func columnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
+
+// sheetName returns a valid sheet name: at most 31 characters without []:*?/\
+func sheetName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return -1
+		}
+		return r
+	}, title)
+
+	if runes := []rune(name); len(runes) > 31 {
+		name = string(runes[:31])
+	}
+	if name == "" {
+		name = "Report"
+	}
+	return name
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,347 @@
+package forecast
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Recurrence intervals
+const (
+	IntervalWeekly    = "weekly"
+	IntervalBiweekly  = "biweekly"
+	IntervalMonthly   = "monthly"
+	IntervalQuarterly = "quarterly"
+	IntervalYearly    = "yearly"
+)
+
+// Event sources
+const (
+	SourceRecurring = "recurring"
+	SourceInterest  = "interest"
+)
+
+// amountTolerance is the allowed deviation of an occurrence from the median amount
+var amountTolerance = decimal.NewFromFloat(0.1)
+
+// interval describes a supported recurrence with its expected gap in days
+type interval struct {
+	name      string
+	days      int
+	tolerance int // allowed deviation of a single gap in days
+	minCount  int // occurrences required to trust the pattern
+}
+
+var intervals = []interval{
+	{name: IntervalWeekly, days: 7, tolerance: 1, minCount: 4},
+	{name: IntervalBiweekly, days: 14, tolerance: 2, minCount: 3},
+	{name: IntervalMonthly, days: 30, tolerance: 4, minCount: 3},
+	{name: IntervalQuarterly, days: 91, tolerance: 7, minCount: 3},
+	{name: IntervalYearly, days: 365, tolerance: 10, minCount: 2},
+}
+
+// Occurrence is a past transaction used for pattern detection
+type Occurrence struct {
+	AccountID   uuid.UUID
+	CategoryID  uuid.UUID
+	Type        string // income, expense
+	Description string
+	Amount      decimal.Decimal
+	Date        time.Time
+}
+
+// Pattern is a transaction repeating with the same payee, amount and interval
+type Pattern struct {
+	AccountID   uuid.UUID
+	CategoryID  uuid.UUID
+	Type        string
+	Description string
+	Amount      decimal.Decimal // median amount of the occurrences
+	Interval    string
+	LastDate    time.Time
+	Occurrences int
+}
+
+// Event is an expected transaction in the forecast period
+type Event struct {
+	Date        time.Time
+	AccountID   uuid.UUID
+	CategoryID  uuid.UUID
+	Type        string
+	Description string
+	Amount      decimal.Decimal
+	Source      string // recurring, interest
+	Interval    string
+}
+
+// Day is a projected end-of-day balance
+type Day struct {
+	Date    time.Time
+	Balance decimal.Decimal
+	Income  decimal.Decimal
+	Expense decimal.Decimal
+}
+
+// DetectRecurring finds recurring transactions. Occurrences are grouped by
+// account, type, category and description (the payee); a group is recurring
+// if amounts stay within 10% of the median and gaps between dates match one
+// of the supported intervals.
+func DetectRecurring(occurrences []Occurrence) []Pattern {
+	type key struct {
+		accountID   uuid.UUID
+		categoryID  uuid.UUID
+		txType      string
+		description string
+	}
+
+	groups := make(map[key][]Occurrence)
+	var order []key
+	for _, o := range occurrences {
+		k := key{o.AccountID, o.CategoryID, o.Type, normalizeDescription(o.Description)}
+		if _, exists := groups[k]; !exists {
+			order = append(order, k)
+		}
+		groups[k] = append(groups[k], o)
+	}
+
+	var patterns []Pattern
+	for _, k := range order {
+		group := groups[k]
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].Date.Before(group[j].Date)
+		})
+
+		amounts := make([]decimal.Decimal, len(group))
+		for i, o := range group {
+			amounts[i] = o.Amount
+		}
+		amount := median(amounts)
+		if !amount.IsPositive() || !amountsStable(amounts, amount) {
+			continue
+		}
+
+		iv, ok := matchInterval(group)
+		if !ok {
+			continue
+		}
+
+		patterns = append(patterns, Pattern{
+			AccountID:   k.accountID,
+			CategoryID:  k.categoryID,
+			Type:        k.txType,
+			Description: strings.TrimSpace(group[len(group)-1].Description),
+			Amount:      amount,
+			Interval:    iv.name,
+			LastDate:    group[len(group)-1].Date,
+			Occurrences: len(group),
+		})
+	}
+
+	return patterns
+}
+
+// Events returns expected occurrences of the pattern after today up to until.
+// A pattern overdue by more than the interval tolerance is considered stopped;
+// a slightly overdue one is expected tomorrow.
+func (p Pattern) Events(today, until time.Time) []Event {
+	iv, ok := findInterval(p.Interval)
+	if !ok {
+		return nil
+	}
+
+	next := p.step(p.LastDate, 1)
+	if !next.After(today) {
+		if daysBetween(next, today) > iv.tolerance {
+			return nil
+		}
+		next = today.AddDate(0, 0, 1)
+	}
+
+	var events []Event
+	for n := 1; !next.After(until); n++ {
+		events = append(events, Event{
+			Date:        next,
+			AccountID:   p.AccountID,
+			CategoryID:  p.CategoryID,
+			Type:        p.Type,
+			Description: p.Description,
+			Amount:      p.Amount,
+			Source:      SourceRecurring,
+			Interval:    p.Interval,
+		})
+		next = p.step(p.LastDate, n+1)
+		if !next.After(events[len(events)-1].Date) {
+			next = events[len(events)-1].Date.AddDate(0, 0, iv.days)
+		}
+	}
+
+	return events
+}
+
+// step returns the date n intervals after from. Calendar intervals keep the day of month.
+func (p Pattern) step(from time.Time, n int) time.Time {
+	switch p.Interval {
+	case IntervalWeekly:
+		return from.AddDate(0, 0, 7*n)
+	case IntervalBiweekly:
+		return from.AddDate(0, 0, 14*n)
+	case IntervalQuarterly:
+		return addMonths(from, 3*n)
+	case IntervalYearly:
+		return addMonths(from, 12*n)
+	default:
+		return addMonths(from, n)
+	}
+}
+
+// Project returns end-of-day balances from the day after today up to until,
+// starting from the current balance and applying the events
+func Project(balance decimal.Decimal, events []Event, today, until time.Time) []Day {
+	byDate := make(map[time.Time][]Event)
+	for _, e := range events {
+		byDate[e.Date] = append(byDate[e.Date], e)
+	}
+
+	var days []Day
+	for date := today.AddDate(0, 0, 1); !date.After(until); date = date.AddDate(0, 0, 1) {
+		day := Day{Date: date}
+		for _, e := range byDate[date] {
+			if e.Type == "income" {
+				day.Income = day.Income.Add(e.Amount)
+			} else {
+				day.Expense = day.Expense.Add(e.Amount)
+			}
+		}
+		balance = balance.Add(day.Income).Sub(day.Expense)
+		day.Balance = balance
+		days = append(days, day)
+	}
+
+	return days
+}
+
+// LowPoints returns up to n days where the balance bottoms out before rising
+// again (or the period ends), lowest first. For a flat stretch the first day
+// of the stretch is returned.
+func LowPoints(days []Day, n int) []Day {
+	var lows []Day
+	for i := 0; i < len(days); {
+		// Find the end of the stretch with the same balance
+		j := i
+		for j+1 < len(days) && days[j+1].Balance.Equal(days[i].Balance) {
+			j++
+		}
+
+		fallsInto := i == 0 || days[i-1].Balance.GreaterThan(days[i].Balance)
+		risesAfter := j == len(days)-1 || days[j+1].Balance.GreaterThan(days[i].Balance)
+		if fallsInto && risesAfter {
+			lows = append(lows, days[i])
+		}
+		i = j + 1
+	}
+
+	sort.SliceStable(lows, func(i, j int) bool {
+		return lows[i].Balance.LessThan(lows[j].Balance)
+	})
+	if len(lows) > n {
+		lows = lows[:n]
+	}
+	return lows
+}
+
+// --- Helper functions ---
+
+func normalizeDescription(description string) string {
+	return strings.Join(strings.Fields(strings.ToLower(description)), " ")
+}
+
+func median(values []decimal.Decimal) decimal.Decimal {
+	if len(values) == 0 {
+		return decimal.Zero
+	}
+	sorted := make([]decimal.Decimal, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return sorted[mid-1].Add(sorted[mid]).Div(decimal.NewFromInt(2))
+}
+
+func amountsStable(amounts []decimal.Decimal, median decimal.Decimal) bool {
+	limit := median.Mul(amountTolerance)
+	for _, a := range amounts {
+		if a.Sub(median).Abs().GreaterThan(limit) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchInterval returns the interval all gaps of the sorted group agree with
+func matchInterval(group []Occurrence) (interval, bool) {
+	if len(group) < 2 {
+		return interval{}, false
+	}
+
+	gaps := make([]int, 0, len(group)-1)
+	for i := 1; i < len(group); i++ {
+		gaps = append(gaps, daysBetween(group[i-1].Date, group[i].Date))
+	}
+
+	for _, iv := range intervals {
+		if len(group) < iv.minCount {
+			continue
+		}
+		matches := true
+		for _, gap := range gaps {
+			if abs(gap-iv.days) > iv.tolerance {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return iv, true
+		}
+	}
+
+	return interval{}, false
+}
+
+func findInterval(name string) (interval, bool) {
+	for _, iv := range intervals {
+		if iv.name == name {
+			return iv, true
+		}
+	}
+	return interval{}, false
+}
+
+// addMonths adds months keeping the day of month, clamped to the last day of the target month
+func addMonths(t time.Time, months int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,315 @@
+package insights
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Finding kinds
+const (
+	KindCategorySpike    = "category_spike"
+	KindLargeTransaction = "large_transaction"
+	KindNewPayee         = "new_payee"
+)
+
+// Severities
+const (
+	SeverityMedium = "medium"
+	SeverityHigh   = "high"
+)
+
+var (
+	// spikeScore is the robust z-score above which a value is anomalous
+	// (the usual 3.5 cut-off for modified z-scores)
+	spikeScore = decimal.NewFromFloat(3.5)
+	// newPayeeScore is lower: a charge from an unknown payee is suspicious by itself
+	newPayeeScore = decimal.NewFromInt(2)
+	// maxScore caps the score when the history has no spread at all
+	maxScore = decimal.NewFromInt(99)
+
+	// Values must also exceed the median by this factor, so that small
+	// absolute changes of very stable categories are not reported
+	minSpikeRatio       = decimal.NewFromFloat(1.5)
+	minTransactionRatio = decimal.NewFromInt(2)
+
+	madScale    = decimal.NewFromFloat(1.4826) // MAD to standard deviation of a normal distribution
+	meanADScale = decimal.NewFromFloat(1.2533) // mean absolute deviation to standard deviation
+)
+
+const (
+	minActiveMonths        = 3  // months with spending required to judge a category
+	minCategoryHistory     = 5  // transactions required to judge a single amount in a category
+	minPayeeHistory        = 20 // transactions required to judge a new payee
+	maxFindingTransactions = 10 // transactions linked to a category spike
+)
+
+// Transaction is an expense used for detection
+type Transaction struct {
+	ID          uuid.UUID
+	CategoryID  uuid.UUID
+	Description string
+	Amount      decimal.Decimal
+	Date        time.Time
+}
+
+// Finding is an unusual month total or transaction
+type Finding struct {
+	Kind         string
+	Severity     string
+	CategoryID   uuid.UUID
+	Description  string          // payee of new payee findings
+	Amount       decimal.Decimal // period total of the category or transaction amount
+	Expected     decimal.Decimal // median of the history
+	Score        decimal.Decimal // robust z-score
+	Transactions []Transaction   // largest first
+}
+
+// Detect finds anomalies of the current period against history.
+// History holds expenses of the trailing months before the period start,
+// months is the number of those months.
+func Detect(history, current []Transaction, historyStart time.Time, months int) []Finding {
+	var findings []Finding
+	findings = append(findings, categorySpikes(history, current, historyStart, months)...)
+
+	flagged := make(map[uuid.UUID]bool)
+	for _, f := range largeTransactions(history, current) {
+		flagged[f.Transactions[0].ID] = true
+		findings = append(findings, f)
+	}
+	for _, f := range newPayees(history, current) {
+		if !flagged[f.Transactions[0].ID] {
+			findings = append(findings, f)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Score.GreaterThan(findings[j].Score)
+	})
+	return findings
+}
+
+// categorySpikes compares the period total of each category with its monthly totals
+func categorySpikes(history, current []Transaction, historyStart time.Time, months int) []Finding {
+	monthly := make(map[uuid.UUID][]decimal.Decimal)
+	for _, t := range history {
+		i := monthIndex(historyStart, t.Date)
+		if i < 0 || i >= months {
+			continue
+		}
+		totals, exists := monthly[t.CategoryID]
+		if !exists {
+			totals = make([]decimal.Decimal, months)
+			monthly[t.CategoryID] = totals
+		}
+		totals[i] = totals[i].Add(t.Amount)
+	}
+
+	byCategory := make(map[uuid.UUID][]Transaction)
+	var order []uuid.UUID
+	for _, t := range current {
+		if _, exists := byCategory[t.CategoryID]; !exists {
+			order = append(order, t.CategoryID)
+		}
+		byCategory[t.CategoryID] = append(byCategory[t.CategoryID], t)
+	}
+
+	var findings []Finding
+	for _, categoryID := range order {
+		totals := monthly[categoryID]
+		if activeMonths(totals) < minActiveMonths {
+			continue
+		}
+
+		transactions := byCategory[categoryID]
+		var total decimal.Decimal
+		for _, t := range transactions {
+			total = total.Add(t.Amount)
+		}
+
+		score, expected := robustScore(totals, total)
+		if score.LessThan(spikeScore) || total.LessThan(expected.Mul(minSpikeRatio)) {
+			continue
+		}
+
+		sortByAmount(transactions)
+		if len(transactions) > maxFindingTransactions {
+			transactions = transactions[:maxFindingTransactions]
+		}
+
+		findings = append(findings, Finding{
+			Kind:         KindCategorySpike,
+			Severity:     severity(score, spikeScore),
+			CategoryID:   categoryID,
+			Amount:       total,
+			Expected:     expected,
+			Score:        score,
+			Transactions: transactions,
+		})
+	}
+
+	return findings
+}
+
+// largeTransactions compares each transaction with past amounts of its category
+func largeTransactions(history, current []Transaction) []Finding {
+	amounts := make(map[uuid.UUID][]decimal.Decimal)
+	for _, t := range history {
+		amounts[t.CategoryID] = append(amounts[t.CategoryID], t.Amount)
+	}
+
+	var findings []Finding
+	for _, t := range current {
+		past := amounts[t.CategoryID]
+		if len(past) < minCategoryHistory {
+			continue
+		}
+
+		score, expected := robustScore(past, t.Amount)
+		if score.LessThan(spikeScore) || t.Amount.LessThan(expected.Mul(minTransactionRatio)) {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Kind:         KindLargeTransaction,
+			Severity:     severity(score, spikeScore),
+			CategoryID:   t.CategoryID,
+			Amount:       t.Amount,
+			Expected:     expected,
+			Score:        score,
+			Transactions: []Transaction{t},
+		})
+	}
+
+	return findings
+}
+
+// newPayees finds large charges from descriptions never seen in the history.
+// Amounts are compared with all past expenses.
+func newPayees(history, current []Transaction) []Finding {
+	if len(history) < minPayeeHistory {
+		return nil
+	}
+
+	known := make(map[string]bool)
+	amounts := make([]decimal.Decimal, len(history))
+	for i, t := range history {
+		known[normalizeDescription(t.Description)] = true
+		amounts[i] = t.Amount
+	}
+
+	var findings []Finding
+	for _, t := range current {
+		payee := normalizeDescription(t.Description)
+		if payee == "" || known[payee] {
+			continue
+		}
+
+		score, expected := robustScore(amounts, t.Amount)
+		if score.LessThan(newPayeeScore) || t.Amount.LessThan(expected.Mul(minTransactionRatio)) {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Kind:         KindNewPayee,
+			Severity:     severity(score, newPayeeScore),
+			CategoryID:   t.CategoryID,
+			Description:  strings.TrimSpace(t.Description),
+			Amount:       t.Amount,
+			Expected:     expected,
+			Score:        score,
+			Transactions: []Transaction{t},
+		})
+	}
+
+	return findings
+}
+
+// --- Helper functions ---
+
+// robustScore returns the modified z-score of value against values and their
+// median. The median absolute deviation is used as spread, falling back to
+// the mean absolute deviation when more than half of the values are equal.
+func robustScore(values []decimal.Decimal, value decimal.Decimal) (decimal.Decimal, decimal.Decimal) {
+	m := median(values)
+
+	deviations := make([]decimal.Decimal, len(values))
+	var sum decimal.Decimal
+	for i, v := range values {
+		deviations[i] = v.Sub(m).Abs()
+		sum = sum.Add(deviations[i])
+	}
+
+	spread := median(deviations).Mul(madScale)
+	if spread.IsZero() && len(values) > 0 {
+		spread = sum.Div(decimal.NewFromInt(int64(len(values)))).Mul(meanADScale)
+	}
+
+	diff := value.Sub(m)
+	if spread.IsZero() {
+		// All values are equal: any increase is as unusual as it gets
+		if diff.IsPositive() {
+			return maxScore, m
+		}
+		return decimal.Zero, m
+	}
+
+	score := diff.Div(spread).Round(2)
+	if score.GreaterThan(maxScore) {
+		score = maxScore
+	}
+	return score, m
+}
+
+// severity is high when the score is at least twice the threshold
+func severity(score, threshold decimal.Decimal) string {
+	if score.GreaterThanOrEqual(threshold.Mul(decimal.NewFromInt(2))) {
+		return SeverityHigh
+	}
+	return SeverityMedium
+}
+
+func activeMonths(totals []decimal.Decimal) int {
+	n := 0
+	for _, t := range totals {
+		if t.IsPositive() {
+			n++
+		}
+	}
+	return n
+}
+
+// monthIndex returns the number of months from start to the month of date
+func monthIndex(start, date time.Time) int {
+	return (date.Year()-start.Year())*12 + int(date.Month()) - int(start.Month())
+}
+
+func sortByAmount(transactions []Transaction) {
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Amount.GreaterThan(transactions[j].Amount)
+	})
+}
+
+func normalizeDescription(description string) string {
+	return strings.Join(strings.Fields(strings.ToLower(description)), " ")
+}
+
+func median(values []decimal.Decimal) decimal.Decimal {
+	if len(values) == 0 {
+		return decimal.Zero
+	}
+	sorted := make([]decimal.Decimal, len(values))
+	copy(sorted, values)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LessThan(sorted[j])
+	})
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid]
+	}
+	return sorted[mid-1].Add(sorted[mid]).Div(decimal.NewFromInt(2))
+}
@@ -0,0 +1,82 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/DigitLock/expense-tracker/internal/exchangerates"
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+// ExchangeRateJob stores the latest published exchange rates
+type ExchangeRateJob struct {
+	chain            *exchangerates.Chain
+	exchangeRateRepo *repository.ExchangeRateRepository
+	currencyRepo     *repository.CurrencyRepository
+}
+
+// NewExchangeRateJob creates a new ExchangeRateJob
+func NewExchangeRateJob(
+	chain *exchangerates.Chain,
+	exchangeRateRepo *repository.ExchangeRateRepository,
+	currencyRepo *repository.CurrencyRepository,
+) *ExchangeRateJob {
+	return &ExchangeRateJob{
+		chain:            chain,
+		exchangeRateRepo: exchangeRateRepo,
+		currencyRepo:     currencyRepo,
+	}
+}
+
+// Name returns job name for logging
+func (j *ExchangeRateJob) Name() string {
+	return "exchange_rates"
+}
+
+// Run fetches rates from the first available provider and upserts them.
+// Rates of currencies missing from the currencies table are skipped.
+func (j *ExchangeRateJob) Run(ctx context.Context) error {
+	result, err := j.chain.Fetch(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch exchange rates: %w", err)
+	}
+
+	currencies, err := j.currencyRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list currencies: %w", err)
+	}
+
+	known := make(map[string]bool, len(currencies))
+	for _, c := range currencies {
+		known[c.Code] = true
+	}
+
+	var stored int
+	for _, rate := range result.Rates {
+		if !known[rate.From] || !known[rate.To] || rate.From == rate.To {
+			continue
+		}
+
+		// Stored with 6 decimal places
+		value := rate.Rate.Round(6)
+		if !value.IsPositive() {
+			continue
+		}
+
+		_, err := j.exchangeRateRepo.Upsert(ctx, repository.CreateRateInput{
+			FromCurrency: rate.From,
+			ToCurrency:   rate.To,
+			Rate:         value,
+			Date:         rate.Date,
+			Source:       result.Source,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to store %s/%s rate: %w", rate.From, rate.To, err)
+		}
+		stored++
+	}
+
+	log.Printf("💱 Stored %d exchange rate(s) from %s", stored, result.Source)
+	return nil
+}
@@ -0,0 +1,73 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/DigitLock/expense-tracker/internal/repository"
+)
+
+// InterestJob posts due interest income for savings accounts
+type InterestJob struct {
+	interestRepo *repository.InterestRepository
+}
+
+// NewInterestJob creates a new InterestJob
+func NewInterestJob(interestRepo *repository.InterestRepository) *InterestJob {
+	return &InterestJob{interestRepo: interestRepo}
+}
+
+// Name returns job name for logging
+func (j *InterestJob) Name() string {
+	return "interest_accrual"
+}
+
+// Run posts interest for every payout date up to today, catching up on missed runs
+func (j *InterestJob) Run(ctx context.Context) error {
+	settings, err := j.interestRepo.ListForPosting(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list interest settings: %w", err)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var posted, failed int
+	for _, s := range settings {
+		for {
+			payoutDate := repository.NextInterestPayoutDate(s)
+			if payoutDate.After(today) {
+				break
+			}
+
+			result, err := j.interestRepo.PostInterest(ctx, s, payoutDate)
+			if errors.Is(err, repository.ErrInterestAlreadyPosted) {
+				break
+			}
+			if err != nil {
+				log.Printf("❌ Failed to post interest for account %s on %s: %v",
+					s.AccountID, payoutDate.Format("2006-01-02"), err)
+				failed++
+				break
+			}
+
+			s.LastPostedDate = pgtype.Date{Time: payoutDate, Valid: true}
+			if result.Transaction != nil {
+				posted++
+			}
+		}
+	}
+
+	if posted > 0 {
+		log.Printf("💰 Posted %d interest transaction(s)", posted)
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to post interest for %d account(s)", failed)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// Job is a unit of background work executed periodically by Scheduler
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs at a fixed interval
+type Scheduler struct {
+	interval time.Duration
+	jobs     []Job
+	wg       sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler
+func NewScheduler(interval time.Duration, jobs ...Job) *Scheduler {
+	return &Scheduler{
+		interval: interval,
+		jobs:     jobs,
+	}
+}
+
+// Start runs all jobs immediately and then on every tick until ctx is cancelled
+func (s *Scheduler) Start(ctx context.Context) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		s.runAll(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.runAll(ctx)
+			}
+		}
+	}()
+}
+
+// Wait blocks until the scheduler has stopped
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) runAll(ctx context.Context) {
+	for _, job := range s.jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		if err := job.Run(ctx); err != nil {
+			log.Printf("❌ Job %s failed: %v", job.Name(), err)
+		}
+	}
+}
@@ -0,0 +1,439 @@
+package reportbuilder
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Limits of a definition
+const (
+	DefaultLimit = 1000
+	MaxLimit     = 10000
+	maxFilterIDs = 100
+)
+
+// Column types of a result
+const (
+	TypeUUID    = "uuid"
+	TypeString  = "string"
+	TypeDate    = "date"
+	TypeInteger = "integer"
+	TypeDecimal = "decimal"
+)
+
+// Column roles of a result
+const (
+	RoleDimension = "dimension"
+	RoleMeasure   = "measure"
+)
+
+// Definition describes an aggregation of transactions
+type Definition struct {
+	Measures   []string `json:"measures"`             // sum, count, avg (of amount_base)
+	Dimensions []string `json:"dimensions,omitempty"` // see Dimensions
+	Filters    Filters  `json:"filters"`
+	Sort       []Sort   `json:"sort,omitempty"`
+	Limit      int      `json:"limit,omitempty"` // default 1000, max 10000
+}
+
+// Filters restrict the aggregated transactions. Empty fields do not filter.
+type Filters struct {
+	Period      string           `json:"period,omitempty"`       // relative period, see Periods; overrides dates
+	StartDate   string           `json:"start_date,omitempty"`   // YYYY-MM-DD
+	EndDate     string           `json:"end_date,omitempty"`     // YYYY-MM-DD
+	Type        string           `json:"type,omitempty"`         // income, expense
+	CategoryIDs []uuid.UUID      `json:"category_ids,omitempty"` // including subcategories
+	AccountIDs  []uuid.UUID      `json:"account_ids,omitempty"`
+	MemberIDs   []uuid.UUID      `json:"member_ids,omitempty"`
+	Currencies  []string         `json:"currencies,omitempty"`
+	MinAmount   *decimal.Decimal `json:"min_amount,omitempty"` // amount_base
+	MaxAmount   *decimal.Decimal `json:"max_amount,omitempty"` // amount_base
+}
+
+// Sort orders the result by a measure or dimension
+type Sort struct {
+	Field string `json:"field"`
+	Desc  bool   `json:"desc,omitempty"`
+}
+
+// Column describes a column of the result
+type Column struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Role string `json:"role"`
+}
+
+// Query is a parameterized SQL query built from a definition. Column names
+// are whitelisted identifiers, quoted in the SQL.
+type Query struct {
+	SQL     string
+	Args    []any
+	Columns []Column
+	Start   *time.Time // resolved period, nil = unbounded
+	End     *time.Time
+}
+
+// ValidationError is an invalid field of a definition
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// measure is a whitelisted aggregate
+type measure struct {
+	expr string
+	typ  string
+}
+
+var measures = map[string]measure{
+	"sum":   {expr: "COALESCE(SUM(t.amount_base), 0)::numeric", typ: TypeDecimal},
+	"count": {expr: "COUNT(t.id)", typ: TypeInteger},
+	"avg":   {expr: "COALESCE(ROUND(AVG(t.amount_base), 2), 0)::numeric", typ: TypeDecimal},
+}
+
+// dimension is a whitelisted grouping with its output columns.
+// The last column is used for sorting.
+type dimension struct {
+	columns []dimensionColumn
+	join    string
+	time    bool
+}
+
+type dimensionColumn struct {
+	name string
+	expr string
+	typ  string
+}
+
+const (
+	joinAccounts = "JOIN accounts a ON a.id = t.account_id"
+	joinUsers    = "LEFT JOIN users u ON u.id = t.created_by"
+	joinRoots    = "JOIN category_roots cr ON cr.id = t.category_id JOIN categories rc ON rc.id = cr.root_id"
+)
+
+var dimensions = map[string]dimension{
+	"category": {columns: []dimensionColumn{
+		{"category_id", "c.id", TypeUUID},
+		{"category_name", "c.name", TypeString},
+	}},
+	"root_category": {join: joinRoots, columns: []dimensionColumn{
+		{"root_category_id", "rc.id", TypeUUID},
+		{"root_category_name", "rc.name", TypeString},
+	}},
+	"account": {join: joinAccounts, columns: []dimensionColumn{
+		{"account_id", "a.id", TypeUUID},
+		{"account_name", "a.name", TypeString},
+	}},
+	"type": {columns: []dimensionColumn{
+		{"type", "t.type", TypeString},
+	}},
+	"member": {join: joinUsers, columns: []dimensionColumn{
+		{"member_id", "t.created_by", TypeUUID},
+		{"member_name", "COALESCE(u.name, 'Former member')", TypeString},
+	}},
+	"currency": {columns: []dimensionColumn{
+		{"currency", "t.currency", TypeString},
+	}},
+	"day": {time: true, columns: []dimensionColumn{
+		{"day", "t.transaction_date", TypeDate},
+	}},
+	"week": {time: true, columns: []dimensionColumn{
+		{"week", "date_trunc('week', t.transaction_date)::date", TypeDate}, // Monday of the ISO week
+	}},
+	"month": {time: true, columns: []dimensionColumn{
+		{"month", "to_char(t.transaction_date, 'YYYY-MM')", TypeString},
+	}},
+	"quarter": {time: true, columns: []dimensionColumn{
+		{"quarter", `to_char(t.transaction_date, 'YYYY-"Q"Q')`, TypeString},
+	}},
+	"year": {time: true, columns: []dimensionColumn{
+		{"year", "EXTRACT(YEAR FROM t.transaction_date)::integer", TypeInteger},
+	}},
+}
+
+// Measures lists supported measures
+func Measures() []string {
+	return []string{"sum", "count", "avg"}
+}
+
+// Dimensions lists supported dimensions
+func Dimensions() []string {
+	return []string{"category", "root_category", "account", "type", "member", "currency", "day", "week", "month", "quarter", "year"}
+}
+
+// Periods lists supported relative periods
+func Periods() []string {
+	return []string{"this_month", "last_month", "last_30_days", "last_90_days", "this_quarter", "last_quarter", "this_year", "last_year", "last_12_months"}
+}
+
+var currencyCode = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Validate checks the definition without building the query
+func (d Definition) Validate() error {
+	_, err := Build(d, uuid.Nil, time.Now())
+	return err
+}
+
+// Build builds the query of the definition for a family. Relative periods are
+// resolved against today. Only whitelisted expressions end up in the SQL; all
+// values are passed as parameters.
+func Build(d Definition, familyID uuid.UUID, today time.Time) (Query, error) {
+	var q Query
+	args := []any{familyID}
+	param := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if len(d.Measures) == 0 {
+		return Query{}, &ValidationError{"measures", "At least one measure is required"}
+	}
+
+	var selects, joins, groupBy []string
+	var timeColumns, measureColumns []string
+	sortable := make(map[string]string) // field name -> output column
+	seen := make(map[string]bool)
+
+	// Dimensions
+	for _, name := range d.Dimensions {
+		dim, ok := dimensions[name]
+		if !ok {
+			return Query{}, &ValidationError{"dimensions", fmt.Sprintf("Unknown dimension %q, supported: %s", name, strings.Join(Dimensions(), ", "))}
+		}
+		if seen[name] {
+			return Query{}, &ValidationError{"dimensions", fmt.Sprintf("Dimension %q is listed twice", name)}
+		}
+		seen[name] = true
+
+		if dim.join != "" && !contains(joins, dim.join) {
+			joins = append(joins, dim.join)
+		}
+		for _, col := range dim.columns {
+			selects = append(selects, fmt.Sprintf("%s AS %q", col.expr, col.name))
+			groupBy = append(groupBy, col.expr)
+			q.Columns = append(q.Columns, Column{Name: col.name, Type: col.typ, Role: RoleDimension})
+		}
+		last := dim.columns[len(dim.columns)-1].name
+		sortable[name] = last
+		if dim.time {
+			timeColumns = append(timeColumns, last)
+		}
+	}
+
+	// Measures
+	for _, name := range d.Measures {
+		m, ok := measures[name]
+		if !ok {
+			return Query{}, &ValidationError{"measures", fmt.Sprintf("Unknown measure %q, supported: %s", name, strings.Join(Measures(), ", "))}
+		}
+		if seen[name] {
+			return Query{}, &ValidationError{"measures", fmt.Sprintf("Measure %q is listed twice", name)}
+		}
+		seen[name] = true
+
+		selects = append(selects, fmt.Sprintf("%s AS %q", m.expr, name))
+		q.Columns = append(q.Columns, Column{Name: name, Type: m.typ, Role: RoleMeasure})
+		sortable[name] = name
+		measureColumns = append(measureColumns, name)
+	}
+
+	// Filters
+	where := []string{"t.family_id = $1", "t.is_active = true", "c.is_system = false"}
+	f := d.Filters
+
+	start, end, err := resolvePeriod(f, today)
+	if err != nil {
+		return Query{}, err
+	}
+	if start != nil {
+		where = append(where, "t.transaction_date >= "+param(*start))
+		q.Start = start
+	}
+	if end != nil {
+		where = append(where, "t.transaction_date <= "+param(*end))
+		q.End = end
+	}
+
+	switch f.Type {
+	case "":
+	case "income", "expense":
+		where = append(where, "t.type = "+param(f.Type))
+	default:
+		return Query{}, &ValidationError{"filters.type", "Type must be income or expense"}
+	}
+
+	if len(f.CategoryIDs) > 0 {
+		if len(f.CategoryIDs) > maxFilterIDs {
+			return Query{}, &ValidationError{"filters.category_ids", fmt.Sprintf("At most %d categories", maxFilterIDs)}
+		}
+		where = append(where, fmt.Sprintf(`t.category_id IN (
+        WITH RECURSIVE subtree AS (
+            SELECT id FROM categories WHERE id = ANY(%s::uuid[]) AND family_id = $1
+            UNION ALL
+            SELECT sc.id FROM categories sc JOIN subtree s ON sc.parent_id = s.id
+        )
+        SELECT id FROM subtree
+    )`, param(f.CategoryIDs)))
+	}
+
+	if len(f.AccountIDs) > 0 {
+		if len(f.AccountIDs) > maxFilterIDs {
+			return Query{}, &ValidationError{"filters.account_ids", fmt.Sprintf("At most %d accounts", maxFilterIDs)}
+		}
+		where = append(where, fmt.Sprintf("t.account_id = ANY(%s::uuid[])", param(f.AccountIDs)))
+	}
+
+	if len(f.MemberIDs) > 0 {
+		if len(f.MemberIDs) > maxFilterIDs {
+			return Query{}, &ValidationError{"filters.member_ids", fmt.Sprintf("At most %d members", maxFilterIDs)}
+		}
+		where = append(where, fmt.Sprintf("t.created_by = ANY(%s::uuid[])", param(f.MemberIDs)))
+	}
+
+	if len(f.Currencies) > 0 {
+		for _, c := range f.Currencies {
+			if !currencyCode.MatchString(c) {
+				return Query{}, &ValidationError{"filters.currencies", fmt.Sprintf("Invalid currency code %q", c)}
+			}
+		}
+		where = append(where, fmt.Sprintf("t.currency = ANY(%s::text[])", param(f.Currencies)))
+	}
+
+	if f.MinAmount != nil {
+		where = append(where, fmt.Sprintf("t.amount_base >= %s", param(*f.MinAmount)))
+	}
+	if f.MaxAmount != nil {
+		where = append(where, fmt.Sprintf("t.amount_base <= %s", param(*f.MaxAmount)))
+	}
+	if f.MinAmount != nil && f.MaxAmount != nil && f.MinAmount.GreaterThan(*f.MaxAmount) {
+		return Query{}, &ValidationError{"filters.min_amount", "Min amount must not be greater than max amount"}
+	}
+
+	// Sort: explicit fields, otherwise time ascending and the first measure descending
+	var orderBy []string
+	for _, s := range d.Sort {
+		col, ok := sortable[s.Field]
+		if !ok {
+			return Query{}, &ValidationError{"sort", fmt.Sprintf("Cannot sort by %q: not a selected measure or dimension", s.Field)}
+		}
+		dir := "ASC"
+		if s.Desc {
+			dir = "DESC"
+		}
+		orderBy = append(orderBy, fmt.Sprintf("%q %s", col, dir))
+	}
+	if len(orderBy) == 0 {
+		for _, col := range timeColumns {
+			orderBy = append(orderBy, fmt.Sprintf("%q ASC", col))
+		}
+		orderBy = append(orderBy, fmt.Sprintf("%q DESC", measureColumns[0]))
+	}
+
+	limit := d.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 1 || limit > MaxLimit {
+		return Query{}, &ValidationError{"limit", fmt.Sprintf("Limit must be between 1 and %d", MaxLimit)}
+	}
+
+	// Assemble
+	var sql strings.Builder
+	if contains(joins, joinRoots) {
+		sql.WriteString(`WITH RECURSIVE category_roots AS (
+    SELECT id, id AS root_id FROM categories WHERE family_id = $1 AND parent_id IS NULL
+    UNION ALL
+    SELECT ch.id, cr.root_id FROM categories ch JOIN category_roots cr ON ch.parent_id = cr.id
+)
+`)
+	}
+	sql.WriteString("SELECT\n    " + strings.Join(selects, ",\n    "))
+	sql.WriteString("\nFROM transactions t\nJOIN categories c ON c.id = t.category_id")
+	for _, j := range joins {
+		sql.WriteString("\n" + j)
+	}
+	sql.WriteString("\nWHERE " + strings.Join(where, "\n  AND "))
+	if len(groupBy) > 0 {
+		sql.WriteString("\nGROUP BY " + strings.Join(groupBy, ", "))
+	}
+	sql.WriteString("\nORDER BY " + strings.Join(orderBy, ", "))
+	sql.WriteString("\nLIMIT " + param(limit))
+
+	q.SQL = sql.String()
+	q.Args = args
+	return q, nil
+}
+
+// resolvePeriod returns the date range of the filters
+func resolvePeriod(f Filters, today time.Time) (*time.Time, *time.Time, error) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+
+	if f.Period != "" {
+		monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		quarterStart := time.Date(today.Year(), (today.Month()-1)/3*3+1, 1, 0, 0, 0, 0, time.UTC)
+		yearStart := time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+
+		var start, end time.Time
+		switch f.Period {
+		case "this_month":
+			start, end = monthStart, today
+		case "last_month":
+			start, end = monthStart.AddDate(0, -1, 0), monthStart.AddDate(0, 0, -1)
+		case "last_30_days":
+			start, end = today.AddDate(0, 0, -29), today
+		case "last_90_days":
+			start, end = today.AddDate(0, 0, -89), today
+		case "this_quarter":
+			start, end = quarterStart, today
+		case "last_quarter":
+			start, end = quarterStart.AddDate(0, -3, 0), quarterStart.AddDate(0, 0, -1)
+		case "this_year":
+			start, end = yearStart, today
+		case "last_year":
+			start, end = yearStart.AddDate(-1, 0, 0), yearStart.AddDate(0, 0, -1)
+		case "last_12_months":
+			start, end = monthStart.AddDate(0, -11, 0), today
+		default:
+			return nil, nil, &ValidationError{"filters.period", fmt.Sprintf("Unknown period %q, supported: %s", f.Period, strings.Join(Periods(), ", "))}
+		}
+		return &start, &end, nil
+	}
+
+	var start, end *time.Time
+	if f.StartDate != "" {
+		parsed, err := time.Parse("2006-01-02", f.StartDate)
+		if err != nil {
+			return nil, nil, &ValidationError{"filters.start_date", "Start date must be in YYYY-MM-DD format"}
+		}
+		start = &parsed
+	}
+	if f.EndDate != "" {
+		parsed, err := time.Parse("2006-01-02", f.EndDate)
+		if err != nil {
+			return nil, nil, &ValidationError{"filters.end_date", "End date must be in YYYY-MM-DD format"}
+		}
+		end = &parsed
+	}
+	if start != nil && end != nil && start.After(*end) {
+		return nil, nil, &ValidationError{"filters.start_date", "Start date must not be after end date"}
+	}
+	return start, end, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
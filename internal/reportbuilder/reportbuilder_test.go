@@ -0,0 +1,249 @@
+package reportbuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var testFamilyID = uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestBuildRejectsUnknownFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		def   Definition
+		field string
+	}{
+		{"no measures", Definition{}, "measures"},
+		{"unknown measure", Definition{Measures: []string{"max"}}, "measures"},
+		{"measure with SQL", Definition{Measures: []string{"sum; DROP TABLE transactions"}}, "measures"},
+		{"duplicate measure", Definition{Measures: []string{"sum", "sum"}}, "measures"},
+		{"unknown dimension", Definition{Measures: []string{"sum"}, Dimensions: []string{"description"}}, "dimensions"},
+		{"dimension with SQL", Definition{Measures: []string{"sum"}, Dimensions: []string{"t.amount) --"}}, "dimensions"},
+		{"duplicate dimension", Definition{Measures: []string{"sum"}, Dimensions: []string{"month", "month"}}, "dimensions"},
+		{"unknown sort field", Definition{Measures: []string{"sum"}, Sort: []Sort{{Field: "amount"}}}, "sort"},
+		{"sort by unselected dimension", Definition{Measures: []string{"sum"}, Sort: []Sort{{Field: "category"}}}, "sort"},
+		{"sort by unselected measure", Definition{Measures: []string{"sum"}, Sort: []Sort{{Field: "count"}}}, "sort"},
+		{"unknown period", Definition{Measures: []string{"sum"}, Filters: Filters{Period: "yesterday"}}, "filters.period"},
+		{"invalid type", Definition{Measures: []string{"sum"}, Filters: Filters{Type: "transfer"}}, "filters.type"},
+		{"invalid currency", Definition{Measures: []string{"sum"}, Filters: Filters{Currencies: []string{"eur'"}}}, "filters.currencies"},
+		{"invalid start date", Definition{Measures: []string{"sum"}, Filters: Filters{StartDate: "01.01.2024"}}, "filters.start_date"},
+		{"start after end", Definition{Measures: []string{"sum"}, Filters: Filters{StartDate: "2024-02-01", EndDate: "2024-01-01"}}, "filters.start_date"},
+		{"limit too large", Definition{Measures: []string{"sum"}, Limit: MaxLimit + 1}, "limit"},
+		{"negative limit", Definition{Measures: []string{"sum"}, Limit: -1}, "limit"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Build(tt.def, testFamilyID, date(2024, 5, 15))
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			if validationErr.Field != tt.field {
+				t.Errorf("field %q, want %q", validationErr.Field, tt.field)
+			}
+		})
+	}
+}
+
+func TestBuildPassesValuesAsParameters(t *testing.T) {
+	categoryID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440011")
+	accountID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440022")
+	memberID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440033")
+	minAmount := decimal.RequireFromString("1234.56")
+	maxAmount := decimal.RequireFromString("98765.43")
+
+	q, err := Build(Definition{
+		Measures:   []string{"sum", "count"},
+		Dimensions: []string{"category", "month"},
+		Filters: Filters{
+			StartDate:   "2024-01-01",
+			EndDate:     "2024-03-31",
+			Type:        "expense",
+			CategoryIDs: []uuid.UUID{categoryID},
+			AccountIDs:  []uuid.UUID{accountID},
+			MemberIDs:   []uuid.UUID{memberID},
+			Currencies:  []string{"CHF"},
+			MinAmount:   &minAmount,
+			MaxAmount:   &maxAmount,
+		},
+		Limit: 250,
+	}, testFamilyID, date(2024, 5, 15))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, value := range []string{
+		testFamilyID.String(), categoryID.String(), accountID.String(), memberID.String(),
+		"2024-01-01", "2024-03-31", "expense", "CHF", "1234.56", "98765.43", "250",
+	} {
+		if strings.Contains(q.SQL, value) {
+			t.Errorf("SQL contains value %q:\n%s", value, q.SQL)
+		}
+	}
+
+	// Family first, then filters in order and the limit last
+	wantArgs := []any{
+		testFamilyID,
+		date(2024, 1, 1),
+		date(2024, 3, 31),
+		"expense",
+		[]uuid.UUID{categoryID},
+		[]uuid.UUID{accountID},
+		[]uuid.UUID{memberID},
+		[]string{"CHF"},
+		minAmount,
+		maxAmount,
+		250,
+	}
+	if len(q.Args) != len(wantArgs) {
+		t.Fatalf("%d args, want %d: %v", len(q.Args), len(wantArgs), q.Args)
+	}
+	for i, want := range wantArgs {
+		if fmt.Sprint(q.Args[i]) != fmt.Sprint(want) {
+			t.Errorf("$%d = %v, want %v", i+1, q.Args[i], want)
+		}
+	}
+
+	for _, fragment := range []string{
+		"t.family_id = $1",
+		"t.transaction_date >= $2",
+		"t.transaction_date <= $3",
+		"t.type = $4",
+		"id = ANY($5::uuid[])",
+		"t.account_id = ANY($6::uuid[])",
+		"t.created_by = ANY($7::uuid[])",
+		"t.currency = ANY($8::text[])",
+		"t.amount_base >= $9",
+		"t.amount_base <= $10",
+		"LIMIT $11",
+	} {
+		if !strings.Contains(q.SQL, fragment) {
+			t.Errorf("SQL does not contain %q:\n%s", fragment, q.SQL)
+		}
+	}
+	if strings.Contains(q.SQL, "$12") {
+		t.Errorf("SQL references a parameter without argument:\n%s", q.SQL)
+	}
+}
+
+func TestBuildParameterNumbering(t *testing.T) {
+	accountID := uuid.New()
+	minAmount := decimal.NewFromInt(100)
+
+	tests := []struct {
+		name      string
+		filters   Filters
+		fragments []string
+		args      int
+	}{
+		{"no filters", Filters{}, []string{"LIMIT $2"}, 2},
+		{"type only", Filters{Type: "income"}, []string{"t.type = $2", "LIMIT $3"}, 3},
+		{"end date and amount", Filters{EndDate: "2024-01-31", MinAmount: &minAmount},
+			[]string{"t.transaction_date <= $2", "t.amount_base >= $3", "LIMIT $4"}, 4},
+		{"period, account and currency", Filters{Period: "last_month", AccountIDs: []uuid.UUID{accountID}, Currencies: []string{"EUR", "RSD"}},
+			[]string{"t.transaction_date >= $2", "t.transaction_date <= $3", "t.account_id = ANY($4::uuid[])", "t.currency = ANY($5::text[])", "LIMIT $6"}, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Build(Definition{Measures: []string{"sum"}, Filters: tt.filters}, testFamilyID, date(2024, 5, 15))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(q.Args) != tt.args {
+				t.Errorf("%d args, want %d", len(q.Args), tt.args)
+			}
+			for _, fragment := range tt.fragments {
+				if !strings.Contains(q.SQL, fragment) {
+					t.Errorf("SQL does not contain %q:\n%s", fragment, q.SQL)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildOrderBy(t *testing.T) {
+	tests := []struct {
+		name string
+		def  Definition
+		want string
+	}{
+		{
+			"default without time dimension",
+			Definition{Measures: []string{"sum", "count"}, Dimensions: []string{"category"}},
+			`ORDER BY "sum" DESC`,
+		},
+		{
+			"default with time dimensions",
+			Definition{Measures: []string{"count", "sum"}, Dimensions: []string{"year", "category", "month"}},
+			`ORDER BY "year" ASC, "month" ASC, "count" DESC`,
+		},
+		{
+			"explicit",
+			Definition{Measures: []string{"sum"}, Dimensions: []string{"account"}, Sort: []Sort{{Field: "account"}, {Field: "sum", Desc: true}}},
+			`ORDER BY "account_name" ASC, "sum" DESC`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, err := Build(tt.def, testFamilyID, date(2024, 5, 15))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(q.SQL, tt.want+"\nLIMIT") {
+				t.Errorf("SQL does not contain %q:\n%s", tt.want, q.SQL)
+			}
+		})
+	}
+}
+
+func TestBuildResolvesPeriods(t *testing.T) {
+	tests := []struct {
+		period string
+		today  time.Time
+		start  time.Time
+		end    time.Time
+	}{
+		{"last_quarter", date(2024, 5, 15), date(2024, 1, 1), date(2024, 3, 31)},
+		{"last_quarter", date(2024, 2, 10), date(2023, 10, 1), date(2023, 12, 31)},
+		{"last_quarter", date(2024, 12, 31), date(2024, 7, 1), date(2024, 9, 30)},
+		{"last_year", date(2024, 5, 15), date(2023, 1, 1), date(2023, 12, 31)},
+		{"last_year", date(2024, 1, 1), date(2023, 1, 1), date(2023, 12, 31)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period+" "+tt.today.Format("2006-01-02"), func(t *testing.T) {
+			// Period overrides explicit dates
+			q, err := Build(Definition{
+				Measures: []string{"sum"},
+				Filters:  Filters{Period: tt.period, StartDate: "2000-01-01", EndDate: "2000-12-31"},
+			}, testFamilyID, tt.today.Add(15*time.Hour))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if q.Start == nil || q.End == nil {
+				t.Fatalf("period not resolved: start %v, end %v", q.Start, q.End)
+			}
+			if !q.Start.Equal(tt.start) || !q.End.Equal(tt.end) {
+				t.Errorf("period %s - %s, want %s - %s",
+					q.Start.Format("2006-01-02"), q.End.Format("2006-01-02"),
+					tt.start.Format("2006-01-02"), tt.end.Format("2006-01-02"))
+			}
+			if !q.Start.Equal(q.Args[1].(time.Time)) || !q.End.Equal(q.Args[2].(time.Time)) {
+				t.Errorf("args %v, %v do not match the resolved period", q.Args[1], q.Args[2])
+			}
+		})
+	}
+}
@@ -2,8 +2,12 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 
 	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
@@ -12,11 +16,15 @@ import (
 // AccountRepository handles account data operations
 type AccountRepository struct {
 	queries *sqlc.Queries
+	pool    *pgxpool.Pool
 }
 
 // NewAccountRepository creates a new AccountRepository
-func NewAccountRepository(queries *sqlc.Queries) *AccountRepository {
-	return &AccountRepository{queries: queries}
+func NewAccountRepository(queries *sqlc.Queries, pool *pgxpool.Pool) *AccountRepository {
+	return &AccountRepository{
+		queries: queries,
+		pool:    pool,
+	}
 }
 
 // GetByID retrieves an active account by ID
@@ -52,7 +60,7 @@ type CreateAccountInput struct {
 	FamilyID       uuid.UUID
 	Name           string
 	Type           string // cash, checking, savings
-	Currency       string // RSD, EUR
+	Currency       string // ISO 4217 code, see currencies
 	InitialBalance decimal.Decimal
 }
 
@@ -70,15 +78,31 @@ func (r *AccountRepository) Create(ctx context.Context, input CreateAccountInput
 
 // UpdateAccountInput contains data for updating an account (partial update)
 type UpdateAccountInput struct {
-	ID       uuid.UUID
-	Name     *string
-	IsActive *bool
+	ID             uuid.UUID
+	Name           *string
+	IsActive       *bool
+	InitialBalance *decimal.Decimal // current_balance is recalculated when set
+	UpdatedBy      uuid.UUID
 }
 
 // Update updates account details (partial update)
 func (r *AccountRepository) Update(ctx context.Context, input UpdateAccountInput) (sqlc.Account, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.Account{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.UpdatedBy.String()))
+	if err != nil {
+		return sqlc.Account{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
 	// First get current account (including inactive to allow reactivation)
-	current, err := r.queries.GetAccountIncludingInactive(ctx, input.ID)
+	current, err := qtx.GetAccountIncludingInactive(ctx, input.ID)
 	if err != nil {
 		return sqlc.Account{}, err
 	}
@@ -95,11 +119,31 @@ func (r *AccountRepository) Update(ctx context.Context, input UpdateAccountInput
 	}
 
 	// Update with merged values
-	return r.queries.UpdateAccount(ctx, sqlc.UpdateAccountParams{
+	result, err := qtx.UpdateAccount(ctx, sqlc.UpdateAccountParams{
 		ID:       input.ID,
 		Name:     name,
 		IsActive: isActive,
 	})
+	if err != nil {
+		return sqlc.Account{}, fmt.Errorf("failed to update account: %w", err)
+	}
+
+	// Correct initial balance and recalculate current balance from transactions
+	if input.InitialBalance != nil && !input.InitialBalance.Equal(current.InitialBalance) {
+		result, err = qtx.UpdateAccountInitialBalance(ctx, sqlc.UpdateAccountInitialBalanceParams{
+			ID:             input.ID,
+			InitialBalance: *input.InitialBalance,
+		})
+		if err != nil {
+			return sqlc.Account{}, fmt.Errorf("failed to update initial balance: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.Account{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return result, nil
 }
 
 // Delete soft-deletes an account
@@ -116,6 +160,34 @@ func (r *AccountRepository) GetBalance(ctx context.Context, id uuid.UUID) (decim
 	return result.CurrentBalance, result.Currency, nil
 }
 
+// GetBalanceAsOf calculates account balance including transactions up to and including date
+func (r *AccountRepository) GetBalanceAsOf(ctx context.Context, id uuid.UUID, date time.Time) (decimal.Decimal, error) {
+	return r.queries.GetAccountBalanceAsOf(ctx, sqlc.GetAccountBalanceAsOfParams{
+		ID:              id,
+		TransactionDate: pgtype.Date{Time: date, Valid: true},
+	})
+}
+
+// ListBalancesBetween calculates opening (before startDate) and closing (up to and including endDate)
+// balances of all family accounts, including inactive ones
+func (r *AccountRepository) ListBalancesBetween(ctx context.Context, familyID uuid.UUID, startDate, endDate time.Time) ([]sqlc.ListAccountBalancesBetweenRow, error) {
+	return r.queries.ListAccountBalancesBetween(ctx, sqlc.ListAccountBalancesBetweenParams{
+		StartDate: pgtype.Date{Time: startDate, Valid: true},
+		EndDate:   pgtype.Date{Time: endDate, Valid: true},
+		FamilyID:  familyID,
+	})
+}
+
+// GetNetFlow calculates income minus expense (in base currency) on the given accounts within a date range.
+// Transactions in system categories (balance adjustments) are not counted.
+func (r *AccountRepository) GetNetFlow(ctx context.Context, accountIDs []uuid.UUID, startDate, endDate time.Time) (decimal.Decimal, error) {
+	return r.queries.GetAccountsNetFlow(ctx, sqlc.GetAccountsNetFlowParams{
+		AccountIds: accountIDs,
+		StartDate:  pgtype.Date{Time: startDate, Valid: true},
+		EndDate:    pgtype.Date{Time: endDate, Valid: true},
+	})
+}
+
 // GetTotalBalanceByFamily retrieves total balance across all accounts
 func (r *AccountRepository) GetTotalBalanceByFamily(ctx context.Context, familyID uuid.UUID) (decimal.Decimal, int64, error) {
 	result, err := r.queries.GetTotalBalanceByFamily(ctx, familyID)
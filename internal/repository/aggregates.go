@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+)
+
+// AggregateRepository maintains monthly transaction aggregates.
+// Aggregates are kept up to date by a trigger on transactions;
+// this repository rebuilds and verifies them.
+type AggregateRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewAggregateRepository creates a new AggregateRepository
+func NewAggregateRepository(queries *sqlc.Queries) *AggregateRepository {
+	return &AggregateRepository{queries: queries}
+}
+
+// Rebuild recalculates monthly aggregates from raw transactions and returns
+// the number of aggregate rows. uuid.Nil rebuilds all families.
+// Transaction writes are blocked while it runs.
+func (r *AggregateRepository) Rebuild(ctx context.Context, familyID uuid.UUID) (int32, error) {
+	rows, err := r.queries.RebuildMonthlyAggregates(ctx, familyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to rebuild monthly aggregates: %w", err)
+	}
+	return rows, nil
+}
+
+// ListMismatches compares monthly aggregates with raw transactions and returns
+// aggregates that are missing, extra or have different totals. uuid.Nil checks all families.
+func (r *AggregateRepository) ListMismatches(ctx context.Context, familyID uuid.UUID) ([]sqlc.ListMonthlyAggregateMismatchesRow, error) {
+	mismatches, err := r.queries.ListMonthlyAggregateMismatches(ctx, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check monthly aggregates: %w", err)
+	}
+	return mismatches, nil
+}
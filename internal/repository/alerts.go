@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+)
+
+// DefaultAlertPercentages are used when a threshold is created without percentages
+var DefaultAlertPercentages = []int32{80, 100}
+
+// AlertRepository handles alert thresholds and alerts
+type AlertRepository struct {
+	queries *sqlc.Queries
+	pool    *pgxpool.Pool
+}
+
+// NewAlertRepository creates a new AlertRepository
+func NewAlertRepository(queries *sqlc.Queries, pool *pgxpool.Pool) *AlertRepository {
+	return &AlertRepository{
+		queries: queries,
+		pool:    pool,
+	}
+}
+
+// GetThreshold retrieves an active alert threshold by ID
+func (r *AlertRepository) GetThreshold(ctx context.Context, id uuid.UUID) (sqlc.AlertThreshold, error) {
+	return r.queries.GetAlertThreshold(ctx, id)
+}
+
+// ListThresholds retrieves all active alert thresholds in a family
+func (r *AlertRepository) ListThresholds(ctx context.Context, familyID uuid.UUID) ([]sqlc.AlertThreshold, error) {
+	return r.queries.ListAlertThresholdsByFamily(ctx, familyID)
+}
+
+// CreateThresholdInput contains data for creating an alert threshold
+type CreateThresholdInput struct {
+	FamilyID    uuid.UUID
+	CategoryID  *uuid.UUID       // nil = overall monthly spending
+	LimitAmount *decimal.Decimal // nil = monthly budget of the category
+	Percentages []int32          // empty = DefaultAlertPercentages
+	CreatedBy   uuid.UUID
+}
+
+// CreateThreshold creates a new alert threshold
+func (r *AlertRepository) CreateThreshold(ctx context.Context, input CreateThresholdInput) (sqlc.AlertThreshold, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.AlertThreshold{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.CreatedBy.String()))
+	if err != nil {
+		return sqlc.AlertThreshold{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	var categoryID pgtype.UUID
+	if input.CategoryID != nil {
+		categoryID = pgtype.UUID{Bytes: *input.CategoryID, Valid: true}
+	}
+
+	percentages := input.Percentages
+	if len(percentages) == 0 {
+		percentages = DefaultAlertPercentages
+	}
+
+	threshold, err := sqlc.New(tx).CreateAlertThreshold(ctx, sqlc.CreateAlertThresholdParams{
+		ID:          uuid.New(),
+		FamilyID:    input.FamilyID,
+		CategoryID:  categoryID,
+		LimitAmount: toPgNumeric(input.LimitAmount),
+		Percentages: percentages,
+		CreatedBy:   pgtype.UUID{Bytes: input.CreatedBy, Valid: true},
+	})
+	if err != nil {
+		return sqlc.AlertThreshold{}, fmt.Errorf("failed to create alert threshold: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.AlertThreshold{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return threshold, nil
+}
+
+// UpdateThresholdInput contains data for updating an alert threshold (partial update)
+type UpdateThresholdInput struct {
+	ID          uuid.UUID
+	LimitAmount *decimal.Decimal
+	ClearLimit  bool // use monthly budget of the category instead of a fixed limit
+	Percentages []int32
+	UpdatedBy   uuid.UUID
+}
+
+// UpdateThreshold updates an alert threshold (partial update)
+func (r *AlertRepository) UpdateThreshold(ctx context.Context, input UpdateThresholdInput) (sqlc.AlertThreshold, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.AlertThreshold{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.UpdatedBy.String()))
+	if err != nil {
+		return sqlc.AlertThreshold{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
+	current, err := qtx.GetAlertThreshold(ctx, input.ID)
+	if err != nil {
+		return sqlc.AlertThreshold{}, fmt.Errorf("failed to get alert threshold: %w", err)
+	}
+
+	limitAmount := current.LimitAmount
+	if input.ClearLimit {
+		limitAmount = pgtype.Numeric{}
+	} else if input.LimitAmount != nil {
+		limitAmount = toPgNumeric(input.LimitAmount)
+	}
+
+	percentages := current.Percentages
+	if len(input.Percentages) > 0 {
+		percentages = input.Percentages
+	}
+
+	threshold, err := qtx.UpdateAlertThreshold(ctx, sqlc.UpdateAlertThresholdParams{
+		ID:          input.ID,
+		LimitAmount: limitAmount,
+		Percentages: percentages,
+	})
+	if err != nil {
+		return sqlc.AlertThreshold{}, fmt.Errorf("failed to update alert threshold: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.AlertThreshold{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return threshold, nil
+}
+
+// DeleteThreshold soft-deletes an alert threshold
+func (r *AlertRepository) DeleteThreshold(ctx context.Context, id, deletedBy uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", deletedBy.String()))
+	if err != nil {
+		return fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	if err := sqlc.New(tx).DeleteAlertThreshold(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete alert threshold: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetByID retrieves an alert by ID
+func (r *AlertRepository) GetByID(ctx context.Context, id uuid.UUID) (sqlc.Alert, error) {
+	return r.queries.GetAlert(ctx, id)
+}
+
+// ListForUser retrieves latest alerts of a family with read state of the user
+func (r *AlertRepository) ListForUser(ctx context.Context, familyID, userID uuid.UUID, unreadOnly bool, limit int32) ([]sqlc.ListAlertsForUserRow, error) {
+	return r.queries.ListAlertsForUser(ctx, sqlc.ListAlertsForUserParams{
+		UserID:     userID,
+		FamilyID:   familyID,
+		UnreadOnly: unreadOnly,
+		RowLimit:   limit,
+	})
+}
+
+// CountUnread counts alerts of a family the user has not read yet
+func (r *AlertRepository) CountUnread(ctx context.Context, familyID, userID uuid.UUID) (int64, error) {
+	return r.queries.CountUnreadAlerts(ctx, sqlc.CountUnreadAlertsParams{
+		FamilyID: familyID,
+		UserID:   userID,
+	})
+}
+
+// MarkRead marks an alert as read by the user
+func (r *AlertRepository) MarkRead(ctx context.Context, alertID, userID uuid.UUID) error {
+	return r.queries.MarkAlertRead(ctx, sqlc.MarkAlertReadParams{
+		AlertID: alertID,
+		UserID:  userID,
+	})
+}
+
+// Acknowledge marks an alert as read and acknowledged by the user
+func (r *AlertRepository) Acknowledge(ctx context.Context, alertID, userID uuid.UUID) error {
+	return r.queries.AcknowledgeAlert(ctx, sqlc.AcknowledgeAlertParams{
+		AlertID: alertID,
+		UserID:  userID,
+	})
+}
+
+// MarkAllRead marks all alerts of a family as read by the user
+func (r *AlertRepository) MarkAllRead(ctx context.Context, familyID, userID uuid.UUID) (int64, error) {
+	return r.queries.MarkAllAlertsRead(ctx, sqlc.MarkAllAlertsReadParams{
+		UserID:   userID,
+		FamilyID: familyID,
+	})
+}
+
+// evaluateAlerts checks thresholds affected by an expense in the category on
+// the given date and raises alerts for reached percentages. Alerts already
+// raised for the same month are skipped.
+func evaluateAlerts(ctx context.Context, qtx *sqlc.Queries, familyID, categoryID uuid.UUID, date time.Time) error {
+	thresholds, err := qtx.ListApplicableAlertThresholds(ctx, sqlc.ListApplicableAlertThresholdsParams{
+		CategoryID: categoryID,
+		FamilyID:   familyID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list alert thresholds: %w", err)
+	}
+	if len(thresholds) == 0 {
+		return nil
+	}
+
+	start := BudgetMonth(date)
+	period := pgtype.Date{Time: start, Valid: true}
+	end := pgtype.Date{Time: start.AddDate(0, 1, -1), Valid: true}
+
+	for _, t := range thresholds {
+		// Limit: fixed amount or budget of the month
+		var limit decimal.Decimal
+		switch {
+		case t.LimitAmount.Valid:
+			limit = decimal.NewFromBigInt(t.LimitAmount.Int, t.LimitAmount.Exp)
+		case t.CategoryID.Valid:
+			limit, err = qtx.GetEffectiveBudgetAmount(ctx, sqlc.GetEffectiveBudgetAmountParams{
+				CategoryID: t.CategoryID.Bytes,
+				Month:      period,
+			})
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("failed to get budget: %w", err)
+			}
+		default:
+			continue
+		}
+
+		var spent decimal.Decimal
+		if t.CategoryID.Valid {
+			spent, err = qtx.GetCategorySubtreeExpenseTotal(ctx, sqlc.GetCategorySubtreeExpenseTotalParams{
+				CategoryID: t.CategoryID.Bytes,
+				StartDate:  period,
+				EndDate:    end,
+			})
+		} else {
+			spent, err = qtx.GetFamilyExpenseTotal(ctx, sqlc.GetFamilyExpenseTotalParams{
+				FamilyID:          familyID,
+				TransactionDate:   period,
+				TransactionDate_2: end,
+			})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to calculate spending: %w", err)
+		}
+
+		for _, percentage := range t.Percentages {
+			reachedAt := limit.Mul(decimal.NewFromInt32(percentage)).Div(decimal.NewFromInt(100))
+			if spent.LessThan(reachedAt) {
+				continue
+			}
+
+			_, err := qtx.CreateAlert(ctx, sqlc.CreateAlertParams{
+				ID:          uuid.New(),
+				FamilyID:    familyID,
+				ThresholdID: t.ID,
+				CategoryID:  t.CategoryID,
+				Period:      period,
+				Percentage:  percentage,
+				LimitAmount: limit,
+				SpentAmount: spent,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create alert: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,472 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+)
+
+// Budget rollover modes
+const (
+	RolloverNone         = "none"          // remainder is dropped at month end
+	RolloverCarrySurplus = "carry_surplus" // unspent amount is added to next month
+	RolloverCarryAll     = "carry_all"     // surplus and overspending are carried to next month
+)
+
+// BudgetRepository handles budget data operations
+type BudgetRepository struct {
+	queries *sqlc.Queries
+	pool    *pgxpool.Pool
+}
+
+// NewBudgetRepository creates a new BudgetRepository
+func NewBudgetRepository(queries *sqlc.Queries, pool *pgxpool.Pool) *BudgetRepository {
+	return &BudgetRepository{
+		queries: queries,
+		pool:    pool,
+	}
+}
+
+// GetByID retrieves an active budget by ID
+func (r *BudgetRepository) GetByID(ctx context.Context, id uuid.UUID) (sqlc.Budget, error) {
+	return r.queries.GetBudget(ctx, id)
+}
+
+// GetByCategoryMonth retrieves the budget of a category for a month (nil = default budget)
+func (r *BudgetRepository) GetByCategoryMonth(ctx context.Context, categoryID uuid.UUID, month *time.Time) (sqlc.Budget, error) {
+	return r.queries.GetBudgetByCategoryMonth(ctx, sqlc.GetBudgetByCategoryMonthParams{
+		CategoryID: categoryID,
+		Month:      toPgDate(month),
+	})
+}
+
+// ListByFamily retrieves all active budgets in a family (default and monthly)
+func (r *BudgetRepository) ListByFamily(ctx context.Context, familyID uuid.UUID) ([]sqlc.Budget, error) {
+	return r.queries.ListBudgetsByFamily(ctx, familyID)
+}
+
+// ListForMonth retrieves effective budgets for a month: the monthly budget of each category,
+// or its default budget if there is none
+func (r *BudgetRepository) ListForMonth(ctx context.Context, familyID uuid.UUID, month time.Time) ([]sqlc.Budget, error) {
+	return r.queries.ListBudgetsForMonth(ctx, sqlc.ListBudgetsForMonthParams{
+		FamilyID: familyID,
+		Month:    pgtype.Date{Time: BudgetMonth(month), Valid: true},
+	})
+}
+
+// CreateBudgetInput contains data for creating a new budget
+type CreateBudgetInput struct {
+	FamilyID     uuid.UUID
+	CategoryID   uuid.UUID
+	Month        *time.Time // nil = default budget for every month
+	Amount       decimal.Decimal
+	RolloverMode string // none, carry_surplus, carry_all; empty = none
+	CreatedBy    uuid.UUID
+}
+
+// Create creates a new budget
+func (r *BudgetRepository) Create(ctx context.Context, input CreateBudgetInput) (sqlc.Budget, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.Budget{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.CreatedBy.String()))
+	if err != nil {
+		return sqlc.Budget{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	var month *time.Time
+	if input.Month != nil {
+		m := BudgetMonth(*input.Month)
+		month = &m
+	}
+
+	rolloverMode := input.RolloverMode
+	if rolloverMode == "" {
+		rolloverMode = RolloverNone
+	}
+
+	budget, err := sqlc.New(tx).CreateBudget(ctx, sqlc.CreateBudgetParams{
+		ID:           uuid.New(),
+		FamilyID:     input.FamilyID,
+		CategoryID:   input.CategoryID,
+		Month:        toPgDate(month),
+		Amount:       input.Amount,
+		RolloverMode: rolloverMode,
+		CreatedBy:    pgtype.UUID{Bytes: input.CreatedBy, Valid: true},
+	})
+	if err != nil {
+		return sqlc.Budget{}, fmt.Errorf("failed to create budget: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.Budget{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return budget, nil
+}
+
+// UpdateBudgetInput contains data for updating a budget (partial update)
+type UpdateBudgetInput struct {
+	ID           uuid.UUID
+	Amount       *decimal.Decimal
+	RolloverMode *string
+	UpdatedBy    uuid.UUID
+}
+
+// Update updates budget details (partial update)
+func (r *BudgetRepository) Update(ctx context.Context, input UpdateBudgetInput) (sqlc.Budget, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.Budget{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.UpdatedBy.String()))
+	if err != nil {
+		return sqlc.Budget{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
+	current, err := qtx.GetBudget(ctx, input.ID)
+	if err != nil {
+		return sqlc.Budget{}, fmt.Errorf("failed to get budget: %w", err)
+	}
+
+	amount := current.Amount
+	if input.Amount != nil {
+		amount = *input.Amount
+	}
+
+	rolloverMode := current.RolloverMode
+	if input.RolloverMode != nil {
+		rolloverMode = *input.RolloverMode
+	}
+
+	budget, err := qtx.UpdateBudget(ctx, sqlc.UpdateBudgetParams{
+		ID:           input.ID,
+		Amount:       amount,
+		RolloverMode: rolloverMode,
+	})
+	if err != nil {
+		return sqlc.Budget{}, fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.Budget{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return budget, nil
+}
+
+// Delete soft-deletes a budget
+func (r *BudgetRepository) Delete(ctx context.Context, id, deletedBy uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", deletedBy.String()))
+	if err != nil {
+		return fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	if err := sqlc.New(tx).DeleteBudget(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListAssignments retrieves envelope assignments of a family for a month
+func (r *BudgetRepository) ListAssignments(ctx context.Context, familyID uuid.UUID, month time.Time) ([]sqlc.BudgetAssignment, error) {
+	return r.queries.ListBudgetAssignmentsForMonth(ctx, sqlc.ListBudgetAssignmentsForMonthParams{
+		FamilyID: familyID,
+		Month:    pgtype.Date{Time: BudgetMonth(month), Valid: true},
+	})
+}
+
+// AssignBudgetInput contains data for assigning money to a category for a month
+type AssignBudgetInput struct {
+	FamilyID   uuid.UUID
+	CategoryID uuid.UUID
+	Month      time.Time
+	Amount     decimal.Decimal // replaces previous assignment, 0 = clear
+	AssignedBy uuid.UUID
+}
+
+// Assign sets the amount assigned to a category for a month
+func (r *BudgetRepository) Assign(ctx context.Context, input AssignBudgetInput) (sqlc.BudgetAssignment, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.BudgetAssignment{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.AssignedBy.String()))
+	if err != nil {
+		return sqlc.BudgetAssignment{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	assignment, err := sqlc.New(tx).UpsertBudgetAssignment(ctx, sqlc.UpsertBudgetAssignmentParams{
+		ID:         uuid.New(),
+		FamilyID:   input.FamilyID,
+		CategoryID: input.CategoryID,
+		Month:      pgtype.Date{Time: BudgetMonth(input.Month), Valid: true},
+		Amount:     input.Amount,
+		CreatedBy:  pgtype.UUID{Bytes: input.AssignedBy, Valid: true},
+	})
+	if err != nil {
+		return sqlc.BudgetAssignment{}, fmt.Errorf("failed to assign budget: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.BudgetAssignment{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return assignment, nil
+}
+
+// AvailableToAssign is the income of a family not yet assigned to categories
+type AvailableToAssign struct {
+	Income      decimal.Decimal // income of the month
+	Assigned    decimal.Decimal // assigned for the month
+	CarriedOver decimal.Decimal // unassigned income of previous months (negative if over-assigned)
+	Available   decimal.Decimal // CarriedOver + Income - Assigned
+}
+
+// GetAvailableToAssign calculates income not yet assigned up to the end of a month
+func (r *BudgetRepository) GetAvailableToAssign(ctx context.Context, familyID uuid.UUID, month time.Time) (AvailableToAssign, error) {
+	start := BudgetMonth(month)
+	end := start.AddDate(0, 1, -1)
+
+	income, err := r.queries.GetIncomeTotalsForMonth(ctx, sqlc.GetIncomeTotalsForMonthParams{
+		MonthStart: pgtype.Date{Time: start, Valid: true},
+		FamilyID:   familyID,
+		MonthEnd:   pgtype.Date{Time: end, Valid: true},
+	})
+	if err != nil {
+		return AvailableToAssign{}, fmt.Errorf("failed to get income totals: %w", err)
+	}
+
+	assigned, err := r.queries.GetBudgetAssignmentTotals(ctx, sqlc.GetBudgetAssignmentTotalsParams{
+		Month:    pgtype.Date{Time: start, Valid: true},
+		FamilyID: familyID,
+	})
+	if err != nil {
+		return AvailableToAssign{}, fmt.Errorf("failed to get assignment totals: %w", err)
+	}
+
+	carriedOver := income.PreviousTotal.Sub(assigned.PreviousTotal)
+
+	return AvailableToAssign{
+		Income:      income.MonthTotal,
+		Assigned:    assigned.MonthTotal,
+		CarriedOver: carriedOver,
+		Available:   carriedOver.Add(income.MonthTotal).Sub(assigned.MonthTotal),
+	}, nil
+}
+
+// BudgetPlan is the plan of a category for a month
+type BudgetPlan struct {
+	CategoryID   uuid.UUID
+	Budget       *sqlc.Budget     // effective budget, nil for envelope-only categories
+	Assigned     *decimal.Decimal // envelope assignment for the month
+	Planned      decimal.Decimal  // assignment if set, otherwise budget amount
+	RolloverMode string
+	CarriedOver  decimal.Decimal // remainder carried from previous months
+}
+
+// Available returns the amount that can be spent in the month
+func (p BudgetPlan) Available() decimal.Decimal {
+	return p.Planned.Add(p.CarriedOver)
+}
+
+// PlanMonth returns plans of all budgeted and envelope categories for a month.
+// Remainders of previous months are carried over according to the rollover mode
+// of each budget; envelope categories without a budget always carry everything.
+// Spending of child categories counts towards their parents.
+func (r *BudgetRepository) PlanMonth(ctx context.Context, familyID uuid.UUID, month time.Time) ([]BudgetPlan, error) {
+	month = BudgetMonth(month)
+
+	budgets, err := r.queries.ListBudgetsByFamily(ctx, familyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list budgets: %w", err)
+	}
+
+	assignments, err := r.queries.ListBudgetAssignmentsUntil(ctx, sqlc.ListBudgetAssignmentsUntilParams{
+		FamilyID: familyID,
+		Month:    pgtype.Date{Time: month, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assignments: %w", err)
+	}
+
+	// Month from which each budget applies, and the earliest month to replay
+	start := month
+	budgetStart := make(map[uuid.UUID]time.Time, len(budgets))
+	for _, b := range budgets {
+		from := BudgetMonth(b.CreatedAt)
+		if b.Month.Valid {
+			from = BudgetMonth(b.Month.Time)
+		}
+		budgetStart[b.ID] = from
+		if b.RolloverMode != RolloverNone && from.Before(start) {
+			start = from
+		}
+	}
+
+	assigned := make(map[time.Time]map[uuid.UUID]decimal.Decimal)
+	envelopes := make(map[uuid.UUID]bool)
+	for _, a := range assignments {
+		m := BudgetMonth(a.Month.Time)
+		if assigned[m] == nil {
+			assigned[m] = make(map[uuid.UUID]decimal.Decimal)
+		}
+		assigned[m][a.CategoryID] = a.Amount
+		envelopes[a.CategoryID] = true
+		if m.Before(start) {
+			start = m
+		}
+	}
+
+	// Spending of previous months, rolled up to parent categories
+	spent := make(map[time.Time]map[uuid.UUID]decimal.Decimal)
+	if start.Before(month) {
+		categories, err := r.queries.ListAllCategoriesByFamily(ctx, familyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list categories: %w", err)
+		}
+		parents := make(map[uuid.UUID]uuid.UUID, len(categories))
+		for _, c := range categories {
+			if c.ParentID.Valid {
+				parents[c.ID] = c.ParentID.Bytes
+			}
+		}
+
+		rows, err := r.queries.GetTransactionsMonthlySummaryByCategory(ctx, sqlc.GetTransactionsMonthlySummaryByCategoryParams{
+			FamilyID:          familyID,
+			Type:              "expense",
+			TransactionDate:   pgtype.Date{Time: start, Valid: true},
+			TransactionDate_2: pgtype.Date{Time: month.AddDate(0, 0, -1), Valid: true},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get monthly spending: %w", err)
+		}
+		for _, row := range rows {
+			m := BudgetMonth(row.Month.Time)
+			if spent[m] == nil {
+				spent[m] = make(map[uuid.UUID]decimal.Decimal)
+			}
+			id := row.CategoryID
+			for depth := 0; depth <= len(parents); depth++ {
+				spent[m][id] = spent[m][id].Add(row.Total)
+				parentID, ok := parents[id]
+				if !ok {
+					break
+				}
+				id = parentID
+			}
+		}
+	}
+
+	// Replay months up to the requested one
+	carried := make(map[uuid.UUID]decimal.Decimal)
+	var plans []BudgetPlan
+	for m := start; !m.After(month); m = m.AddDate(0, 1, 0) {
+		plans = plans[:0]
+
+		// Monthly budget, otherwise the default one
+		effective := make(map[uuid.UUID]*sqlc.Budget)
+		for i := range budgets {
+			b := &budgets[i]
+			if !b.Month.Valid && (!budgetStart[b.ID].After(m) || m.Equal(month)) {
+				effective[b.CategoryID] = b
+			}
+		}
+		for i := range budgets {
+			b := &budgets[i]
+			if b.Month.Valid && budgetStart[b.ID].Equal(m) {
+				effective[b.CategoryID] = b
+			}
+		}
+
+		categoryIDs := make(map[uuid.UUID]bool)
+		for id := range effective {
+			categoryIDs[id] = true
+		}
+		for id := range assigned[m] {
+			categoryIDs[id] = true
+		}
+		for id, amount := range carried {
+			if !amount.IsZero() {
+				categoryIDs[id] = true
+			}
+		}
+
+		for id := range categoryIDs {
+			plan := BudgetPlan{
+				CategoryID:   id,
+				RolloverMode: RolloverNone,
+				CarriedOver:  carried[id],
+			}
+			if b, ok := effective[id]; ok {
+				plan.Budget = b
+				plan.Planned = b.Amount
+				plan.RolloverMode = b.RolloverMode
+			} else if envelopes[id] {
+				plan.RolloverMode = RolloverCarryAll
+			}
+			if amount, ok := assigned[m][id]; ok {
+				plan.Assigned = &amount
+				plan.Planned = amount
+			}
+			plans = append(plans, plan)
+		}
+
+		if m.Equal(month) {
+			break
+		}
+
+		// Carry remainders into the next month
+		carried = make(map[uuid.UUID]decimal.Decimal, len(plans))
+		for _, plan := range plans {
+			remainder := plan.Available().Sub(spent[m][plan.CategoryID])
+			switch plan.RolloverMode {
+			case RolloverCarrySurplus:
+				if remainder.IsPositive() {
+					carried[plan.CategoryID] = remainder
+				}
+			case RolloverCarryAll:
+				carried[plan.CategoryID] = remainder
+			}
+		}
+	}
+
+	sort.Slice(plans, func(i, j int) bool {
+		return plans[i].CategoryID.String() < plans[j].CategoryID.String()
+	})
+
+	return plans, nil
+}
+
+// BudgetMonth returns the first day of the month of t
+func BudgetMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
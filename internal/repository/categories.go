@@ -2,21 +2,62 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/DigitLock/expense-tracker/internal/categorytemplates"
 	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
 )
 
+// DefaultCategoryMaxDepth is the default maximum number of levels in the category hierarchy
+const DefaultCategoryMaxDepth = 5
+
+var (
+	// ErrCategoryParentNotFound is returned when parent category does not exist or belongs to another family
+	ErrCategoryParentNotFound = errors.New("parent category not found")
+	// ErrCategoryTypeMismatch is returned when parent and child categories have different types
+	ErrCategoryTypeMismatch = errors.New("parent category must be the same type")
+	// ErrCategoryCycle is returned when a category would become its own ancestor
+	ErrCategoryCycle = errors.New("category cannot be moved under itself or its descendant")
+	// ErrCategoryMaxDepth is returned when the hierarchy would exceed the maximum depth
+	ErrCategoryMaxDepth = errors.New("category hierarchy is too deep")
+	// ErrCategoryInUse is returned when deleting a category that still has transactions
+	ErrCategoryInUse = errors.New("category has transactions, replacement category is required")
+	// ErrCategoryMergeIntoDescendant is returned when merge target is the source itself or one of its descendants
+	ErrCategoryMergeIntoDescendant = errors.New("cannot merge category into itself or its descendant")
+)
+
 // CategoryRepository handles category data operations
 type CategoryRepository struct {
-	queries *sqlc.Queries
+	queries  *sqlc.Queries
+	pool     *pgxpool.Pool
+	maxDepth int
 }
 
 // NewCategoryRepository creates a new CategoryRepository
-func NewCategoryRepository(queries *sqlc.Queries) *CategoryRepository {
-	return &CategoryRepository{queries: queries}
+func NewCategoryRepository(queries *sqlc.Queries, pool *pgxpool.Pool) *CategoryRepository {
+	return &CategoryRepository{
+		queries:  queries,
+		pool:     pool,
+		maxDepth: DefaultCategoryMaxDepth,
+	}
+}
+
+// SetMaxDepth sets maximum number of levels in the category hierarchy
+func (r *CategoryRepository) SetMaxDepth(depth int) {
+	if depth > 0 {
+		r.maxDepth = depth
+	}
+}
+
+// MaxDepth returns maximum number of levels in the category hierarchy
+func (r *CategoryRepository) MaxDepth() int {
+	return r.maxDepth
 }
 
 // GetByID retrieves an active category by ID
@@ -59,42 +100,96 @@ func (r *CategoryRepository) ListChildCategories(ctx context.Context, parentID u
 
 // CreateCategoryInput contains data for creating a new category
 type CreateCategoryInput struct {
-	FamilyID uuid.UUID
-	Name     string
-	Type     string     // income, expense
-	ParentID *uuid.UUID // nil for root categories
+	FamilyID    uuid.UUID
+	Name        string
+	Type        string     // income, expense
+	ParentID    *uuid.UUID // nil for root categories
+	Description *string
+	Icon        *string
+	Color       *string // #RRGGBB
+	SortOrder   *int    // nil = after existing siblings
 }
 
 // Create creates a new category
 func (r *CategoryRepository) Create(ctx context.Context, input CreateCategoryInput) (sqlc.Category, error) {
+	tx, err := r.beginHierarchyTx(ctx)
+	if err != nil {
+		return sqlc.Category{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := sqlc.New(tx)
+
 	var parentID pgtype.UUID
 	if input.ParentID != nil {
+		if err := r.validateParent(ctx, qtx, uuid.Nil, input.FamilyID, input.Type, *input.ParentID); err != nil {
+			return sqlc.Category{}, err
+		}
 		parentID = pgtype.UUID{Bytes: *input.ParentID, Valid: true}
 	}
 
-	return r.queries.CreateCategory(ctx, sqlc.CreateCategoryParams{
-		ID:       uuid.New(),
-		FamilyID: input.FamilyID,
-		Name:     input.Name,
-		Type:     input.Type,
-		ParentID: parentID,
+	var sortOrder int32
+	if input.SortOrder != nil {
+		sortOrder = int32(*input.SortOrder)
+	} else {
+		sortOrder, err = qtx.GetNextCategorySortOrder(ctx, sqlc.GetNextCategorySortOrderParams{
+			FamilyID: input.FamilyID,
+			Type:     input.Type,
+			ParentID: parentID,
+		})
+		if err != nil {
+			return sqlc.Category{}, fmt.Errorf("failed to get sort order: %w", err)
+		}
+	}
+
+	category, err := qtx.CreateCategory(ctx, sqlc.CreateCategoryParams{
+		ID:          uuid.New(),
+		FamilyID:    input.FamilyID,
+		Name:        input.Name,
+		Type:        input.Type,
+		ParentID:    parentID,
+		Description: toPgText(input.Description),
+		Icon:        toPgText(input.Icon),
+		Color:       toPgText(input.Color),
+		SortOrder:   sortOrder,
 	})
+	if err != nil {
+		return sqlc.Category{}, fmt.Errorf("failed to create category: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.Category{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return category, nil
 }
 
 // UpdateCategoryInput contains data for updating a category (partial update)
 type UpdateCategoryInput struct {
-	ID       uuid.UUID
-	Name     *string
-	ParentID *uuid.UUID // use special value to clear parent
-	IsActive *bool
+	ID          uuid.UUID
+	Name        *string
+	ParentID    *uuid.UUID // use special value to clear parent
+	IsActive    *bool
+	Description *string // "" = clear
+	Icon        *string // "" = clear
+	Color       *string // "" = clear
+	SortOrder   *int
 	// Special flag to indicate we want to clear parent_id (set to NULL)
 	ClearParent bool
 }
 
 // Update updates category details (partial update)
 func (r *CategoryRepository) Update(ctx context.Context, input UpdateCategoryInput) (sqlc.Category, error) {
+	tx, err := r.beginHierarchyTx(ctx)
+	if err != nil {
+		return sqlc.Category{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := sqlc.New(tx)
+
 	// First get current category (including inactive to allow reactivation)
-	current, err := r.queries.GetCategoryIncludingInactive(ctx, input.ID)
+	current, err := qtx.GetCategoryIncludingInactive(ctx, input.ID)
 	if err != nil {
 		return sqlc.Category{}, err
 	}
@@ -109,6 +204,11 @@ func (r *CategoryRepository) Update(ctx context.Context, input UpdateCategoryInp
 	if input.ClearParent {
 		parentID = pgtype.UUID{Valid: false}
 	} else if input.ParentID != nil {
+		if !current.ParentID.Valid || uuid.UUID(current.ParentID.Bytes) != *input.ParentID {
+			if err := r.validateParent(ctx, qtx, current.ID, current.FamilyID, current.Type, *input.ParentID); err != nil {
+				return sqlc.Category{}, err
+			}
+		}
 		parentID = pgtype.UUID{Bytes: *input.ParentID, Valid: true}
 	}
 
@@ -117,15 +217,505 @@ func (r *CategoryRepository) Update(ctx context.Context, input UpdateCategoryInp
 		isActive = *input.IsActive
 	}
 
-	return r.queries.UpdateCategory(ctx, sqlc.UpdateCategoryParams{
+	description := current.Description
+	if input.Description != nil {
+		description = toPgText(input.Description)
+	}
+
+	icon := current.Icon
+	if input.Icon != nil {
+		icon = toPgText(input.Icon)
+	}
+
+	color := current.Color
+	if input.Color != nil {
+		color = toPgText(input.Color)
+	}
+
+	sortOrder := current.SortOrder
+	if input.SortOrder != nil {
+		sortOrder = int32(*input.SortOrder)
+	}
+
+	category, err := qtx.UpdateCategory(ctx, sqlc.UpdateCategoryParams{
+		ID:          input.ID,
+		Name:        name,
+		ParentID:    parentID,
+		IsActive:    isActive,
+		Description: description,
+		Icon:        icon,
+		Color:       color,
+		SortOrder:   sortOrder,
+	})
+	if err != nil {
+		return sqlc.Category{}, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.Category{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return category, nil
+}
+
+// Reorder sets display order of sibling categories to the order of ids
+func (r *CategoryRepository) Reorder(ctx context.Context, ids []uuid.UUID) error {
+	if _, err := r.queries.UpdateCategorySortOrders(ctx, ids); err != nil {
+		return fmt.Errorf("failed to reorder categories: %w", err)
+	}
+	return nil
+}
+
+// MoveCategoryInput contains data for moving a category with its subtree
+type MoveCategoryInput struct {
+	ID       uuid.UUID
+	ParentID *uuid.UUID // nil = move to top level
+	MovedBy  uuid.UUID
+}
+
+// Move re-parents a category. Descendants stay attached to it, so the whole subtree is moved.
+func (r *CategoryRepository) Move(ctx context.Context, input MoveCategoryInput) (sqlc.Category, error) {
+	tx, err := r.beginHierarchyTx(ctx)
+	if err != nil {
+		return sqlc.Category{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.MovedBy.String()))
+	if err != nil {
+		return sqlc.Category{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
+	current, err := qtx.GetCategory(ctx, input.ID)
+	if err != nil {
+		return sqlc.Category{}, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	var parentID pgtype.UUID
+	if input.ParentID != nil {
+		if err := r.validateParent(ctx, qtx, current.ID, current.FamilyID, current.Type, *input.ParentID); err != nil {
+			return sqlc.Category{}, err
+		}
+		parentID = pgtype.UUID{Bytes: *input.ParentID, Valid: true}
+	}
+
+	category, err := qtx.SetCategoryParent(ctx, sqlc.SetCategoryParentParams{
 		ID:       input.ID,
-		Name:     name,
 		ParentID: parentID,
-		IsActive: isActive,
 	})
+	if err != nil {
+		return sqlc.Category{}, fmt.Errorf("failed to move category: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.Category{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return category, nil
+}
+
+// beginHierarchyTx starts a transaction with the hierarchy depth limit
+// passed to the check_category_hierarchy trigger
+func (r *CategoryRepository) beginHierarchyTx(ctx context.Context) (pgx.Tx, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.category_max_depth = '%d'", r.maxDepth))
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, fmt.Errorf("failed to set category max depth: %w", err)
+	}
+
+	return tx, nil
+}
+
+// validateParent checks that parentID can become parent of a category:
+// same family, same type, not inside the category subtree and within maximum depth.
+// categoryID is uuid.Nil for a new category.
+func (r *CategoryRepository) validateParent(ctx context.Context, qtx *sqlc.Queries, categoryID, familyID uuid.UUID, categoryType string, parentID uuid.UUID) error {
+	parent, err := qtx.GetCategory(ctx, parentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrCategoryParentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get parent category: %w", err)
+	}
+	if parent.FamilyID != familyID {
+		return ErrCategoryParentNotFound
+	}
+	if parent.Type != categoryType {
+		return ErrCategoryTypeMismatch
+	}
+
+	// Path from parent to the root, parent first
+	ancestors, err := qtx.ListCategoryAncestorIDs(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to get parent categories: %w", err)
+	}
+	for _, id := range ancestors {
+		if id == categoryID {
+			return ErrCategoryCycle
+		}
+	}
+
+	subtreeDepth := int32(1)
+	if categoryID != uuid.Nil {
+		subtreeDepth, err = qtx.GetCategorySubtreeDepth(ctx, categoryID)
+		if err != nil {
+			return fmt.Errorf("failed to get subtree depth: %w", err)
+		}
+	}
+
+	if len(ancestors)+int(subtreeDepth) > r.maxDepth {
+		return ErrCategoryMaxDepth
+	}
+
+	return nil
+}
+
+// CountTransactions returns number of active transactions in a category
+func (r *CategoryRepository) CountTransactions(ctx context.Context, id uuid.UUID) (int64, error) {
+	return r.queries.CountTransactionsByCategory(ctx, id)
+}
+
+// DeleteCategoryInput contains data for deleting a category
+type DeleteCategoryInput struct {
+	ID            uuid.UUID
+	ReplacementID *uuid.UUID // required if category has transactions
+	DeletedBy     uuid.UUID
+}
+
+// Delete soft-deletes a category.
+// With a replacement, transactions and child categories are moved to it first (same as Merge).
+// Without a replacement, the category must have no transactions and its children move to its parent.
+func (r *CategoryRepository) Delete(ctx context.Context, input DeleteCategoryInput) error {
+	tx, err := r.beginHierarchyTx(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.DeletedBy.String()))
+	if err != nil {
+		return fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
+	if input.ReplacementID != nil {
+		if _, err := r.mergeCategories(ctx, qtx, input.ID, *input.ReplacementID); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}
+
+	count, err := qtx.CountTransactionsByCategory(ctx, input.ID)
+	if err != nil {
+		return fmt.Errorf("failed to count transactions: %w", err)
+	}
+	if count > 0 {
+		return ErrCategoryInUse
+	}
+
+	category, err := qtx.GetCategoryIncludingInactive(ctx, input.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+
+	// Keep children in the hierarchy by attaching them to the grandparent
+	_, err = qtx.MoveChildCategories(ctx, sqlc.MoveChildCategoriesParams{
+		NewParentID: category.ParentID,
+		OldParentID: pgtype.UUID{Bytes: input.ID, Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to move child categories: %w", err)
+	}
+
+	if err := qtx.DeleteCategory(ctx, input.ID); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// MergeCategoriesInput contains data for merging one category into another
+type MergeCategoriesInput struct {
+	SourceID uuid.UUID
+	TargetID uuid.UUID
+	MergedBy uuid.UUID
+}
+
+// CategoryMergeResult contains the outcome of a merge
+type CategoryMergeResult struct {
+	Target            sqlc.Category
+	TransactionsMoved int64
+	ChildrenMoved     int64
+}
+
+// Merge moves all transactions and child categories from source to target and deactivates source
+func (r *CategoryRepository) Merge(ctx context.Context, input MergeCategoriesInput) (CategoryMergeResult, error) {
+	tx, err := r.beginHierarchyTx(ctx)
+	if err != nil {
+		return CategoryMergeResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.MergedBy.String()))
+	if err != nil {
+		return CategoryMergeResult{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	result, err := r.mergeCategories(ctx, sqlc.New(tx), input.SourceID, input.TargetID)
+	if err != nil {
+		return CategoryMergeResult{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return CategoryMergeResult{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return result, nil
+}
+
+// mergeCategories moves transactions, interest settings, budgets, alert thresholds and
+// children from source to target and soft-deletes source. Budgets and assignments are
+// added to those of target in the same month; if target has its own alert threshold,
+// the threshold of source is deactivated. Must be called inside a transaction.
+func (r *CategoryRepository) mergeCategories(ctx context.Context, qtx *sqlc.Queries, sourceID, targetID uuid.UUID) (CategoryMergeResult, error) {
+	target, err := qtx.GetCategoryIncludingInactive(ctx, targetID)
+	if err != nil {
+		return CategoryMergeResult{}, fmt.Errorf("failed to get target category: %w", err)
+	}
+
+	// Target must not be inside the source subtree, otherwise moving children creates a cycle
+	ancestors, err := qtx.ListCategoryAncestorIDs(ctx, targetID)
+	if err != nil {
+		return CategoryMergeResult{}, fmt.Errorf("failed to get parent categories: %w", err)
+	}
+	for _, id := range ancestors {
+		if id == sourceID {
+			return CategoryMergeResult{}, ErrCategoryMergeIntoDescendant
+		}
+	}
+
+	// Children of source become children of target
+	subtreeDepth, err := qtx.GetCategorySubtreeDepth(ctx, sourceID)
+	if err != nil {
+		return CategoryMergeResult{}, fmt.Errorf("failed to get subtree depth: %w", err)
+	}
+	if len(ancestors)+int(subtreeDepth)-1 > r.maxDepth {
+		return CategoryMergeResult{}, ErrCategoryMaxDepth
+	}
+
+	transactionsMoved, err := qtx.ReassignTransactionsCategory(ctx, sqlc.ReassignTransactionsCategoryParams{
+		TargetCategoryID: targetID,
+		SourceCategoryID: sourceID,
+	})
+	if err != nil {
+		return CategoryMergeResult{}, fmt.Errorf("failed to move transactions: %w", err)
+	}
+
+	err = qtx.ReassignInterestIncomeCategory(ctx, sqlc.ReassignInterestIncomeCategoryParams{
+		TargetCategoryID: targetID,
+		SourceCategoryID: sourceID,
+	})
+	if err != nil {
+		return CategoryMergeResult{}, fmt.Errorf("failed to move interest settings: %w", err)
+	}
+
+	if err := mergeCategoryBudgets(ctx, qtx, sourceID, targetID); err != nil {
+		return CategoryMergeResult{}, err
+	}
+
+	if err := mergeCategoryAlerts(ctx, qtx, sourceID, targetID); err != nil {
+		return CategoryMergeResult{}, err
+	}
+
+	childrenMoved, err := qtx.MoveChildCategories(ctx, sqlc.MoveChildCategoriesParams{
+		NewParentID: pgtype.UUID{Bytes: targetID, Valid: true},
+		OldParentID: pgtype.UUID{Bytes: sourceID, Valid: true},
+	})
+	if err != nil {
+		return CategoryMergeResult{}, fmt.Errorf("failed to move child categories: %w", err)
+	}
+
+	if err := qtx.DeleteCategory(ctx, sourceID); err != nil {
+		return CategoryMergeResult{}, fmt.Errorf("failed to deactivate source category: %w", err)
+	}
+
+	return CategoryMergeResult{
+		Target:            target,
+		TransactionsMoved: transactionsMoved,
+		ChildrenMoved:     childrenMoved,
+	}, nil
+}
+
+// mergeCategoryBudgets moves budgets and envelope assignments of source to target
+func mergeCategoryBudgets(ctx context.Context, qtx *sqlc.Queries, sourceID, targetID uuid.UUID) error {
+	err := qtx.AddMergedBudgetAmounts(ctx, sqlc.AddMergedBudgetAmountsParams{
+		SourceCategoryID: sourceID,
+		TargetCategoryID: targetID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge budgets: %w", err)
+	}
+
+	err = qtx.DeactivateMergedBudgets(ctx, sqlc.DeactivateMergedBudgetsParams{
+		SourceCategoryID: sourceID,
+		TargetCategoryID: targetID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge budgets: %w", err)
+	}
+
+	err = qtx.ReassignBudgetsCategory(ctx, sqlc.ReassignBudgetsCategoryParams{
+		TargetCategoryID: targetID,
+		SourceCategoryID: sourceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to move budgets: %w", err)
+	}
+
+	err = qtx.MergeBudgetAssignments(ctx, sqlc.MergeBudgetAssignmentsParams{
+		TargetCategoryID: targetID,
+		SourceCategoryID: sourceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge budget assignments: %w", err)
+	}
+
+	if err := qtx.DeleteBudgetAssignmentsByCategory(ctx, sourceID); err != nil {
+		return fmt.Errorf("failed to merge budget assignments: %w", err)
+	}
+
+	return nil
 }
 
-// Delete soft-deletes a category
-func (r *CategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.queries.DeleteCategory(ctx, id)
+// mergeCategoryAlerts moves alert thresholds and alert history of source to target
+func mergeCategoryAlerts(ctx context.Context, qtx *sqlc.Queries, sourceID, targetID uuid.UUID) error {
+	err := qtx.DeactivateMergedAlertThreshold(ctx, sqlc.DeactivateMergedAlertThresholdParams{
+		SourceCategoryID: sourceID,
+		TargetCategoryID: targetID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to merge alert thresholds: %w", err)
+	}
+
+	err = qtx.ReassignAlertThresholdsCategory(ctx, sqlc.ReassignAlertThresholdsCategoryParams{
+		TargetCategoryID: targetID,
+		SourceCategoryID: sourceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to move alert thresholds: %w", err)
+	}
+
+	err = qtx.ReassignAlertsCategory(ctx, sqlc.ReassignAlertsCategoryParams{
+		TargetCategoryID: targetID,
+		SourceCategoryID: sourceID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to move alerts: %w", err)
+	}
+
+	return nil
+}
+
+// ApplyTemplateInput contains data for applying a category template to a family
+type ApplyTemplateInput struct {
+	FamilyID  uuid.UUID
+	Template  categorytemplates.Template
+	AppliedBy uuid.UUID
+}
+
+// ApplyTemplateResult contains the outcome of applying a category template
+type ApplyTemplateResult struct {
+	Created []sqlc.Category
+	Skipped int // categories that already existed
+}
+
+// ApplyTemplate creates template categories missing in the family.
+// Applying the same template again creates nothing.
+func (r *CategoryRepository) ApplyTemplate(ctx context.Context, input ApplyTemplateInput) (ApplyTemplateResult, error) {
+	tx, err := r.beginHierarchyTx(ctx)
+	if err != nil {
+		return ApplyTemplateResult{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.AppliedBy.String()))
+	if err != nil {
+		return ApplyTemplateResult{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	result, err := applyCategoryTemplate(ctx, sqlc.New(tx), input.FamilyID, input.Template)
+	if err != nil {
+		return ApplyTemplateResult{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return ApplyTemplateResult{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return result, nil
+}
+
+// applyCategoryTemplate creates template categories that don't exist in the family yet
+// (matched by name and type). Subcategories of a deactivated category are skipped.
+// Must be called inside a transaction.
+func applyCategoryTemplate(ctx context.Context, qtx *sqlc.Queries, familyID uuid.UUID, template categorytemplates.Template) (ApplyTemplateResult, error) {
+	result := ApplyTemplateResult{Created: []sqlc.Category{}}
+
+	var apply func(categories []categorytemplates.Category, parentID pgtype.UUID) error
+	apply = func(categories []categorytemplates.Category, parentID pgtype.UUID) error {
+		for i, c := range categories {
+			category, err := qtx.GetCategoryByName(ctx, sqlc.GetCategoryByNameParams{
+				FamilyID: familyID,
+				Name:     c.Name,
+				Type:     c.Type,
+			})
+			switch {
+			case err == nil:
+				result.Skipped++
+				if !category.IsActive {
+					result.Skipped += categorytemplates.Template{Categories: c.Children}.Count()
+					continue
+				}
+			case errors.Is(err, pgx.ErrNoRows):
+				category, err = qtx.CreateCategory(ctx, sqlc.CreateCategoryParams{
+					ID:          uuid.New(),
+					FamilyID:    familyID,
+					Name:        c.Name,
+					Type:        c.Type,
+					ParentID:    parentID,
+					Description: pgtype.Text{String: c.Description, Valid: c.Description != ""},
+					SortOrder:   int32(i),
+				})
+				if err != nil {
+					return fmt.Errorf("failed to create category %q: %w", c.Name, err)
+				}
+				result.Created = append(result.Created, category)
+			default:
+				return fmt.Errorf("failed to get category %q: %w", c.Name, err)
+			}
+
+			if err := apply(c.Children, pgtype.UUID{Bytes: category.ID, Valid: true}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := apply(template.Categories, pgtype.UUID{}); err != nil {
+		return ApplyTemplateResult{}, err
+	}
+
+	return result, nil
 }
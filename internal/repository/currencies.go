@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+)
+
+var ErrCurrencyNotFound = errors.New("currency not found")
+
+// CurrencyRepository handles the ISO 4217 currency reference
+type CurrencyRepository struct {
+	queries *sqlc.Queries
+}
+
+// NewCurrencyRepository creates a new CurrencyRepository
+func NewCurrencyRepository(queries *sqlc.Queries) *CurrencyRepository {
+	return &CurrencyRepository{queries: queries}
+}
+
+// Get retrieves a currency by its ISO code
+func (r *CurrencyRepository) Get(ctx context.Context, code string) (sqlc.Currency, error) {
+	currency, err := r.queries.GetCurrency(ctx, code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.Currency{}, ErrCurrencyNotFound
+	}
+	if err != nil {
+		return sqlc.Currency{}, fmt.Errorf("failed to get currency: %w", err)
+	}
+	return currency, nil
+}
+
+// List retrieves all currencies including disabled ones
+func (r *CurrencyRepository) List(ctx context.Context) ([]sqlc.Currency, error) {
+	return r.queries.ListCurrencies(ctx)
+}
+
+// ListEnabled retrieves currencies that can be used for new data
+func (r *CurrencyRepository) ListEnabled(ctx context.Context) ([]sqlc.Currency, error) {
+	return r.queries.ListEnabledCurrencies(ctx)
+}
+
+// IsEnabled reports whether the currency exists and is enabled
+func (r *CurrencyRepository) IsEnabled(ctx context.Context, code string) (bool, error) {
+	currency, err := r.Get(ctx, code)
+	if errors.Is(err, ErrCurrencyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return currency.IsEnabled, nil
+}
+
+// Round rounds an amount to the minor units of the currency
+func (r *CurrencyRepository) Round(ctx context.Context, amount decimal.Decimal, code string) (decimal.Decimal, error) {
+	currency, err := r.Get(ctx, code)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	return RoundToMinorUnits(amount, currency), nil
+}
+
+// RoundToMinorUnits rounds an amount to the number of decimal places of the currency
+func RoundToMinorUnits(amount decimal.Decimal, currency sqlc.Currency) decimal.Decimal {
+	return amount.Round(int32(currency.MinorUnits))
+}
+
+// HasMinorUnits reports whether an amount has no more decimal places than the currency allows
+func HasMinorUnits(amount decimal.Decimal, currency sqlc.Currency) bool {
+	return amount.Equal(RoundToMinorUnits(amount, currency))
+}
+
+// getCurrency retrieves a currency within a transaction
+func getCurrency(ctx context.Context, tx pgx.Tx, code string) (sqlc.Currency, error) {
+	currency, err := sqlc.New(tx).GetCurrency(ctx, code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.Currency{}, ErrCurrencyNotFound
+	}
+	if err != nil {
+		return sqlc.Currency{}, fmt.Errorf("failed to get currency: %w", err)
+	}
+	return currency, nil
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+	"github.com/DigitLock/expense-tracker/internal/reportbuilder"
+)
+
+// customReportTimeout bounds the run time of a custom report query
+const customReportTimeout = 15 * time.Second
+
+// CustomReportRepository runs custom report queries and stores saved report definitions
+type CustomReportRepository struct {
+	queries *sqlc.Queries
+	pool    *pgxpool.Pool
+}
+
+// NewCustomReportRepository creates a new CustomReportRepository
+func NewCustomReportRepository(queries *sqlc.Queries, pool *pgxpool.Pool) *CustomReportRepository {
+	return &CustomReportRepository{
+		queries: queries,
+		pool:    pool,
+	}
+}
+
+// Run executes a query built by reportbuilder. Values of a row follow the
+// query columns: uuid.UUID, string, time.Time, int64 or decimal.Decimal.
+func (r *CustomReportRepository) Run(ctx context.Context, query reportbuilder.Query) ([][]any, error) {
+	ctx, cancel := context.WithTimeout(ctx, customReportTimeout)
+	defer cancel()
+
+	rows, err := r.pool.Query(ctx, query.SQL, query.Args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run custom report: %w", err)
+	}
+	defer rows.Close()
+
+	result := [][]any{}
+	for rows.Next() {
+		dest := make([]any, len(query.Columns))
+		for i, col := range query.Columns {
+			switch col.Type {
+			case reportbuilder.TypeUUID:
+				dest[i] = new(uuid.UUID)
+			case reportbuilder.TypeDate:
+				dest[i] = new(time.Time)
+			case reportbuilder.TypeInteger:
+				dest[i] = new(int64)
+			case reportbuilder.TypeDecimal:
+				dest[i] = new(decimal.Decimal)
+			default:
+				dest[i] = new(string)
+			}
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan custom report row: %w", err)
+		}
+
+		values := make([]any, len(dest))
+		for i, d := range dest {
+			switch v := d.(type) {
+			case *uuid.UUID:
+				values[i] = *v
+			case *time.Time:
+				values[i] = *v
+			case *int64:
+				values[i] = *v
+			case *decimal.Decimal:
+				values[i] = *v
+			case *string:
+				values[i] = *v
+			}
+		}
+		result = append(result, values)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to run custom report: %w", err)
+	}
+
+	return result, nil
+}
+
+// GetSaved retrieves an active saved report by ID
+func (r *CustomReportRepository) GetSaved(ctx context.Context, id uuid.UUID) (sqlc.SavedReport, error) {
+	return r.queries.GetSavedReport(ctx, id)
+}
+
+// ListSaved retrieves active saved reports of a family ordered by name
+func (r *CustomReportRepository) ListSaved(ctx context.Context, familyID uuid.UUID) ([]sqlc.SavedReport, error) {
+	return r.queries.ListSavedReportsByFamily(ctx, familyID)
+}
+
+// CreateSavedReportInput contains data for saving a report definition
+type CreateSavedReportInput struct {
+	FamilyID    uuid.UUID
+	Name        string
+	Description *string
+	Definition  reportbuilder.Definition
+	CreatedBy   uuid.UUID
+}
+
+// CreateSaved saves a report definition
+func (r *CustomReportRepository) CreateSaved(ctx context.Context, input CreateSavedReportInput) (sqlc.SavedReport, error) {
+	definition, err := json.Marshal(input.Definition)
+	if err != nil {
+		return sqlc.SavedReport{}, fmt.Errorf("failed to encode report definition: %w", err)
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.SavedReport{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.CreatedBy.String()))
+	if err != nil {
+		return sqlc.SavedReport{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	report, err := sqlc.New(tx).CreateSavedReport(ctx, sqlc.CreateSavedReportParams{
+		ID:          uuid.New(),
+		FamilyID:    input.FamilyID,
+		Name:        input.Name,
+		Description: toPgText(input.Description),
+		Definition:  definition,
+		CreatedBy:   pgtype.UUID{Bytes: input.CreatedBy, Valid: true},
+	})
+	if err != nil {
+		return sqlc.SavedReport{}, fmt.Errorf("failed to create saved report: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.SavedReport{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return report, nil
+}
+
+// UpdateSavedReportInput contains data for updating a saved report (partial update)
+type UpdateSavedReportInput struct {
+	ID          uuid.UUID
+	Name        *string
+	Description *string
+	Definition  *reportbuilder.Definition
+	UpdatedBy   uuid.UUID
+}
+
+// UpdateSaved updates a saved report (partial update)
+func (r *CustomReportRepository) UpdateSaved(ctx context.Context, input UpdateSavedReportInput) (sqlc.SavedReport, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.SavedReport{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.UpdatedBy.String()))
+	if err != nil {
+		return sqlc.SavedReport{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
+	current, err := qtx.GetSavedReport(ctx, input.ID)
+	if err != nil {
+		return sqlc.SavedReport{}, fmt.Errorf("failed to get saved report: %w", err)
+	}
+
+	name := current.Name
+	if input.Name != nil {
+		name = *input.Name
+	}
+
+	description := current.Description
+	if input.Description != nil {
+		description = toPgText(input.Description)
+	}
+
+	definition := current.Definition
+	if input.Definition != nil {
+		definition, err = json.Marshal(input.Definition)
+		if err != nil {
+			return sqlc.SavedReport{}, fmt.Errorf("failed to encode report definition: %w", err)
+		}
+	}
+
+	report, err := qtx.UpdateSavedReport(ctx, sqlc.UpdateSavedReportParams{
+		ID:          input.ID,
+		Name:        name,
+		Description: description,
+		Definition:  definition,
+	})
+	if err != nil {
+		return sqlc.SavedReport{}, fmt.Errorf("failed to update saved report: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.SavedReport{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return report, nil
+}
+
+// DeleteSaved soft-deletes a saved report
+func (r *CustomReportRepository) DeleteSaved(ctx context.Context, id, deletedBy uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", deletedBy.String()))
+	if err != nil {
+		return fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	if err := sqlc.New(tx).DeleteSavedReport(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete saved report: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
@@ -2,15 +2,20 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/shopspring/decimal"
 
 	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
 )
 
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
 // ExchangeRateRepository handles exchange rate data operations
 type ExchangeRateRepository struct {
 	queries *sqlc.Queries
@@ -39,6 +44,41 @@ func (r *ExchangeRateRepository) GetLatestRate(ctx context.Context, fromCurrency
 	})
 }
 
+// ResolveRate returns the rate converting 1 fromCurrency to toCurrency using the latest
+// rate up to given date, together with the stored rate it was derived from.
+// Falls back to the inverse rate if the direct pair is not stored, then to a
+// cross rate through EUR or RSD.
+func (r *ExchangeRateRepository) ResolveRate(ctx context.Context, fromCurrency, toCurrency string, date time.Time) (decimal.Decimal, sqlc.ExchangeRate, error) {
+	rate, stored, err := resolveRate(ctx, r.queries, fromCurrency, toCurrency, date)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return decimal.Zero, sqlc.ExchangeRate{}, fmt.Errorf("%w: %s/%s", ErrExchangeRateNotFound, fromCurrency, toCurrency)
+	}
+	if err != nil {
+		return decimal.Zero, sqlc.ExchangeRate{}, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return rate, stored, nil
+}
+
+// Convert converts amount between currencies using the latest rate up to given date.
+// The result is rounded to the minor units of the target currency.
+func (r *ExchangeRateRepository) Convert(ctx context.Context, amount decimal.Decimal, fromCurrency, toCurrency string, date time.Time) (decimal.Decimal, error) {
+	if fromCurrency == toCurrency {
+		return amount, nil
+	}
+
+	rate, _, err := r.ResolveRate(ctx, fromCurrency, toCurrency, date)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	target, err := r.queries.GetCurrency(ctx, toCurrency)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get currency %s: %w", toCurrency, err)
+	}
+
+	return RoundToMinorUnits(amount.Mul(rate), target), nil
+}
+
 // ListByDate retrieves all exchange rates for a specific date
 func (r *ExchangeRateRepository) ListByDate(ctx context.Context, date time.Time) ([]sqlc.ExchangeRate, error) {
 	return r.queries.ListExchangeRatesByDate(ctx, pgtype.Date{Time: date, Valid: true})
@@ -60,6 +100,7 @@ type CreateRateInput struct {
 	ToCurrency   string
 	Rate         decimal.Decimal
 	Date         time.Time
+	Source       string // provider of the rate, "manual" if empty
 }
 
 // Create creates a new exchange rate
@@ -70,6 +111,7 @@ func (r *ExchangeRateRepository) Create(ctx context.Context, input CreateRateInp
 		ToCurrency:   input.ToCurrency,
 		Rate:         input.Rate,
 		Date:         pgtype.Date{Time: input.Date, Valid: true},
+		Source:       rateSource(input.Source),
 	})
 }
 
@@ -81,5 +123,117 @@ func (r *ExchangeRateRepository) Upsert(ctx context.Context, input CreateRateInp
 		ToCurrency:   input.ToCurrency,
 		Rate:         input.Rate,
 		Date:         pgtype.Date{Time: input.Date, Valid: true},
+		Source:       rateSource(input.Source),
 	})
 }
+
+// rateSource returns the source value of a rate, defaulting to "manual"
+func rateSource(source string) string {
+	if source == "" {
+		return "manual"
+	}
+	return source
+}
+
+// crossCurrencies are the currencies rate providers publish against (ECB: EUR, NBS: RSD),
+// tried in order to derive cross rates
+var crossCurrencies = []string{"EUR", "RSD"}
+
+// getExchangeRate retrieves latest exchange rate up to given date within a transaction.
+// Falls back to the inverse rate, then to a cross rate through EUR or RSD.
+func getExchangeRate(ctx context.Context, tx pgx.Tx, fromCurrency, toCurrency string, date time.Time) (decimal.Decimal, error) {
+	rate, _, err := resolveRate(ctx, sqlc.New(tx), fromCurrency, toCurrency, date)
+	return rate, err
+}
+
+// resolveRate finds the rate of a currency pair: stored directly, as the inverse pair,
+// or as a cross rate when providers only publish rates against their own currency.
+// Returns the stored rate it was derived from (the more recent leg of a cross rate)
+// and pgx.ErrNoRows if the rate cannot be derived.
+func resolveRate(ctx context.Context, q *sqlc.Queries, fromCurrency, toCurrency string, date time.Time) (decimal.Decimal, sqlc.ExchangeRate, error) {
+	rate, stored, err := resolvePairRate(ctx, q, fromCurrency, toCurrency, date)
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return rate, stored, err
+	}
+
+	for _, cross := range crossCurrencies {
+		if cross == fromCurrency || cross == toCurrency {
+			continue
+		}
+
+		toCross, storedToCross, err := resolvePairRate(ctx, q, fromCurrency, cross, date)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return decimal.Zero, sqlc.ExchangeRate{}, err
+		}
+
+		fromCross, storedFromCross, err := resolvePairRate(ctx, q, cross, toCurrency, date)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return decimal.Zero, sqlc.ExchangeRate{}, err
+		}
+
+		stored = storedToCross
+		if storedFromCross.CreatedAt.After(stored.CreatedAt) {
+			stored = storedFromCross
+		}
+		return toCross.Mul(fromCross), stored, nil
+	}
+
+	return decimal.Zero, sqlc.ExchangeRate{}, pgx.ErrNoRows
+}
+
+// resolvePairRate finds the latest direct or inverse rate of a currency pair up to given date
+func resolvePairRate(ctx context.Context, q *sqlc.Queries, fromCurrency, toCurrency string, date time.Time) (decimal.Decimal, sqlc.ExchangeRate, error) {
+	rate, err := q.GetLatestExchangeRate(ctx, sqlc.GetLatestExchangeRateParams{
+		FromCurrency: fromCurrency,
+		ToCurrency:   toCurrency,
+		Date:         pgtype.Date{Time: date, Valid: true},
+	})
+	if err == nil {
+		return rate.Rate, rate, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return decimal.Zero, sqlc.ExchangeRate{}, err
+	}
+
+	inverse, err := q.GetLatestExchangeRate(ctx, sqlc.GetLatestExchangeRateParams{
+		FromCurrency: toCurrency,
+		ToCurrency:   fromCurrency,
+		Date:         pgtype.Date{Time: date, Valid: true},
+	})
+	if err != nil {
+		return decimal.Zero, sqlc.ExchangeRate{}, err
+	}
+
+	return decimal.NewFromInt(1).Div(inverse.Rate), inverse, nil
+}
+
+// toBaseAmount converts an amount to the base currency of the family within a
+// transaction, rounded to its minor units. The family row is share-locked, so the base currency cannot
+// change until the transaction ends.
+func toBaseAmount(ctx context.Context, tx pgx.Tx, familyID uuid.UUID, amount decimal.Decimal, currency string, date time.Time) (decimal.Decimal, error) {
+	baseCurrency, err := sqlc.New(tx).LockFamilyBaseCurrency(ctx, familyID)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get base currency: %w", err)
+	}
+
+	if currency == baseCurrency {
+		return amount, nil
+	}
+
+	base, err := getCurrency(ctx, tx, baseCurrency)
+	if err != nil {
+		return decimal.Zero, err
+	}
+
+	rate, err := getExchangeRate(ctx, tx, currency, baseCurrency, date)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+	return RoundToMinorUnits(amount.Mul(rate), base), nil
+}
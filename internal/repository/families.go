@@ -2,20 +2,33 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/DigitLock/expense-tracker/internal/categorytemplates"
 	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
 )
 
+// ErrMissingExchangeRates is returned when family amounts cannot be converted to a new base currency
+var ErrMissingExchangeRates = errors.New("exchange rates are missing for some transactions")
+
 // FamilyRepository handles family data operations
 type FamilyRepository struct {
 	queries *sqlc.Queries
+	pool    *pgxpool.Pool
 }
 
 // NewFamilyRepository creates a new FamilyRepository
-func NewFamilyRepository(queries *sqlc.Queries) *FamilyRepository {
-	return &FamilyRepository{queries: queries}
+func NewFamilyRepository(queries *sqlc.Queries, pool *pgxpool.Pool) *FamilyRepository {
+	return &FamilyRepository{
+		queries: queries,
+		pool:    pool,
+	}
 }
 
 // GetByID retrieves a family by ID
@@ -33,22 +46,209 @@ func (r *FamilyRepository) List(ctx context.Context) ([]sqlc.Family, error) {
 	return r.queries.ListFamilies(ctx)
 }
 
-// Create creates a new family
+// Create creates a new family with categories from the default template
 func (r *FamilyRepository) Create(ctx context.Context, name, baseCurrency string) (sqlc.Family, error) {
-	return r.queries.CreateFamily(ctx, sqlc.CreateFamilyParams{
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.Family{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := sqlc.New(tx)
+
+	family, err := qtx.CreateFamily(ctx, sqlc.CreateFamilyParams{
 		ID:           uuid.New(),
 		Name:         name,
 		BaseCurrency: baseCurrency,
 	})
+	if err != nil {
+		return sqlc.Family{}, fmt.Errorf("failed to create family: %w", err)
+	}
+
+	template, ok := categorytemplates.Get(categorytemplates.Default)
+	if ok {
+		if _, err := applyCategoryTemplate(ctx, qtx, family.ID, template); err != nil {
+			return sqlc.Family{}, fmt.Errorf("failed to create default categories: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.Family{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return family, nil
+}
+
+// GetBaseCurrency retrieves the base currency of a family
+func (r *FamilyRepository) GetBaseCurrency(ctx context.Context, id uuid.UUID) (string, error) {
+	return r.queries.GetFamilyBaseCurrency(ctx, id)
+}
+
+// UpdateFamilyInput contains data for updating a family (partial update)
+type UpdateFamilyInput struct {
+	ID           uuid.UUID
+	Name         *string
+	BaseCurrency *string
+	UpdatedBy    uuid.UUID
 }
 
-// Update updates a family
-func (r *FamilyRepository) Update(ctx context.Context, id uuid.UUID, name, baseCurrency string) (sqlc.Family, error) {
-	return r.queries.UpdateFamily(ctx, sqlc.UpdateFamilyParams{
-		ID:           id,
+// UpdateFamilyResult describes the updated family
+type UpdateFamilyResult struct {
+	Family                 sqlc.Family
+	RecomputedTransactions int64 // transactions converted to the new base currency
+	ConvertedAmounts       int64 // budgets, assignments, threshold limits and alerts converted
+}
+
+// Update updates a family. When the base currency changes, amount_base of all
+// family transactions is recomputed with the exchange rates of their dates in
+// the same database transaction; monthly aggregates follow via trigger.
+// Budgets, budget assignments, fixed alert limits and raised alerts are kept
+// in the base currency and are converted with the current exchange rate.
+func (r *FamilyRepository) Update(ctx context.Context, input UpdateFamilyInput) (UpdateFamilyResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return UpdateFamilyResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.UpdatedBy.String()))
+	if err != nil {
+		return UpdateFamilyResult{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
+	current, err := qtx.GetFamily(ctx, input.ID)
+	if err != nil {
+		return UpdateFamilyResult{}, fmt.Errorf("failed to get family: %w", err)
+	}
+
+	name := current.Name
+	if input.Name != nil {
+		name = *input.Name
+	}
+
+	baseCurrency := current.BaseCurrency
+	if input.BaseCurrency != nil {
+		baseCurrency = *input.BaseCurrency
+	}
+
+	// Locks the family row: transaction writes wait until the recomputation is committed
+	family, err := qtx.UpdateFamily(ctx, sqlc.UpdateFamilyParams{
+		ID:           input.ID,
 		Name:         name,
 		BaseCurrency: baseCurrency,
 	})
+	if err != nil {
+		return UpdateFamilyResult{}, fmt.Errorf("failed to update family: %w", err)
+	}
+
+	result := UpdateFamilyResult{Family: family}
+
+	if baseCurrency != current.BaseCurrency {
+		missing, err := qtx.CountTransactionsWithoutExchangeRate(ctx, sqlc.CountTransactionsWithoutExchangeRateParams{
+			FamilyID:     input.ID,
+			BaseCurrency: baseCurrency,
+		})
+		if err != nil {
+			return UpdateFamilyResult{}, fmt.Errorf("failed to check exchange rates: %w", err)
+		}
+		if missing > 0 {
+			return UpdateFamilyResult{}, fmt.Errorf("%w: %d transactions cannot be converted to %s", ErrMissingExchangeRates, missing, baseCurrency)
+		}
+
+		result.RecomputedTransactions, err = qtx.RecomputeTransactionsAmountBase(ctx, sqlc.RecomputeTransactionsAmountBaseParams{
+			BaseCurrency: baseCurrency,
+			FamilyID:     input.ID,
+		})
+		if err != nil {
+			return UpdateFamilyResult{}, fmt.Errorf("failed to recompute base amounts: %w", err)
+		}
+
+		result.ConvertedAmounts, err = convertBaseAmounts(ctx, tx, input.ID, current.BaseCurrency, baseCurrency)
+		if err != nil {
+			return UpdateFamilyResult{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return UpdateFamilyResult{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return result, nil
+}
+
+// convertBaseAmounts converts family amounts stored in the base currency from
+// one currency to another with the latest exchange rate
+func convertBaseAmounts(ctx context.Context, tx pgx.Tx, familyID uuid.UUID, fromCurrency, toCurrency string) (int64, error) {
+	qtx := sqlc.New(tx)
+
+	total, err := qtx.CountFamilyBaseCurrencyAmounts(ctx, familyID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count base currency amounts: %w", err)
+	}
+	if total == 0 {
+		return 0, nil
+	}
+
+	rate, err := getExchangeRate(ctx, tx, fromCurrency, toCurrency, time.Now())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%w: budgets and alerts cannot be converted to %s", ErrMissingExchangeRates, toCurrency)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get exchange rate: %w", err)
+	}
+
+	currency, err := getCurrency(ctx, tx, toCurrency)
+	if err != nil {
+		return 0, err
+	}
+	minorUnits := int32(currency.MinorUnits)
+
+	var converted int64
+
+	n, err := qtx.ConvertBudgetAmounts(ctx, sqlc.ConvertBudgetAmountsParams{
+		Rate:       rate,
+		MinorUnits: minorUnits,
+		FamilyID:   familyID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert budgets: %w", err)
+	}
+	converted += n
+
+	n, err = qtx.ConvertBudgetAssignmentAmounts(ctx, sqlc.ConvertBudgetAssignmentAmountsParams{
+		Rate:       rate,
+		MinorUnits: minorUnits,
+		FamilyID:   familyID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert budget assignments: %w", err)
+	}
+	converted += n
+
+	n, err = qtx.ConvertAlertThresholdLimits(ctx, sqlc.ConvertAlertThresholdLimitsParams{
+		Rate:       rate,
+		MinorUnits: minorUnits,
+		FamilyID:   familyID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert alert thresholds: %w", err)
+	}
+	converted += n
+
+	n, err = qtx.ConvertAlertAmounts(ctx, sqlc.ConvertAlertAmountsParams{
+		Rate:       rate,
+		MinorUnits: minorUnits,
+		FamilyID:   familyID,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert alerts: %w", err)
+	}
+	converted += n
+
+	return converted, nil
 }
 
 // Delete soft-deletes a family
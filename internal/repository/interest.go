@@ -0,0 +1,271 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+)
+
+// Compounding frequencies supported for interest accrual
+const (
+	CompoundingMonthly   = "monthly"
+	CompoundingQuarterly = "quarterly"
+	CompoundingAnnually  = "annually"
+)
+
+// ErrInterestAlreadyPosted is returned when interest for a payout date was already posted
+var ErrInterestAlreadyPosted = errors.New("interest already posted for this payout date")
+
+// InterestRepository handles interest settings and posting of interest income
+type InterestRepository struct {
+	queries *sqlc.Queries
+	pool    *pgxpool.Pool
+}
+
+// NewInterestRepository creates a new InterestRepository
+func NewInterestRepository(queries *sqlc.Queries, pool *pgxpool.Pool) *InterestRepository {
+	return &InterestRepository{
+		queries: queries,
+		pool:    pool,
+	}
+}
+
+// GetByAccount retrieves interest settings of an account
+func (r *InterestRepository) GetByAccount(ctx context.Context, accountID uuid.UUID) (sqlc.AccountInterestSetting, error) {
+	return r.queries.GetAccountInterestSettings(ctx, accountID)
+}
+
+// ListForPosting retrieves interest settings of all active accounts
+func (r *InterestRepository) ListForPosting(ctx context.Context) ([]sqlc.AccountInterestSetting, error) {
+	return r.queries.ListInterestSettingsForPosting(ctx)
+}
+
+// UpsertInterestSettingsInput contains data for creating or replacing interest settings
+type UpsertInterestSettingsInput struct {
+	AccountID          uuid.UUID
+	FamilyID           uuid.UUID
+	AnnualRate         decimal.Decimal // percent
+	Compounding        string          // monthly, quarterly, annually
+	PayoutDay          int             // 1-28
+	WithholdingTaxRate decimal.Decimal // percent
+	IncomeCategoryID   uuid.UUID
+	UpdatedBy          uuid.UUID
+}
+
+// Upsert creates or replaces interest settings of an account
+func (r *InterestRepository) Upsert(ctx context.Context, input UpsertInterestSettingsInput) (sqlc.AccountInterestSetting, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.AccountInterestSetting{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.UpdatedBy.String()))
+	if err != nil {
+		return sqlc.AccountInterestSetting{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
+	result, err := qtx.UpsertAccountInterestSettings(ctx, sqlc.UpsertAccountInterestSettingsParams{
+		ID:                 uuid.New(),
+		AccountID:          input.AccountID,
+		FamilyID:           input.FamilyID,
+		AnnualRate:         input.AnnualRate,
+		Compounding:        input.Compounding,
+		PayoutDay:          int16(input.PayoutDay),
+		WithholdingTaxRate: input.WithholdingTaxRate,
+		IncomeCategoryID:   input.IncomeCategoryID,
+		CreatedBy:          input.UpdatedBy,
+	})
+	if err != nil {
+		return sqlc.AccountInterestSetting{}, fmt.Errorf("failed to save interest settings: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.AccountInterestSetting{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return result, nil
+}
+
+// Delete removes interest settings of an account. Posted transactions are kept.
+func (r *InterestRepository) Delete(ctx context.Context, accountID, deletedBy uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", deletedBy.String()))
+	if err != nil {
+		return fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	if err := sqlc.New(tx).DeleteAccountInterestSettings(ctx, accountID); err != nil {
+		return fmt.Errorf("failed to delete interest settings: %w", err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// InterestAmount contains interest for one compounding period
+type InterestAmount struct {
+	Gross decimal.Decimal
+	Tax   decimal.Decimal
+	Net   decimal.Decimal
+}
+
+// InterestPostingResult contains the outcome of posting interest for one payout date
+type InterestPostingResult struct {
+	Balance     decimal.Decimal
+	Interest    InterestAmount
+	Transaction *sqlc.Transaction // nil if there was nothing to post
+}
+
+// PostInterest posts net interest for a payout date as an income transaction.
+// The payout date is recorded in the same transaction, so each date is posted only once.
+func (r *InterestRepository) PostInterest(ctx context.Context, settings sqlc.AccountInterestSetting, payoutDate time.Time) (InterestPostingResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return InterestPostingResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Interest is posted on behalf of the user who configured it
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", settings.CreatedBy.String()))
+	if err != nil {
+		return InterestPostingResult{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
+	// Mark payout date first - locks the settings row against concurrent runs
+	updated, err := qtx.UpdateInterestLastPostedDate(ctx, sqlc.UpdateInterestLastPostedDateParams{
+		ID:             settings.ID,
+		LastPostedDate: pgtype.Date{Time: payoutDate, Valid: true},
+	})
+	if err != nil {
+		return InterestPostingResult{}, fmt.Errorf("failed to update last posted date: %w", err)
+	}
+	if updated == 0 {
+		return InterestPostingResult{}, ErrInterestAlreadyPosted
+	}
+
+	account, err := qtx.GetAccount(ctx, settings.AccountID)
+	if err != nil {
+		return InterestPostingResult{}, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	balance, err := qtx.GetAccountBalanceAsOf(ctx, sqlc.GetAccountBalanceAsOfParams{
+		ID:              settings.AccountID,
+		TransactionDate: pgtype.Date{Time: payoutDate, Valid: true},
+	})
+	if err != nil {
+		return InterestPostingResult{}, fmt.Errorf("failed to calculate balance: %w", err)
+	}
+
+	currency, err := getCurrency(ctx, tx, account.Currency)
+	if err != nil {
+		return InterestPostingResult{}, err
+	}
+
+	result := InterestPostingResult{
+		Balance:  balance,
+		Interest: CalculateInterest(settings, balance, currency),
+	}
+
+	if result.Interest.Net.IsPositive() {
+		amountBase, err := toBaseAmount(ctx, tx, account.FamilyID, result.Interest.Net, account.Currency, payoutDate)
+		if err != nil {
+			return InterestPostingResult{}, err
+		}
+
+		description := fmt.Sprintf("Interest %s (gross %s, tax %s)",
+			payoutDate.Format("2006-01-02"),
+			result.Interest.Gross.StringFixed(int32(currency.MinorUnits)),
+			result.Interest.Tax.StringFixed(int32(currency.MinorUnits)),
+		)
+
+		transaction, err := qtx.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+			ID:              uuid.New(),
+			FamilyID:        settings.FamilyID,
+			AccountID:       settings.AccountID,
+			CategoryID:      settings.IncomeCategoryID,
+			Type:            "income",
+			Amount:          result.Interest.Net,
+			Currency:        account.Currency,
+			AmountBase:      amountBase,
+			Description:     pgtype.Text{String: description, Valid: true},
+			TransactionDate: pgtype.Date{Time: payoutDate, Valid: true},
+			CreatedBy:       settings.CreatedBy,
+		})
+		if err != nil {
+			return InterestPostingResult{}, fmt.Errorf("failed to create interest transaction: %w", err)
+		}
+		result.Transaction = &transaction
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return InterestPostingResult{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return result, nil
+}
+
+// CompoundingMonths returns length of a compounding period in months
+func CompoundingMonths(compounding string) int {
+	switch compounding {
+	case CompoundingQuarterly:
+		return 3
+	case CompoundingAnnually:
+		return 12
+	default:
+		return 1
+	}
+}
+
+// NextInterestPayoutDate returns the payout date following the last posted one.
+// If nothing was posted yet, the first payout is one compounding period after settings were created.
+func NextInterestPayoutDate(settings sqlc.AccountInterestSetting) time.Time {
+	base := settings.CreatedAt
+	if settings.LastPostedDate.Valid {
+		base = settings.LastPostedDate.Time
+	}
+
+	months := CompoundingMonths(settings.Compounding)
+	return time.Date(base.Year(), base.Month()+time.Month(months), int(settings.PayoutDay), 0, 0, 0, 0, time.UTC)
+}
+
+// CalculateInterest calculates interest for one compounding period on given balance.
+// Uses simple nominal rate per period: balance * annual_rate * period_months / 12,
+// rounded to the minor units of the account currency.
+func CalculateInterest(settings sqlc.AccountInterestSetting, balance decimal.Decimal, currency sqlc.Currency) InterestAmount {
+	if !balance.IsPositive() {
+		return InterestAmount{}
+	}
+
+	hundred := decimal.NewFromInt(100)
+	periodRate := settings.AnnualRate.Div(hundred).
+		Mul(decimal.NewFromInt(int64(CompoundingMonths(settings.Compounding)))).
+		Div(decimal.NewFromInt(12))
+
+	gross := RoundToMinorUnits(balance.Mul(periodRate), currency)
+	tax := RoundToMinorUnits(gross.Mul(settings.WithholdingTaxRate).Div(hundred), currency)
+
+	return InterestAmount{
+		Gross: gross,
+		Tax:   tax,
+		Net:   gross.Sub(tax),
+	}
+}
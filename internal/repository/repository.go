@@ -14,6 +14,13 @@ type Repositories struct {
 	Categories    *CategoryRepository
 	Transactions  *TransactionRepository
 	ExchangeRates *ExchangeRateRepository
+	Currencies    *CurrencyRepository
+	SavingsGoals  *SavingsGoalRepository
+	Interest      *InterestRepository
+	Budgets       *BudgetRepository
+	Alerts        *AlertRepository
+	CustomReports *CustomReportRepository
+	Aggregates    *AggregateRepository
 
 	// Keep reference to pool for transactions
 	pool *pgxpool.Pool
@@ -24,12 +31,19 @@ func New(pool *pgxpool.Pool) *Repositories {
 	queries := sqlc.New(pool)
 
 	return &Repositories{
-		Families:      NewFamilyRepository(queries),
+		Families:      NewFamilyRepository(queries, pool),
 		Users:         NewUserRepository(queries),
-		Accounts:      NewAccountRepository(queries),
-		Categories:    NewCategoryRepository(queries),
+		Accounts:      NewAccountRepository(queries, pool),
+		Categories:    NewCategoryRepository(queries, pool),
 		Transactions:  NewTransactionRepository(queries, pool),
 		ExchangeRates: NewExchangeRateRepository(queries),
+		Currencies:    NewCurrencyRepository(queries),
+		SavingsGoals:  NewSavingsGoalRepository(queries, pool),
+		Interest:      NewInterestRepository(queries, pool),
+		Budgets:       NewBudgetRepository(queries, pool),
+		Alerts:        NewAlertRepository(queries, pool),
+		CustomReports: NewCustomReportRepository(queries, pool),
+		Aggregates:    NewAggregateRepository(queries),
 		pool:          pool,
 	}
 }
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
+)
+
+// SavingsGoalRepository handles savings goal data operations
+type SavingsGoalRepository struct {
+	queries *sqlc.Queries
+	pool    *pgxpool.Pool
+}
+
+// NewSavingsGoalRepository creates a new SavingsGoalRepository
+func NewSavingsGoalRepository(queries *sqlc.Queries, pool *pgxpool.Pool) *SavingsGoalRepository {
+	return &SavingsGoalRepository{
+		queries: queries,
+		pool:    pool,
+	}
+}
+
+// GetByID retrieves an active savings goal by ID
+func (r *SavingsGoalRepository) GetByID(ctx context.Context, id uuid.UUID) (sqlc.SavingsGoal, error) {
+	return r.queries.GetSavingsGoal(ctx, id)
+}
+
+// ListByFamily retrieves all active savings goals in a family
+func (r *SavingsGoalRepository) ListByFamily(ctx context.Context, familyID uuid.UUID) ([]sqlc.SavingsGoal, error) {
+	return r.queries.ListSavingsGoalsByFamily(ctx, familyID)
+}
+
+// ListAccounts retrieves accounts linked to a savings goal
+func (r *SavingsGoalRepository) ListAccounts(ctx context.Context, goalID uuid.UUID) ([]sqlc.SavingsGoalAccount, error) {
+	return r.queries.ListSavingsGoalAccounts(ctx, goalID)
+}
+
+// ListAccountsByFamily retrieves account links of all active savings goals in a family
+func (r *SavingsGoalRepository) ListAccountsByFamily(ctx context.Context, familyID uuid.UUID) ([]sqlc.SavingsGoalAccount, error) {
+	return r.queries.ListSavingsGoalAccountsByFamily(ctx, familyID)
+}
+
+// SavingsGoalAccountInput links an account to a savings goal
+type SavingsGoalAccountInput struct {
+	AccountID       uuid.UUID
+	EarmarkedAmount *decimal.Decimal // nil = whole account balance counts
+}
+
+// CreateSavingsGoalInput contains data for creating a new savings goal
+type CreateSavingsGoalInput struct {
+	FamilyID     uuid.UUID
+	Name         string
+	TargetAmount decimal.Decimal
+	Currency     string
+	Deadline     *time.Time
+	Description  *string
+	Accounts     []SavingsGoalAccountInput
+	CreatedBy    uuid.UUID
+}
+
+// Create creates a new savings goal with its linked accounts
+func (r *SavingsGoalRepository) Create(ctx context.Context, input CreateSavingsGoalInput) (sqlc.SavingsGoal, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.SavingsGoal{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.CreatedBy.String()))
+	if err != nil {
+		return sqlc.SavingsGoal{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
+	goal, err := qtx.CreateSavingsGoal(ctx, sqlc.CreateSavingsGoalParams{
+		ID:           uuid.New(),
+		FamilyID:     input.FamilyID,
+		Name:         input.Name,
+		TargetAmount: input.TargetAmount,
+		Currency:     input.Currency,
+		Deadline:     toPgDate(input.Deadline),
+		Description:  toPgText(input.Description),
+		CreatedBy:    pgtype.UUID{Bytes: input.CreatedBy, Valid: true},
+	})
+	if err != nil {
+		return sqlc.SavingsGoal{}, fmt.Errorf("failed to create savings goal: %w", err)
+	}
+
+	if err := addSavingsGoalAccounts(ctx, qtx, goal.ID, input.Accounts); err != nil {
+		return sqlc.SavingsGoal{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.SavingsGoal{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return goal, nil
+}
+
+// UpdateSavingsGoalInput contains data for updating a savings goal (partial update)
+type UpdateSavingsGoalInput struct {
+	ID            uuid.UUID
+	Name          *string
+	TargetAmount  *decimal.Decimal
+	Currency      *string
+	Deadline      *time.Time
+	ClearDeadline bool
+	Description   *string
+	Accounts      *[]SavingsGoalAccountInput // nil = keep current links
+	UpdatedBy     uuid.UUID
+}
+
+// Update updates savings goal details (partial update)
+func (r *SavingsGoalRepository) Update(ctx context.Context, input UpdateSavingsGoalInput) (sqlc.SavingsGoal, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return sqlc.SavingsGoal{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.UpdatedBy.String()))
+	if err != nil {
+		return sqlc.SavingsGoal{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
+	qtx := sqlc.New(tx)
+
+	current, err := qtx.GetSavingsGoal(ctx, input.ID)
+	if err != nil {
+		return sqlc.SavingsGoal{}, err
+	}
+
+	// Apply updates (keep current values if not provided)
+	params := sqlc.UpdateSavingsGoalParams{
+		ID:           input.ID,
+		Name:         current.Name,
+		TargetAmount: current.TargetAmount,
+		Currency:     current.Currency,
+		Deadline:     current.Deadline,
+		Description:  current.Description,
+	}
+	if input.Name != nil {
+		params.Name = *input.Name
+	}
+	if input.TargetAmount != nil {
+		params.TargetAmount = *input.TargetAmount
+	}
+	if input.Currency != nil {
+		params.Currency = *input.Currency
+	}
+	if input.ClearDeadline {
+		params.Deadline = pgtype.Date{}
+	} else if input.Deadline != nil {
+		params.Deadline = toPgDate(input.Deadline)
+	}
+	if input.Description != nil {
+		params.Description = toPgText(input.Description)
+	}
+
+	goal, err := qtx.UpdateSavingsGoal(ctx, params)
+	if err != nil {
+		return sqlc.SavingsGoal{}, fmt.Errorf("failed to update savings goal: %w", err)
+	}
+
+	// Replace linked accounts if provided
+	if input.Accounts != nil {
+		if err := qtx.DeleteSavingsGoalAccounts(ctx, input.ID); err != nil {
+			return sqlc.SavingsGoal{}, fmt.Errorf("failed to unlink accounts: %w", err)
+		}
+		if err := addSavingsGoalAccounts(ctx, qtx, input.ID, *input.Accounts); err != nil {
+			return sqlc.SavingsGoal{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return sqlc.SavingsGoal{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return goal, nil
+}
+
+// Delete soft-deletes a savings goal
+func (r *SavingsGoalRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.queries.DeleteSavingsGoal(ctx, id)
+}
+
+// addSavingsGoalAccounts links accounts to a savings goal within a transaction
+func addSavingsGoalAccounts(ctx context.Context, qtx *sqlc.Queries, goalID uuid.UUID, accounts []SavingsGoalAccountInput) error {
+	for _, a := range accounts {
+		err := qtx.AddSavingsGoalAccount(ctx, sqlc.AddSavingsGoalAccountParams{
+			GoalID:          goalID,
+			AccountID:       a.AccountID,
+			EarmarkedAmount: toPgNumeric(a.EarmarkedAmount),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to link account: %w", err)
+		}
+	}
+	return nil
+}
+
+func toPgDate(t *time.Time) pgtype.Date {
+	if t == nil {
+		return pgtype.Date{}
+	}
+	return pgtype.Date{Time: *t, Valid: true}
+}
+
+func toPgText(s *string) pgtype.Text {
+	if s == nil || *s == "" {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *s, Valid: true}
+}
+
+func toPgNumeric(d *decimal.Decimal) pgtype.Numeric {
+	if d == nil {
+		return pgtype.Numeric{}
+	}
+	return pgtype.Numeric{Int: d.Coefficient(), Exp: d.Exponent(), Valid: true}
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -14,6 +15,16 @@ import (
 	"github.com/DigitLock/expense-tracker/internal/database/sqlc"
 )
 
+// BalanceAdjustmentCategoryName is the name of the system category used for balance corrections
+const BalanceAdjustmentCategoryName = "Balance adjustment"
+
+// balanceAdjustmentReservedName is used for the system category when a user
+// category already has BalanceAdjustmentCategoryName
+const balanceAdjustmentReservedName = "Balance adjustment (system)"
+
+// ErrBalanceUnchanged is returned when an account already has the requested balance
+var ErrBalanceUnchanged = errors.New("account balance already matches target balance")
+
 // TransactionRepository handles transaction data operations
 type TransactionRepository struct {
 	queries *sqlc.Queries
@@ -73,7 +84,7 @@ type CreateTransactionInput struct {
 	CategoryID      uuid.UUID
 	Type            string // income, expense
 	Amount          decimal.Decimal
-	Currency        string // RSD, EUR
+	Currency        string // ISO 4217 code, see currencies
 	Description     string
 	TransactionDate time.Time
 	CreatedBy       uuid.UUID
@@ -94,15 +105,10 @@ func (r *TransactionRepository) Create(ctx context.Context, input CreateTransact
 		return sqlc.Transaction{}, fmt.Errorf("failed to set audit user: %w", err)
 	}
 
-	// Calculate amount_base (convert to base currency if needed)
-	amountBase := input.Amount
-	if input.Currency != "RSD" {
-		// Get exchange rate
-		rate, err := r.getExchangeRate(ctx, tx, input.Currency, "RSD", input.TransactionDate)
-		if err != nil {
-			return sqlc.Transaction{}, fmt.Errorf("failed to get exchange rate: %w", err)
-		}
-		amountBase = input.Amount.Mul(rate)
+	// Calculate amount_base (convert to the family base currency if needed)
+	amountBase, err := toBaseAmount(ctx, tx, input.FamilyID, input.Amount, input.Currency, input.TransactionDate)
+	if err != nil {
+		return sqlc.Transaction{}, err
 	}
 
 	// Create transaction using queries with tx
@@ -130,6 +136,12 @@ func (r *TransactionRepository) Create(ctx context.Context, input CreateTransact
 		return sqlc.Transaction{}, fmt.Errorf("failed to create transaction: %w", err)
 	}
 
+	if result.Type == "expense" {
+		if err := evaluateAlerts(ctx, qtx, result.FamilyID, result.CategoryID, input.TransactionDate); err != nil {
+			return sqlc.Transaction{}, err
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return sqlc.Transaction{}, fmt.Errorf("failed to commit: %w", err)
 	}
@@ -137,20 +149,99 @@ func (r *TransactionRepository) Create(ctx context.Context, input CreateTransact
 	return result, nil
 }
 
-// getExchangeRate retrieves exchange rate for a given date
-func (r *TransactionRepository) getExchangeRate(ctx context.Context, tx pgx.Tx, fromCurrency, toCurrency string, date time.Time) (decimal.Decimal, error) {
+// BalanceAdjustmentInput contains data for setting an account balance as of a date
+type BalanceAdjustmentInput struct {
+	AccountID     uuid.UUID
+	TargetBalance decimal.Decimal
+	Date          time.Time
+	CreatedBy     uuid.UUID
+}
+
+// BalanceAdjustmentResult describes the adjustment transaction that was created
+type BalanceAdjustmentResult struct {
+	Transaction     sqlc.Transaction
+	PreviousBalance decimal.Decimal
+	Difference      decimal.Decimal
+}
+
+// CreateBalanceAdjustment creates an adjustment transaction in the system
+// "Balance adjustment" category so that the account balance as of the given
+// date equals the target balance
+func (r *TransactionRepository) CreateBalanceAdjustment(ctx context.Context, input BalanceAdjustmentInput) (BalanceAdjustmentResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return BalanceAdjustmentResult{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Set user ID for audit trail
+	_, err = tx.Exec(ctx, fmt.Sprintf("SET LOCAL app.current_user_id = '%s'", input.CreatedBy.String()))
+	if err != nil {
+		return BalanceAdjustmentResult{}, fmt.Errorf("failed to set audit user: %w", err)
+	}
+
 	qtx := sqlc.New(tx)
 
-	rate, err := qtx.GetLatestExchangeRate(ctx, sqlc.GetLatestExchangeRateParams{
-		FromCurrency: fromCurrency,
-		ToCurrency:   toCurrency,
-		Date:         pgtype.Date{Time: date, Valid: true},
+	account, err := qtx.GetAccount(ctx, input.AccountID)
+	if err != nil {
+		return BalanceAdjustmentResult{}, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	balance, err := qtx.GetAccountBalanceAsOf(ctx, sqlc.GetAccountBalanceAsOfParams{
+		ID:              account.ID,
+		TransactionDate: pgtype.Date{Time: input.Date, Valid: true},
 	})
 	if err != nil {
-		return decimal.Zero, err
+		return BalanceAdjustmentResult{}, fmt.Errorf("failed to calculate balance: %w", err)
+	}
+
+	difference := input.TargetBalance.Sub(balance)
+	if difference.IsZero() {
+		return BalanceAdjustmentResult{}, ErrBalanceUnchanged
+	}
+
+	transactionType := "income"
+	if difference.IsNegative() {
+		transactionType = "expense"
+	}
+
+	category, err := ensureAdjustmentCategory(ctx, qtx, account.FamilyID, transactionType)
+	if err != nil {
+		return BalanceAdjustmentResult{}, fmt.Errorf("failed to get adjustment category: %w", err)
+	}
+
+	amount := difference.Abs()
+	amountBase, err := toBaseAmount(ctx, tx, account.FamilyID, amount, account.Currency, input.Date)
+	if err != nil {
+		return BalanceAdjustmentResult{}, err
 	}
 
-	return rate.Rate, nil
+	result, err := qtx.CreateTransaction(ctx, sqlc.CreateTransactionParams{
+		ID:              uuid.New(),
+		FamilyID:        account.FamilyID,
+		AccountID:       account.ID,
+		CategoryID:      category.ID,
+		Type:            transactionType,
+		Amount:          amount,
+		Currency:        account.Currency,
+		AmountBase:      amountBase,
+		Description:     pgtype.Text{String: BalanceAdjustmentCategoryName, Valid: true},
+		TransactionDate: pgtype.Date{Time: input.Date, Valid: true},
+		CreatedBy:       input.CreatedBy,
+	})
+	if err != nil {
+		return BalanceAdjustmentResult{}, fmt.Errorf("failed to create adjustment transaction: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return BalanceAdjustmentResult{}, fmt.Errorf("failed to commit: %w", err)
+	}
+
+	return BalanceAdjustmentResult{
+		Transaction:     result,
+		PreviousBalance: balance,
+		Difference:      difference,
+	}, nil
 }
 
 // UpdateTransactionInput contains data for updating a transaction
@@ -178,17 +269,18 @@ func (r *TransactionRepository) Update(ctx context.Context, input UpdateTransact
 		return sqlc.Transaction{}, fmt.Errorf("failed to set audit user: %w", err)
 	}
 
-	// Calculate amount_base
-	amountBase := input.Amount
-	if input.Currency != "RSD" {
-		rate, err := r.getExchangeRate(ctx, tx, input.Currency, "RSD", input.TransactionDate)
-		if err != nil {
-			return sqlc.Transaction{}, fmt.Errorf("failed to get exchange rate: %w", err)
-		}
-		amountBase = input.Amount.Mul(rate)
+	qtx := sqlc.New(tx)
+
+	existing, err := qtx.GetTransaction(ctx, input.ID)
+	if err != nil {
+		return sqlc.Transaction{}, fmt.Errorf("failed to get transaction: %w", err)
 	}
 
-	qtx := sqlc.New(tx)
+	// Calculate amount_base
+	amountBase, err := toBaseAmount(ctx, tx, existing.FamilyID, input.Amount, input.Currency, input.TransactionDate)
+	if err != nil {
+		return sqlc.Transaction{}, err
+	}
 
 	var description pgtype.Text
 	if input.Description != "" {
@@ -208,6 +300,12 @@ func (r *TransactionRepository) Update(ctx context.Context, input UpdateTransact
 		return sqlc.Transaction{}, fmt.Errorf("failed to update transaction: %w", err)
 	}
 
+	if result.Type == "expense" {
+		if err := evaluateAlerts(ctx, qtx, result.FamilyID, result.CategoryID, input.TransactionDate); err != nil {
+			return sqlc.Transaction{}, err
+		}
+	}
+
 	if err := tx.Commit(ctx); err != nil {
 		return sqlc.Transaction{}, fmt.Errorf("failed to commit: %w", err)
 	}
@@ -237,8 +335,25 @@ func (r *TransactionRepository) Delete(ctx context.Context, id uuid.UUID, delete
 	return tx.Commit(ctx)
 }
 
-// GetSummaryByType retrieves transaction summary grouped by type
+// GetSummaryByType retrieves transaction summary grouped by type.
+// Ranges of whole months are read from monthly aggregates.
 func (r *TransactionRepository) GetSummaryByType(ctx context.Context, familyID uuid.UUID, startDate, endDate time.Time) ([]sqlc.GetTransactionsSummaryByTypeRow, error) {
+	if wholeMonths(startDate, endDate) {
+		rows, err := r.queries.GetMonthlyAggregatesSummaryByType(ctx, sqlc.GetMonthlyAggregatesSummaryByTypeParams{
+			FamilyID:   familyID,
+			StartMonth: pgtype.Date{Time: startDate, Valid: true},
+			EndMonth:   pgtype.Date{Time: endDate, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		summaries := make([]sqlc.GetTransactionsSummaryByTypeRow, len(rows))
+		for i, row := range rows {
+			summaries[i] = sqlc.GetTransactionsSummaryByTypeRow(row)
+		}
+		return summaries, nil
+	}
+
 	return r.queries.GetTransactionsSummaryByType(ctx, sqlc.GetTransactionsSummaryByTypeParams{
 		FamilyID:          familyID,
 		TransactionDate:   pgtype.Date{Time: startDate, Valid: true},
@@ -246,8 +361,26 @@ func (r *TransactionRepository) GetSummaryByType(ctx context.Context, familyID u
 	})
 }
 
-// GetSummaryByCategory retrieves transaction summary grouped by category
+// GetSummaryByCategory retrieves transaction summary grouped by category.
+// Ranges of whole months are read from monthly aggregates.
 func (r *TransactionRepository) GetSummaryByCategory(ctx context.Context, familyID uuid.UUID, transactionType string, startDate, endDate time.Time) ([]sqlc.GetTransactionsSummaryByCategoryRow, error) {
+	if wholeMonths(startDate, endDate) {
+		rows, err := r.queries.GetMonthlyAggregatesSummaryByCategory(ctx, sqlc.GetMonthlyAggregatesSummaryByCategoryParams{
+			FamilyID:   familyID,
+			Type:       transactionType,
+			StartMonth: pgtype.Date{Time: startDate, Valid: true},
+			EndMonth:   pgtype.Date{Time: endDate, Valid: true},
+		})
+		if err != nil {
+			return nil, err
+		}
+		summaries := make([]sqlc.GetTransactionsSummaryByCategoryRow, len(rows))
+		for i, row := range rows {
+			summaries[i] = sqlc.GetTransactionsSummaryByCategoryRow(row)
+		}
+		return summaries, nil
+	}
+
 	return r.queries.GetTransactionsSummaryByCategory(ctx, sqlc.GetTransactionsSummaryByCategoryParams{
 		FamilyID:          familyID,
 		Type:              transactionType,
@@ -256,6 +389,79 @@ func (r *TransactionRepository) GetSummaryByCategory(ctx context.Context, family
 	})
 }
 
+// GetSummaryByCategoryWithNames retrieves transaction summary grouped by category, including category names
+func (r *TransactionRepository) GetSummaryByCategoryWithNames(ctx context.Context, familyID uuid.UUID, transactionType string, startDate, endDate time.Time) ([]sqlc.GetTransactionsSummaryByCategoryWithNamesRow, error) {
+	return r.queries.GetTransactionsSummaryByCategoryWithNames(ctx, sqlc.GetTransactionsSummaryByCategoryWithNamesParams{
+		FamilyID:  familyID,
+		Type:      transactionType,
+		StartDate: pgtype.Date{Time: startDate, Valid: true},
+		EndDate:   pgtype.Date{Time: endDate, Valid: true},
+	})
+}
+
+// GetMonthlyTrend retrieves monthly totals grouped by category, type or account.
+// transactionType nil = both income and expense
+func (r *TransactionRepository) GetMonthlyTrend(ctx context.Context, familyID uuid.UUID, groupBy string, transactionType *string, startDate, endDate time.Time) ([]sqlc.GetTransactionsMonthlyTrendRow, error) {
+	return r.queries.GetTransactionsMonthlyTrend(ctx, sqlc.GetTransactionsMonthlyTrendParams{
+		GroupBy:   groupBy,
+		FamilyID:  familyID,
+		StartDate: pgtype.Date{Time: startDate, Valid: true},
+		EndDate:   pgtype.Date{Time: endDate, Valid: true},
+		Type:      toPgText(transactionType),
+	})
+}
+
+// GetSummaryByMember retrieves transaction totals grouped by creator, type and category
+func (r *TransactionRepository) GetSummaryByMember(ctx context.Context, familyID uuid.UUID, startDate, endDate time.Time) ([]sqlc.GetTransactionsSummaryByMemberRow, error) {
+	return r.queries.GetTransactionsSummaryByMember(ctx, sqlc.GetTransactionsSummaryByMemberParams{
+		FamilyID:  familyID,
+		StartDate: pgtype.Date{Time: startDate, Valid: true},
+		EndDate:   pgtype.Date{Time: endDate, Valid: true},
+	})
+}
+
+// GetDailyTotals retrieves totals of one type per day, only days with transactions are returned
+func (r *TransactionRepository) GetDailyTotals(ctx context.Context, familyID uuid.UUID, transactionType string, startDate, endDate time.Time) ([]sqlc.GetTransactionsDailyTotalsRow, error) {
+	return r.queries.GetTransactionsDailyTotals(ctx, sqlc.GetTransactionsDailyTotalsParams{
+		FamilyID:  familyID,
+		StartDate: pgtype.Date{Time: startDate, Valid: true},
+		EndDate:   pgtype.Date{Time: endDate, Valid: true},
+		Type:      transactionType,
+	})
+}
+
+// GetTopDescriptions retrieves the largest totals per description (payee) in a period
+func (r *TransactionRepository) GetTopDescriptions(ctx context.Context, familyID uuid.UUID, transactionType string, startDate, endDate time.Time, limit int32) ([]sqlc.GetTransactionsTopDescriptionsRow, error) {
+	return r.queries.GetTransactionsTopDescriptions(ctx, sqlc.GetTransactionsTopDescriptionsParams{
+		FamilyID:  familyID,
+		Type:      transactionType,
+		StartDate: pgtype.Date{Time: startDate, Valid: true},
+		EndDate:   pgtype.Date{Time: endDate, Valid: true},
+		RowLimit:  limit,
+	})
+}
+
+// ListLargest retrieves the largest transactions of a type in a period, with details
+func (r *TransactionRepository) ListLargest(ctx context.Context, familyID uuid.UUID, transactionType string, startDate, endDate time.Time, limit int32) ([]TransactionWithDetails, error) {
+	rows, err := r.queries.ListLargestTransactionsWithDetails(ctx, sqlc.ListLargestTransactionsWithDetailsParams{
+		FamilyID:  familyID,
+		Type:      transactionType,
+		StartDate: pgtype.Date{Time: startDate, Valid: true},
+		EndDate:   pgtype.Date{Time: endDate, Valid: true},
+		RowLimit:  limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]TransactionWithDetails, len(rows))
+	for i, row := range rows {
+		transactions[i] = transactionWithDetails(sqlc.ListTransactionsFilteredWithDetailsRow(row))
+	}
+
+	return transactions, nil
+}
+
 // TransactionFilter contains filter options for listing transactions
 type TransactionFilter struct {
 	FamilyID  uuid.UUID
@@ -267,50 +473,64 @@ type TransactionFilter struct {
 	Offset    int32
 }
 
-// ListFiltered retrieves transactions with filters and pagination
-func (r *TransactionRepository) ListFiltered(ctx context.Context, filter TransactionFilter) ([]sqlc.Transaction, int64, error) {
-	// Build params - empty string/zero UUID means "no filter" in SQL
-	typeFilter := ""
-	if filter.Type != nil {
-		typeFilter = *filter.Type
-	}
-
-	var accountFilter uuid.UUID // zero UUID
-	if filter.AccountID != nil {
-		accountFilter = *filter.AccountID
-	}
+// TransactionWithDetails is a transaction with names of its category, account and creator
+type TransactionWithDetails struct {
+	sqlc.Transaction
+	CategoryName  string
+	CategoryType  string
+	AccountName   string
+	AccountType   string
+	CreatedByName string
+}
 
-	var startDate pgtype.Date
-	if filter.StartDate != nil {
-		startDate = pgtype.Date{Time: *filter.StartDate, Valid: true}
+// GetByIDWithDetails retrieves a transaction with category, account and creator names in one query
+func (r *TransactionRepository) GetByIDWithDetails(ctx context.Context, id uuid.UUID) (TransactionWithDetails, error) {
+	row, err := r.queries.GetTransactionWithDetails(ctx, id)
+	if err != nil {
+		return TransactionWithDetails{}, err
 	}
 
-	var endDate pgtype.Date
-	if filter.EndDate != nil {
-		endDate = pgtype.Date{Time: *filter.EndDate, Valid: true}
-	}
+	return TransactionWithDetails{
+		Transaction: sqlc.Transaction{
+			ID:              row.ID,
+			FamilyID:        row.FamilyID,
+			AccountID:       row.AccountID,
+			CategoryID:      row.CategoryID,
+			Type:            row.Type,
+			Amount:          row.Amount,
+			Currency:        row.Currency,
+			AmountBase:      row.AmountBase,
+			Description:     row.Description,
+			TransactionDate: row.TransactionDate,
+			CreatedBy:       row.CreatedBy,
+			CreatedAt:       row.CreatedAt,
+			UpdatedAt:       row.UpdatedAt,
+			IsActive:        row.IsActive,
+		},
+		CategoryName:  row.CategoryName,
+		CategoryType:  row.CategoryType,
+		AccountName:   row.AccountName,
+		AccountType:   row.AccountType,
+		CreatedByName: row.CreatedByName,
+	}, nil
+}
 
-	// Get transactions
-	transactions, err := r.queries.ListTransactionsFiltered(ctx, sqlc.ListTransactionsFilteredParams{
-		FamilyID: filter.FamilyID,
-		Column2:  typeFilter,
-		Column3:  accountFilter,
-		Column4:  startDate,
-		Column5:  endDate,
-		Limit:    filter.Limit,
-		Offset:   filter.Offset,
-	})
+// ListFiltered retrieves transactions with filters and pagination.
+// Category, account and creator names are joined, so a page costs two queries.
+func (r *TransactionRepository) ListFiltered(ctx context.Context, filter TransactionFilter) ([]TransactionWithDetails, int64, error) {
+	transactions, err := r.listFilteredPage(ctx, filter)
 	if err != nil {
 		return nil, 0, err
 	}
 
 	// Get total count
+	params := filterParams(filter)
 	total, err := r.queries.CountTransactionsFiltered(ctx, sqlc.CountTransactionsFilteredParams{
 		FamilyID: filter.FamilyID,
-		Column2:  typeFilter,
-		Column3:  accountFilter,
-		Column4:  startDate,
-		Column5:  endDate,
+		Column2:  params.TypeFilter,
+		Column3:  params.AccountFilter,
+		Column4:  params.StartDate,
+		Column5:  params.EndDate,
 	})
 	if err != nil {
 		return nil, 0, err
@@ -319,7 +539,158 @@ func (r *TransactionRepository) ListFiltered(ctx context.Context, filter Transac
 	return transactions, total, nil
 }
 
+// listFilteredPage retrieves one page of filtered transactions with details
+func (r *TransactionRepository) listFilteredPage(ctx context.Context, filter TransactionFilter) ([]TransactionWithDetails, error) {
+	rows, err := r.queries.ListTransactionsFilteredWithDetails(ctx, filterParams(filter))
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]TransactionWithDetails, len(rows))
+	for i, row := range rows {
+		transactions[i] = transactionWithDetails(row)
+	}
+
+	return transactions, nil
+}
+
+// transactionWithDetails converts a joined row; rows of the other detail
+// queries have the same columns and convert to this row type
+func transactionWithDetails(row sqlc.ListTransactionsFilteredWithDetailsRow) TransactionWithDetails {
+	return TransactionWithDetails{
+		Transaction: sqlc.Transaction{
+			ID:              row.ID,
+			FamilyID:        row.FamilyID,
+			AccountID:       row.AccountID,
+			CategoryID:      row.CategoryID,
+			Type:            row.Type,
+			Amount:          row.Amount,
+			Currency:        row.Currency,
+			AmountBase:      row.AmountBase,
+			Description:     row.Description,
+			TransactionDate: row.TransactionDate,
+			CreatedBy:       row.CreatedBy,
+			CreatedAt:       row.CreatedAt,
+			UpdatedAt:       row.UpdatedAt,
+			IsActive:        row.IsActive,
+		},
+		CategoryName:  row.CategoryName,
+		CategoryType:  row.CategoryType,
+		AccountName:   row.AccountName,
+		AccountType:   row.AccountType,
+		CreatedByName: row.CreatedByName,
+	}
+}
+
+// wholeMonths reports whether a date range starts on the first day of a month
+// and ends on the last day of a month, so it can be read from monthly aggregates
+func wholeMonths(startDate, endDate time.Time) bool {
+	return startDate.Day() == 1 && endDate.AddDate(0, 0, 1).Day() == 1 && !endDate.Before(startDate)
+}
+
+// filterParams converts a filter to query params - empty string/zero UUID means "no filter" in SQL
+func filterParams(filter TransactionFilter) sqlc.ListTransactionsFilteredWithDetailsParams {
+	params := sqlc.ListTransactionsFilteredWithDetailsParams{
+		FamilyID:  filter.FamilyID,
+		RowLimit:  filter.Limit,
+		RowOffset: filter.Offset,
+	}
+
+	if filter.Type != nil {
+		params.TypeFilter = *filter.Type
+	}
+
+	if filter.AccountID != nil {
+		params.AccountFilter = *filter.AccountID
+	}
+
+	if filter.StartDate != nil {
+		params.StartDate = pgtype.Date{Time: *filter.StartDate, Valid: true}
+	}
+
+	if filter.EndDate != nil {
+		params.EndDate = pgtype.Date{Time: *filter.EndDate, Valid: true}
+	}
+
+	return params
+}
+
+// exportBatchSize is the number of transactions loaded per query while exporting
+const exportBatchSize = 500
+
+// EachFiltered calls fn for every transaction matching the filter, loading them in batches.
+// Batches continue after the last loaded row (date, created_at, id), so rows written
+// during the export do not shift later batches. Limit and Offset of the filter are
+// ignored. Used to stream large exports.
+func (r *TransactionRepository) EachFiltered(ctx context.Context, filter TransactionFilter, fn func(TransactionWithDetails) error) error {
+	page := filterParams(filter)
+	params := sqlc.ListTransactionsFilteredAfterParams{
+		FamilyID:      page.FamilyID,
+		TypeFilter:    page.TypeFilter,
+		AccountFilter: page.AccountFilter,
+		StartDate:     page.StartDate,
+		EndDate:       page.EndDate,
+		RowLimit:      exportBatchSize,
+	}
+
+	for {
+		rows, err := r.queries.ListTransactionsFilteredAfter(ctx, params)
+		if err != nil {
+			return fmt.Errorf("failed to list transactions: %w", err)
+		}
+
+		for _, row := range rows {
+			if err := fn(transactionWithDetails(sqlc.ListTransactionsFilteredWithDetailsRow(row))); err != nil {
+				return err
+			}
+		}
+
+		if len(rows) < exportBatchSize {
+			return nil
+		}
+
+		last := rows[len(rows)-1]
+		params.AfterID = pgtype.UUID{Bytes: last.ID, Valid: true}
+		params.AfterDate = last.TransactionDate
+		params.AfterCreatedAt = pgtype.Timestamp{Time: last.CreatedAt, Valid: true}
+	}
+}
+
 // GetByIDIncludingInactive retrieves a transaction by ID (even if inactive)
 func (r *TransactionRepository) GetByIDIncludingInactive(ctx context.Context, id uuid.UUID) (sqlc.Transaction, error) {
 	return r.queries.GetTransactionIncludingInactive(ctx, id)
 }
+
+// ensureAdjustmentCategory returns the system category of the family for balance
+// corrections, creating it if needed. User categories with the same name are
+// never converted: the system category then gets the reserved name.
+func ensureAdjustmentCategory(ctx context.Context, qtx *sqlc.Queries, familyID uuid.UUID, categoryType string) (sqlc.Category, error) {
+	category, err := qtx.GetSystemCategory(ctx, sqlc.GetSystemCategoryParams{
+		FamilyID: familyID,
+		Type:     categoryType,
+	})
+	if err == nil {
+		return category, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.Category{}, err
+	}
+
+	for _, name := range []string{BalanceAdjustmentCategoryName, balanceAdjustmentReservedName} {
+		category, err = qtx.CreateSystemCategory(ctx, sqlc.CreateSystemCategoryParams{
+			ID:       uuid.New(),
+			FamilyID: familyID,
+			Name:     name,
+			Type:     categoryType,
+		})
+		if err == nil {
+			return category, nil
+		}
+		// Name is taken by a user category
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.Category{}, err
+		}
+	}
+
+	return sqlc.Category{}, fmt.Errorf("category names %q and %q are taken", BalanceAdjustmentCategoryName, balanceAdjustmentReservedName)
+}